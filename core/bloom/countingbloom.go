@@ -0,0 +1,212 @@
+package bloom
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/qkbyte/go-zero/core/hash"
+	"github.com/qkbyte/go-zero/core/stores/redis"
+)
+
+const (
+	countingAddScript = `
+for _, offset in ipairs(ARGV) do
+	redis.call("hincrby", KEYS[1], offset, 1)
+end
+`
+	countingRemoveScript = `
+for _, offset in ipairs(ARGV) do
+	local count = tonumber(redis.call("hget", KEYS[1], offset))
+	if not count or count == 0 then
+		return false
+	end
+end
+for _, offset in ipairs(ARGV) do
+	if tonumber(redis.call("hincrby", KEYS[1], offset, -1)) <= 0 then
+		redis.call("hdel", KEYS[1], offset)
+	end
+end
+return true
+`
+	countingTestScript = `
+for _, offset in ipairs(ARGV) do
+	local count = tonumber(redis.call("hget", KEYS[1], offset))
+	if not count or count == 0 then
+		return false
+	end
+end
+return true
+`
+)
+
+// A CountingFilter is a counting bloom filter that supports removal,
+// the counters are kept in a redis hash keyed by bucket offset.
+type CountingFilter struct {
+	bits uint
+	maps uint
+	set  *redisCountSet
+}
+
+// NewCounting creates a CountingFilter, store is the backed redis, key is the
+// key for the filter, bits is how many counting buckets will be used,
+// maps hashes are used for each addition like Filter.
+func NewCounting(store *redis.Redis, key string, bits uint) *CountingFilter {
+	return &CountingFilter{
+		bits: bits,
+		maps: maps,
+		set:  newRedisCountSet(store, key, bits),
+	}
+}
+
+// NewCountingWithEstimates creates a CountingFilter sized for the expected
+// number of elements capacity with false positive rate fpp.
+func NewCountingWithEstimates(store *redis.Redis, key string, capacity uint,
+	fpp float64) *CountingFilter {
+	bits := OptimalBits(capacity, fpp)
+	return &CountingFilter{
+		bits: bits,
+		maps: OptimalMaps(capacity, bits),
+		set:  newRedisCountSet(store, key, bits),
+	}
+}
+
+// OptimalBits returns the number of buckets for the expected number of
+// elements capacity with false positive rate fpp.
+func OptimalBits(capacity uint, fpp float64) uint {
+	return uint(math.Ceil(-float64(capacity) * math.Log(fpp) / (math.Ln2 * math.Ln2)))
+}
+
+// OptimalMaps returns the number of hashes per addition for the expected
+// number of elements capacity on bits buckets.
+func OptimalMaps(capacity, bits uint) uint {
+	k := uint(math.Round(float64(bits) / float64(capacity) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return k
+}
+
+// Add adds data into f.
+func (f *CountingFilter) Add(data []byte) error {
+	locations := f.getLocations(data)
+	return f.set.add(locations)
+}
+
+// Exists checks if data is in f.
+func (f *CountingFilter) Exists(data []byte) (bool, error) {
+	locations := f.getLocations(data)
+	return f.set.check(locations)
+}
+
+// Remove removes data from f, returns false if data was not in f.
+// Removing data that was never added corrupts the counters,
+// like any counting bloom filter.
+func (f *CountingFilter) Remove(data []byte) (bool, error) {
+	locations := f.getLocations(data)
+	return f.set.remove(locations)
+}
+
+// Expire sets the ttl of the whole filter in seconds,
+// useful for rolling window use cases.
+func (f *CountingFilter) Expire(seconds int) error {
+	return f.set.expire(seconds)
+}
+
+func (f *CountingFilter) getLocations(data []byte) []uint {
+	locations := make([]uint, f.maps)
+	for i := uint(0); i < f.maps; i++ {
+		hashValue := hash.Hash(append(data, byte(i)))
+		locations[i] = uint(hashValue % uint64(f.bits))
+	}
+
+	return locations
+}
+
+type redisCountSet struct {
+	store *redis.Redis
+	key   string
+	bits  uint
+}
+
+func newRedisCountSet(store *redis.Redis, key string, bits uint) *redisCountSet {
+	return &redisCountSet{
+		store: store,
+		key:   key,
+		bits:  bits,
+	}
+}
+
+func (r *redisCountSet) buildOffsetArgs(offsets []uint) ([]string, error) {
+	var args []string
+
+	for _, offset := range offsets {
+		if offset >= r.bits {
+			return nil, ErrTooLargeOffset
+		}
+
+		args = append(args, strconv.FormatUint(uint64(offset), 10))
+	}
+
+	return args, nil
+}
+
+func (r *redisCountSet) add(offsets []uint) error {
+	args, err := r.buildOffsetArgs(offsets)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.store.Eval(countingAddScript, []string{r.key}, args)
+	if err == redis.Nil {
+		return nil
+	}
+
+	return err
+}
+
+func (r *redisCountSet) check(offsets []uint) (bool, error) {
+	args, err := r.buildOffsetArgs(offsets)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := r.store.Eval(countingTestScript, []string{r.key}, args)
+	if err == redis.Nil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	exists, ok := resp.(int64)
+	if !ok {
+		return false, nil
+	}
+
+	return exists == 1, nil
+}
+
+func (r *redisCountSet) expire(seconds int) error {
+	return r.store.Expire(r.key, seconds)
+}
+
+func (r *redisCountSet) remove(offsets []uint) (bool, error) {
+	args, err := r.buildOffsetArgs(offsets)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := r.store.Eval(countingRemoveScript, []string{r.key}, args)
+	if err == redis.Nil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	removed, ok := resp.(int64)
+	if !ok {
+		return false, nil
+	}
+
+	return removed == 1, nil
+}
@@ -0,0 +1,69 @@
+package bloom
+
+import (
+	"testing"
+
+	"github.com/qkbyte/go-zero/core/stores/redis/redistest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountingFilter(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	filter := NewCounting(store, "test_key", 1024)
+	assert.Nil(t, filter.Add([]byte("hello")))
+	assert.Nil(t, filter.Add([]byte("hello")))
+	assert.Nil(t, filter.Add([]byte("world")))
+
+	ok, err := filter.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// removing once keeps the second addition visible.
+	removed, err := filter.Remove([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, removed)
+	ok, err = filter.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	removed, err = filter.Remove([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, removed)
+	ok, err = filter.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// removing a missing element is reported and leaves the filter intact.
+	removed, err = filter.Remove([]byte("hello"))
+	assert.Nil(t, err)
+	assert.False(t, removed)
+	ok, err = filter.Exists([]byte("world"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	assert.Nil(t, filter.Expire(3600))
+}
+
+func TestCountingFilterWithEstimates(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	filter := NewCountingWithEstimates(store, "test_key", 1000, 1e-4)
+	assert.True(t, filter.bits > 1000)
+	assert.True(t, filter.maps >= 1)
+	assert.Nil(t, filter.Add([]byte("hello")))
+	ok, err := filter.Exists([]byte("hello"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestOptimalSizing(t *testing.T) {
+	bits := OptimalBits(1000, 0.01)
+	assert.Equal(t, uint(9586), bits)
+	assert.Equal(t, uint(7), OptimalMaps(1000, bits))
+	assert.Equal(t, uint(1), OptimalMaps(1000, 1))
+}
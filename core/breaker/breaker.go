@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/qkbyte/go-zero/core/mathx"
@@ -16,11 +17,44 @@ import (
 const (
 	numHistoryReasons = 5
 	timeFormat        = "15:04:05"
+	// share of requests dropped with the breaker manually half-open.
+	halfOpenDropRatio = 0.9
 )
 
 // ErrServiceUnavailable is returned when the Breaker state is open.
 var ErrServiceUnavailable = errors.New("circuit breaker is open")
 
+// A State is the state of a Breaker.
+type State int32
+
+const (
+	// StateClosed indicates a closed breaker, requests pass through.
+	StateClosed State = iota
+	// StateHalfOpen indicates a half-open breaker, a small share of
+	// requests is let through to probe the dependency.
+	StateHalfOpen
+	// StateOpen indicates an open breaker, requests are rejected.
+	StateOpen
+
+	// stateAuto marks a breaker without manual override,
+	// the state is derived from the collected stats.
+	stateAuto State = -1
+)
+
+// String returns the readable name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
 type (
 	// Acceptable is the func to check if the error can be accepted.
 	Acceptable func(err error) bool
@@ -61,8 +95,26 @@ type (
 		// and causes the same panic again.
 		// acceptable checks if it's a successful call, even if the err is not nil.
 		DoWithFallbackAcceptable(req func() error, fallback func(err error) error, acceptable Acceptable) error
+
+		// State returns the current state of the Breaker.
+		State() State
+
+		// Open manually trips the Breaker, all requests are rejected
+		// until Close or HalfOpen is called.
+		Open()
+
+		// HalfOpen manually puts the Breaker half-open, a small share of
+		// requests is let through to probe the dependency.
+		HalfOpen()
+
+		// Close lifts the manual override and puts the Breaker back
+		// to automatic operation.
+		Close()
 	}
 
+	// StateHandler is called on state changes of a Breaker.
+	StateHandler func(name string, from, to State)
+
 	// Option defines the method to customize a Breaker.
 	Option func(breaker *circuitBreaker)
 
@@ -79,11 +131,25 @@ type (
 		Reject()
 	}
 
+	// algorithmFunc creates the throttle implementing the breaking policy.
+	algorithmFunc func(window time.Duration, buckets int) internalThrottle
+
 	circuitBreaker struct {
-		name string
+		name         string
+		window       time.Duration
+		buckets      int
+		algorithm    algorithmFunc
+		mode         int32
+		lastState    int32
+		stateHandler StateHandler
+		proba        *mathx.Proba
 		throttle
 	}
 
+	stateProvider interface {
+		currentState() State
+	}
+
 	internalThrottle interface {
 		allow() (internalPromise, error)
 		doReq(req func() error, fallback func(err error) error, acceptable Acceptable) error
@@ -105,36 +171,141 @@ func NewBreaker(opts ...Option) Breaker {
 	if len(b.name) == 0 {
 		b.name = stringx.Rand()
 	}
-	b.throttle = newLoggedThrottle(b.name, newGoogleBreaker())
+	if b.window <= 0 {
+		b.window = window
+	}
+	if b.buckets <= 0 {
+		b.buckets = buckets
+	}
+	if b.algorithm == nil {
+		b.algorithm = func(window time.Duration, buckets int) internalThrottle {
+			return newGoogleBreakerWithWindow(window, buckets)
+		}
+	}
+	b.mode = int32(stateAuto)
+	b.proba = mathx.NewProba()
+	b.throttle = newLoggedThrottle(b.name, b.algorithm(b.window, b.buckets))
 
 	return &b
 }
 
 func (cb *circuitBreaker) Allow() (Promise, error) {
+	cb.reportState()
+	if err := cb.accept(); err != nil {
+		return nil, err
+	}
+
 	return cb.throttle.allow()
 }
 
 func (cb *circuitBreaker) Do(req func() error) error {
-	return cb.throttle.doReq(req, nil, defaultAcceptable)
+	return cb.doReq(req, nil, defaultAcceptable)
 }
 
 func (cb *circuitBreaker) DoWithAcceptable(req func() error, acceptable Acceptable) error {
-	return cb.throttle.doReq(req, nil, acceptable)
+	return cb.doReq(req, nil, acceptable)
 }
 
 func (cb *circuitBreaker) DoWithFallback(req func() error, fallback func(err error) error) error {
-	return cb.throttle.doReq(req, fallback, defaultAcceptable)
+	return cb.doReq(req, fallback, defaultAcceptable)
 }
 
 func (cb *circuitBreaker) DoWithFallbackAcceptable(req func() error, fallback func(err error) error,
 	acceptable Acceptable) error {
-	return cb.throttle.doReq(req, fallback, acceptable)
+	return cb.doReq(req, fallback, acceptable)
 }
 
 func (cb *circuitBreaker) Name() string {
 	return cb.name
 }
 
+func (cb *circuitBreaker) State() State {
+	if mode := State(atomic.LoadInt32(&cb.mode)); mode != stateAuto {
+		return mode
+	}
+	if provider, ok := cb.throttle.(stateProvider); ok {
+		return provider.currentState()
+	}
+
+	return StateClosed
+}
+
+func (cb *circuitBreaker) Open() {
+	atomic.StoreInt32(&cb.mode, int32(StateOpen))
+	cb.reportState()
+}
+
+func (cb *circuitBreaker) HalfOpen() {
+	atomic.StoreInt32(&cb.mode, int32(StateHalfOpen))
+	cb.reportState()
+}
+
+func (cb *circuitBreaker) Close() {
+	atomic.StoreInt32(&cb.mode, int32(stateAuto))
+	cb.reportState()
+}
+
+// accept applies the manual override, automatic breaking is left
+// to the underlying throttle.
+func (cb *circuitBreaker) accept() error {
+	switch State(atomic.LoadInt32(&cb.mode)) {
+	case StateOpen:
+		metricDrops.Inc(cb.name)
+		return ErrServiceUnavailable
+	case StateHalfOpen:
+		if cb.proba.TrueOnProba(halfOpenDropRatio) {
+			metricDrops.Inc(cb.name)
+			return ErrServiceUnavailable
+		}
+	}
+
+	return nil
+}
+
+func (cb *circuitBreaker) doReq(req func() error, fallback func(err error) error,
+	acceptable Acceptable) error {
+	cb.reportState()
+	if err := cb.accept(); err != nil {
+		if fallback != nil {
+			return fallback(err)
+		}
+
+		return err
+	}
+
+	return cb.throttle.doReq(req, fallback, acceptable)
+}
+
+// reportState updates the state gauge and fires the state handler
+// with the state changed since the last report.
+func (cb *circuitBreaker) reportState() {
+	state := cb.State()
+	last := State(atomic.SwapInt32(&cb.lastState, int32(state)))
+	if last == state {
+		return
+	}
+
+	metricState.Set(float64(state), cb.name)
+	if cb.stateHandler != nil {
+		cb.stateHandler(cb.name, last, state)
+	}
+	notifyStateChange(StateChange{
+		Name:    cb.name,
+		From:    last,
+		To:      state,
+		Reasons: cb.reasons(),
+	})
+}
+
+// reasons returns the recent errors collected by the breaker.
+func (cb *circuitBreaker) reasons() string {
+	if lt, ok := cb.throttle.(loggedThrottle); ok {
+		return lt.errWin.String()
+	}
+
+	return ""
+}
+
 // WithName returns a function to set the name of a Breaker.
 func WithName(name string) Option {
 	return func(b *circuitBreaker) {
@@ -142,6 +313,46 @@ func WithName(name string) Option {
 	}
 }
 
+// WithWindow returns a function to set the rolling window of a Breaker,
+// a larger window makes the Breaker less sensitive to short error bursts.
+func WithWindow(window time.Duration, buckets int) Option {
+	return func(b *circuitBreaker) {
+		b.window = window
+		b.buckets = buckets
+	}
+}
+
+// WithStateHandler returns a function to set the state handler of a Breaker,
+// handler is called on each state change.
+func WithStateHandler(handler StateHandler) Option {
+	return func(b *circuitBreaker) {
+		b.stateHandler = handler
+	}
+}
+
+// WithConsecutiveFailures returns a function to select the consecutive-failure
+// policy: the Breaker opens after failures successive failures and probes the
+// dependency again once cooldown passed. Predictable for low-QPS clients where
+// the adaptive google algorithm hardly accumulates stats.
+func WithConsecutiveFailures(failures uint32, cooldown time.Duration) Option {
+	return func(b *circuitBreaker) {
+		b.algorithm = func(_ time.Duration, _ int) internalThrottle {
+			return newConsecutiveBreaker(failures, cooldown)
+		}
+	}
+}
+
+// WithErrorRate returns a function to select the error-rate policy: the Breaker
+// opens when at least minVolume requests were seen in the rolling window and
+// rate of them failed, after cooldown a single probe request is let through.
+func WithErrorRate(rate float64, minVolume int64, cooldown time.Duration) Option {
+	return func(b *circuitBreaker) {
+		b.algorithm = func(window time.Duration, buckets int) internalThrottle {
+			return newErrorRateBreaker(rate, minVolume, cooldown, window, buckets)
+		}
+	}
+}
+
 func defaultAcceptable(err error) bool {
 	return err == nil
 }
@@ -178,8 +389,17 @@ func (lt loggedThrottle) doReq(req func() error, fallback func(err error) error,
 	}))
 }
 
+func (lt loggedThrottle) currentState() State {
+	if provider, ok := lt.internalThrottle.(stateProvider); ok {
+		return provider.currentState()
+	}
+
+	return StateClosed
+}
+
 func (lt loggedThrottle) logError(err error) error {
 	if err == ErrServiceUnavailable {
+		metricDrops.Inc(lt.name)
 		// if circuit open, not possible to have empty error window
 		stat.Report(fmt.Sprintf(
 			"proc(%s/%d), callee: %s, breaker is open and requests dropped\nlast errors:\n%s",
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/qkbyte/go-zero/core/stat"
 	"github.com/stretchr/testify/assert"
@@ -129,3 +131,80 @@ func (m *mockedPromise) Accept() {
 
 func (m *mockedPromise) Reject() {
 }
+
+func TestBreakerWithWindow(t *testing.T) {
+	b := NewBreaker(WithName("any"), WithWindow(time.Second, 10))
+	assert.Equal(t, "any", b.Name())
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, b.Do(func() error {
+			return nil
+		}))
+	}
+}
+
+func TestBreakerManualControl(t *testing.T) {
+	b := NewBreaker(WithName("manual"))
+	assert.Equal(t, StateClosed, b.State())
+
+	b.Open()
+	assert.Equal(t, StateOpen, b.State())
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, ErrServiceUnavailable, b.Do(func() error {
+			return nil
+		}))
+	}
+	assert.Equal(t, ErrServiceUnavailable, b.DoWithFallback(func() error {
+		return nil
+	}, func(err error) error {
+		return err
+	}))
+	_, err := b.Allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+
+	b.HalfOpen()
+	assert.Equal(t, StateHalfOpen, b.State())
+	for i := 0; i < 100; i++ {
+		if err := b.Do(func() error {
+			return nil
+		}); err != nil {
+			assert.Equal(t, ErrServiceUnavailable, err)
+		}
+	}
+
+	b.Close()
+	assert.Equal(t, StateClosed, b.State())
+	assert.Nil(t, b.Do(func() error {
+		return nil
+	}))
+}
+
+func TestBreakerStateHandler(t *testing.T) {
+	var lock sync.Mutex
+	var changes []State
+	b := NewBreaker(WithName("handled"), WithStateHandler(func(name string, from, to State) {
+		assert.Equal(t, "handled", name)
+		lock.Lock()
+		changes = append(changes, to)
+		lock.Unlock()
+	}))
+
+	b.Open()
+	assert.Equal(t, ErrServiceUnavailable, b.Do(func() error {
+		return nil
+	}))
+	b.Close()
+	assert.Nil(t, b.Do(func() error {
+		return nil
+	}))
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []State{StateOpen, StateClosed}, changes)
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "unknown", State(10).String())
+}
@@ -0,0 +1,116 @@
+package breaker
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/timex"
+)
+
+const (
+	defaultConsecutiveFailures = 5
+	defaultCooldown            = time.Second * 5
+)
+
+// consecutiveBreaker opens after threshold successive failures, and lets
+// requests probe the dependency again once cooldown passed. A much simpler
+// policy than the google one, predictable for low-QPS clients.
+type consecutiveBreaker struct {
+	threshold uint32
+	cooldown  time.Duration
+	failures  uint32
+	openUntil int64
+}
+
+func newConsecutiveBreaker(threshold uint32, cooldown time.Duration) *consecutiveBreaker {
+	if threshold == 0 {
+		threshold = defaultConsecutiveFailures
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+
+	return &consecutiveBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+func (b *consecutiveBreaker) accept() error {
+	if timex.Now() < time.Duration(atomic.LoadInt64(&b.openUntil)) {
+		return ErrServiceUnavailable
+	}
+
+	return nil
+}
+
+func (b *consecutiveBreaker) allow() (internalPromise, error) {
+	if err := b.accept(); err != nil {
+		return nil, err
+	}
+
+	return consecutivePromise{
+		b: b,
+	}, nil
+}
+
+func (b *consecutiveBreaker) doReq(req func() error, fallback func(err error) error,
+	acceptable Acceptable) error {
+	if err := b.accept(); err != nil {
+		if fallback != nil {
+			return fallback(err)
+		}
+
+		return err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			b.markFailure()
+			panic(e)
+		}
+	}()
+
+	err := req()
+	if acceptable(err) {
+		b.markSuccess()
+	} else {
+		b.markFailure()
+	}
+
+	return err
+}
+
+func (b *consecutiveBreaker) markSuccess() {
+	atomic.StoreUint32(&b.failures, 0)
+	atomic.StoreInt64(&b.openUntil, 0)
+}
+
+func (b *consecutiveBreaker) markFailure() {
+	if atomic.AddUint32(&b.failures, 1) >= b.threshold {
+		atomic.StoreInt64(&b.openUntil, int64(timex.Now()+b.cooldown))
+	}
+}
+
+func (b *consecutiveBreaker) currentState() State {
+	if timex.Now() < time.Duration(atomic.LoadInt64(&b.openUntil)) {
+		return StateOpen
+	}
+	if atomic.LoadUint32(&b.failures) >= b.threshold {
+		return StateHalfOpen
+	}
+
+	return StateClosed
+}
+
+type consecutivePromise struct {
+	b *consecutiveBreaker
+}
+
+func (p consecutivePromise) Accept() {
+	p.b.markSuccess()
+}
+
+func (p consecutivePromise) Reject() {
+	p.b.markFailure()
+}
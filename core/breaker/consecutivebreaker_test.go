@@ -0,0 +1,91 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsecutiveBreaker(t *testing.T) {
+	b := newConsecutiveBreaker(3, time.Millisecond*10)
+
+	for i := 0; i < 2; i++ {
+		assert.NotNil(t, b.doReq(func() error {
+			return errors.New("any")
+		}, nil, defaultAcceptable))
+	}
+	// a success resets the failure streak.
+	assert.Nil(t, b.doReq(func() error {
+		return nil
+	}, nil, defaultAcceptable))
+	assert.Equal(t, StateClosed, b.currentState())
+
+	for i := 0; i < 3; i++ {
+		assert.NotNil(t, b.doReq(func() error {
+			return errors.New("any")
+		}, nil, defaultAcceptable))
+	}
+	assert.Equal(t, StateOpen, b.currentState())
+	assert.Equal(t, ErrServiceUnavailable, b.doReq(func() error {
+		return nil
+	}, nil, defaultAcceptable))
+
+	time.Sleep(time.Millisecond * 20)
+	assert.Equal(t, StateHalfOpen, b.currentState())
+	assert.Nil(t, b.doReq(func() error {
+		return nil
+	}, nil, defaultAcceptable))
+	assert.Equal(t, StateClosed, b.currentState())
+}
+
+func TestConsecutiveBreakerFallback(t *testing.T) {
+	errDummy := errors.New("dummy")
+	b := newConsecutiveBreaker(1, time.Minute)
+	assert.NotNil(t, b.doReq(func() error {
+		return errors.New("any")
+	}, nil, defaultAcceptable))
+	assert.Equal(t, errDummy, b.doReq(func() error {
+		return nil
+	}, func(err error) error {
+		return errDummy
+	}, defaultAcceptable))
+}
+
+func TestConsecutiveBreakerDefaults(t *testing.T) {
+	b := newConsecutiveBreaker(0, 0)
+	assert.Equal(t, uint32(defaultConsecutiveFailures), b.threshold)
+	assert.Equal(t, defaultCooldown, b.cooldown)
+}
+
+func TestConsecutiveBreakerPromise(t *testing.T) {
+	b := newConsecutiveBreaker(1, time.Minute)
+	p, err := b.allow()
+	assert.Nil(t, err)
+	p.Reject()
+	assert.Equal(t, StateOpen, b.currentState())
+	_, err = b.allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+}
+
+func TestConsecutiveBreakerPanic(t *testing.T) {
+	b := newConsecutiveBreaker(1, time.Minute)
+	assert.Panics(t, func() {
+		_ = b.doReq(func() error {
+			panic("fail")
+		}, nil, defaultAcceptable)
+	})
+	assert.Equal(t, StateOpen, b.currentState())
+}
+
+func TestNewBreakerWithConsecutiveFailures(t *testing.T) {
+	b := NewBreaker(WithConsecutiveFailures(1, time.Minute))
+	assert.NotNil(t, b.Do(func() error {
+		return errors.New("any")
+	}))
+	assert.Equal(t, ErrServiceUnavailable, b.Do(func() error {
+		return nil
+	}))
+	assert.Equal(t, StateOpen, b.State())
+}
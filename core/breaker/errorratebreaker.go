@@ -0,0 +1,181 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/collection"
+	"github.com/qkbyte/go-zero/core/timex"
+)
+
+const (
+	defaultErrorRate = 0.5
+	defaultMinVolume = 20
+)
+
+// errorRateBreaker is a hystrix-like policy: the breaker opens when at least
+// minVolume requests were seen in the rolling window and rate of them failed,
+// after cooldown a single probe request is let through, closing the breaker
+// on success and re-opening it on failure.
+type errorRateBreaker struct {
+	rate      float64
+	minVolume int64
+	cooldown  time.Duration
+	window    time.Duration
+	buckets   int
+	lock      sync.Mutex
+	stat      *collection.RollingWindow
+	openUntil time.Duration
+	probing   bool
+}
+
+func newErrorRateBreaker(rate float64, minVolume int64, cooldown time.Duration,
+	window time.Duration, buckets int) *errorRateBreaker {
+	if rate <= 0 || rate > 1 {
+		rate = defaultErrorRate
+	}
+	if minVolume <= 0 {
+		minVolume = defaultMinVolume
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+
+	return &errorRateBreaker{
+		rate:      rate,
+		minVolume: minVolume,
+		cooldown:  cooldown,
+		window:    window,
+		buckets:   buckets,
+		stat:      collection.NewRollingWindow(buckets, time.Duration(int64(window)/int64(buckets))),
+	}
+}
+
+func (b *errorRateBreaker) accept() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.openUntil > 0 {
+		if timex.Now() < b.openUntil {
+			return ErrServiceUnavailable
+		}
+		// half-open, a single probe at a time.
+		if b.probing {
+			return ErrServiceUnavailable
+		}
+
+		b.probing = true
+		return nil
+	}
+
+	accepts, total := b.history()
+	if total >= b.minVolume && float64(total-accepts)/float64(total) >= b.rate {
+		b.openUntil = timex.Now() + b.cooldown
+		return ErrServiceUnavailable
+	}
+
+	return nil
+}
+
+func (b *errorRateBreaker) allow() (internalPromise, error) {
+	if err := b.accept(); err != nil {
+		return nil, err
+	}
+
+	return errorRatePromise{
+		b: b,
+	}, nil
+}
+
+func (b *errorRateBreaker) doReq(req func() error, fallback func(err error) error,
+	acceptable Acceptable) error {
+	if err := b.accept(); err != nil {
+		if fallback != nil {
+			return fallback(err)
+		}
+
+		return err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			b.markFailure()
+			panic(e)
+		}
+	}()
+
+	err := req()
+	if acceptable(err) {
+		b.markSuccess()
+	} else {
+		b.markFailure()
+	}
+
+	return err
+}
+
+func (b *errorRateBreaker) markSuccess() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.openUntil > 0 {
+		// probe succeeded, close and start over with fresh stats.
+		b.openUntil = 0
+		b.probing = false
+		b.stat = collection.NewRollingWindow(b.buckets,
+			time.Duration(int64(b.window)/int64(b.buckets)))
+		return
+	}
+
+	b.stat.Add(1)
+}
+
+func (b *errorRateBreaker) markFailure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.openUntil > 0 {
+		// probe failed, re-open.
+		b.openUntil = timex.Now() + b.cooldown
+		b.probing = false
+		return
+	}
+
+	b.stat.Add(0)
+}
+
+func (b *errorRateBreaker) currentState() State {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.openUntil > 0 {
+		if timex.Now() < b.openUntil {
+			return StateOpen
+		}
+
+		return StateHalfOpen
+	}
+
+	return StateClosed
+}
+
+func (b *errorRateBreaker) history() (accepts, total int64) {
+	b.stat.Reduce(func(b *collection.Bucket) {
+		accepts += int64(b.Sum)
+		total += b.Count
+	})
+
+	return
+}
+
+type errorRatePromise struct {
+	b *errorRateBreaker
+}
+
+func (p errorRatePromise) Accept() {
+	p.b.markSuccess()
+}
+
+func (p errorRatePromise) Reject() {
+	p.b.markFailure()
+}
@@ -0,0 +1,82 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorRateBreaker(t *testing.T) {
+	b := newErrorRateBreaker(0.5, 10, time.Millisecond*10, time.Second, 10)
+
+	// not enough volume to trip, even with all requests failing.
+	for i := 0; i < 9; i++ {
+		assert.NotNil(t, b.doReq(func() error {
+			return errors.New("any")
+		}, nil, defaultAcceptable))
+	}
+	assert.Equal(t, StateClosed, b.currentState())
+
+	assert.NotNil(t, b.doReq(func() error {
+		return errors.New("any")
+	}, nil, defaultAcceptable))
+	assert.Equal(t, ErrServiceUnavailable, b.doReq(func() error {
+		return nil
+	}, nil, defaultAcceptable))
+	assert.Equal(t, StateOpen, b.currentState())
+
+	// after cooldown a single probe goes through, success closes the breaker.
+	time.Sleep(time.Millisecond * 20)
+	assert.Equal(t, StateHalfOpen, b.currentState())
+	p, err := b.allow()
+	assert.Nil(t, err)
+	_, err = b.allow()
+	assert.Equal(t, ErrServiceUnavailable, err)
+	p.Accept()
+	assert.Equal(t, StateClosed, b.currentState())
+	assert.Nil(t, b.doReq(func() error {
+		return nil
+	}, nil, defaultAcceptable))
+}
+
+func TestErrorRateBreakerFailedProbe(t *testing.T) {
+	b := newErrorRateBreaker(0.5, 2, time.Millisecond*10, time.Second, 10)
+
+	for i := 0; i < 2; i++ {
+		assert.NotNil(t, b.doReq(func() error {
+			return errors.New("any")
+		}, nil, defaultAcceptable))
+	}
+	assert.Equal(t, ErrServiceUnavailable, b.doReq(func() error {
+		return nil
+	}, nil, defaultAcceptable))
+
+	// probe fails, the breaker re-opens.
+	time.Sleep(time.Millisecond * 20)
+	assert.NotNil(t, b.doReq(func() error {
+		return errors.New("any")
+	}, nil, defaultAcceptable))
+	assert.Equal(t, StateOpen, b.currentState())
+}
+
+func TestErrorRateBreakerDefaults(t *testing.T) {
+	b := newErrorRateBreaker(0, 0, 0, time.Second, 10)
+	assert.Equal(t, defaultErrorRate, b.rate)
+	assert.Equal(t, int64(defaultMinVolume), b.minVolume)
+	assert.Equal(t, defaultCooldown, b.cooldown)
+}
+
+func TestNewBreakerWithErrorRate(t *testing.T) {
+	b := NewBreaker(WithErrorRate(0.5, 2, time.Minute))
+	for i := 0; i < 2; i++ {
+		assert.NotNil(t, b.Do(func() error {
+			return errors.New("any")
+		}))
+	}
+	assert.Equal(t, ErrServiceUnavailable, b.Do(func() error {
+		return nil
+	}))
+	assert.Equal(t, StateOpen, b.State())
+}
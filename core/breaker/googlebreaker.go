@@ -14,6 +14,9 @@ const (
 	buckets    = 40
 	k          = 1.5
 	protection = 5
+	// above this drop ratio most requests are rejected,
+	// the breaker reads as open.
+	openStateRatio = 0.5
 )
 
 // googleBreaker is a netflixBreaker pattern from google.
@@ -25,6 +28,10 @@ type googleBreaker struct {
 }
 
 func newGoogleBreaker() *googleBreaker {
+	return newGoogleBreakerWithWindow(window, buckets)
+}
+
+func newGoogleBreakerWithWindow(window time.Duration, buckets int) *googleBreaker {
 	bucketDuration := time.Duration(int64(window) / int64(buckets))
 	st := collection.NewRollingWindow(buckets, bucketDuration)
 	return &googleBreaker{
@@ -35,10 +42,7 @@ func newGoogleBreaker() *googleBreaker {
 }
 
 func (b *googleBreaker) accept() error {
-	accepts, total := b.history()
-	weightedAccepts := b.k * float64(accepts)
-	// https://landing.google.com/sre/sre-book/chapters/handling-overload/#eq2101
-	dropRatio := math.Max(0, (float64(total-protection)-weightedAccepts)/float64(total+1))
+	dropRatio := b.dropRatio()
 	if dropRatio <= 0 {
 		return nil
 	}
@@ -50,6 +54,25 @@ func (b *googleBreaker) accept() error {
 	return nil
 }
 
+func (b *googleBreaker) dropRatio() float64 {
+	accepts, total := b.history()
+	weightedAccepts := b.k * float64(accepts)
+	// https://landing.google.com/sre/sre-book/chapters/handling-overload/#eq2101
+	return math.Max(0, (float64(total-protection)-weightedAccepts)/float64(total+1))
+}
+
+func (b *googleBreaker) currentState() State {
+	dropRatio := b.dropRatio()
+	switch {
+	case dropRatio <= 0:
+		return StateClosed
+	case dropRatio < openStateRatio:
+		return StateHalfOpen
+	default:
+		return StateOpen
+	}
+}
+
 func (b *googleBreaker) allow() (internalPromise, error) {
 	if err := b.accept(); err != nil {
 		return nil, err
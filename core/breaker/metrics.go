@@ -0,0 +1,20 @@
+package breaker
+
+import "github.com/qkbyte/go-zero/core/metric"
+
+var (
+	metricDrops = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "breaker",
+		Subsystem: "requests",
+		Name:      "drop_total",
+		Help:      "requests dropped with the breaker open.",
+		Labels:    []string{"name"},
+	})
+	metricState = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "breaker",
+		Subsystem: "state",
+		Name:      "current",
+		Help:      "current breaker state (0 closed, 1 half-open, 2 open).",
+		Labels:    []string{"name"},
+	})
+)
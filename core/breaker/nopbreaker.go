@@ -33,6 +33,19 @@ func (b noOpBreaker) DoWithFallbackAcceptable(req func() error, fallback func(er
 	return req()
 }
 
+func (b noOpBreaker) State() State {
+	return StateClosed
+}
+
+func (b noOpBreaker) Open() {
+}
+
+func (b noOpBreaker) HalfOpen() {
+}
+
+func (b noOpBreaker) Close() {
+}
+
 type nopPromise struct{}
 
 func (p nopPromise) Accept() {
@@ -40,3 +53,9 @@ func (p nopPromise) Accept() {
 
 func (p nopPromise) Reject(reason string) {
 }
+
+// NopBreaker returns a Breaker that accepts all requests,
+// typically used to bypass the circuit breaking on purpose.
+func NopBreaker() Breaker {
+	return newNoOpBreaker()
+}
@@ -36,3 +36,11 @@ func TestNopBreaker(t *testing.T) {
 		return nil
 	}, defaultAcceptable))
 }
+
+func TestNopBreakerExported(t *testing.T) {
+	b := NopBreaker()
+	assert.Equal(t, noOpBreakerName, b.Name())
+	assert.Nil(t, b.Do(func() error {
+		return nil
+	}))
+}
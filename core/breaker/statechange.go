@@ -0,0 +1,51 @@
+package breaker
+
+import (
+	"sync"
+
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+type (
+	// A StateChange describes a single state transition of a Breaker.
+	StateChange struct {
+		// Name is the name of the Breaker.
+		Name string
+		// From is the state before the transition.
+		From State
+		// To is the state after the transition.
+		To State
+		// Reasons holds the recent errors collected by the Breaker,
+		// latest first, one per line.
+		Reasons string
+	}
+
+	// StateChangeHandler handles state transitions of breakers.
+	StateChangeHandler func(change StateChange)
+)
+
+var (
+	stateLock     sync.RWMutex
+	stateHandlers []StateChangeHandler
+)
+
+// OnStateChange registers handler to be called on state transitions of all
+// breakers in the process, typically to alert or log transitions centrally.
+func OnStateChange(handler StateChangeHandler) {
+	stateLock.Lock()
+	stateHandlers = append(stateHandlers, handler)
+	stateLock.Unlock()
+}
+
+func notifyStateChange(change StateChange) {
+	stateLock.RLock()
+	handlers := stateHandlers
+	stateLock.RUnlock()
+
+	for _, handler := range handlers {
+		handler := handler
+		threading.RunSafe(func() {
+			handler(change)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package breaker
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnStateChange(t *testing.T) {
+	var lock sync.Mutex
+	var changes []StateChange
+	OnStateChange(func(change StateChange) {
+		if change.Name != "global" {
+			return
+		}
+
+		lock.Lock()
+		changes = append(changes, change)
+		lock.Unlock()
+	})
+
+	b := NewBreaker(WithName("global"))
+	p, err := b.Allow()
+	assert.Nil(t, err)
+	p.Reject("boom")
+
+	b.Open()
+	b.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, StateClosed, changes[0].From)
+	assert.Equal(t, StateOpen, changes[0].To)
+	assert.True(t, strings.Contains(changes[0].Reasons, "boom"))
+	assert.Equal(t, StateOpen, changes[1].From)
+	assert.Equal(t, StateClosed, changes[1].To)
+}
+
+func TestOnStateChangePanicSafe(t *testing.T) {
+	OnStateChange(func(change StateChange) {
+		if change.Name == "panicky" {
+			panic("fail")
+		}
+	})
+
+	b := NewBreaker(WithName("panicky"))
+	assert.NotPanics(t, func() {
+		b.Open()
+		b.Close()
+	})
+}
@@ -2,6 +2,7 @@ package collection
 
 import (
 	"container/list"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/qkbyte/go-zero/core/logx"
 	"github.com/qkbyte/go-zero/core/mathx"
 	"github.com/qkbyte/go-zero/core/syncx"
+	"github.com/qkbyte/go-zero/core/threading"
 )
 
 const (
@@ -20,12 +22,42 @@ const (
 	expiryDeviation = 0.05
 )
 
+// EvictPolicy is the policy used to pick the items to evict when a Cache is full.
+type EvictPolicy int
+
+const (
+	// EvictLRU evicts the least recently used items, the default policy.
+	EvictLRU EvictPolicy = iota
+	// EvictLFU evicts the least frequently used items.
+	EvictLFU
+	// EvictARC balances between recency and frequency adaptively.
+	EvictARC
+)
+
+// EvictReason is why an item got removed from a Cache.
+type EvictReason int
+
+const (
+	// EvictExpired means the item expired.
+	EvictExpired EvictReason = iota
+	// EvictCapacity means the item was evicted to respect the item or memory limit.
+	EvictCapacity
+	// EvictRemoved means the item was explicitly deleted.
+	EvictRemoved
+)
+
 var emptyLruCache = emptyLru{}
 
 type (
 	// CacheOption defines the method to customize a Cache.
 	CacheOption func(cache *Cache)
 
+	// EvictCallback is called asynchronously when an item is removed from a Cache.
+	EvictCallback func(key string, value interface{}, reason EvictReason)
+
+	// Sizer estimates the memory held by a cached value.
+	Sizer func(value interface{}) int64
+
 	// A Cache object is an in-memory cache.
 	Cache struct {
 		name           string
@@ -34,6 +66,12 @@ type (
 		expire         time.Duration
 		timingWheel    *TimingWheel
 		lruCache       lru
+		limit          int
+		policy         EvictPolicy
+		maxBytes       int64
+		usedBytes      int64
+		sizer          Sizer
+		evictCallback  EvictCallback
 		barrier        syncx.SingleFlight
 		unstableExpiry mathx.Unstable
 		stats          *cacheStat
@@ -57,6 +95,20 @@ func NewCache(expire time.Duration, opts ...CacheOption) (*Cache, error) {
 	if len(cache.name) == 0 {
 		cache.name = defaultCacheName
 	}
+	if cache.limit <= 0 && cache.maxBytes > 0 {
+		// memory bound only, the policy just tracks the eviction order
+		cache.limit = math.MaxInt32
+	}
+	if cache.limit > 0 {
+		switch cache.policy {
+		case EvictLFU:
+			cache.lruCache = newKeyLfu(cache.limit, cache.onEvict)
+		case EvictARC:
+			cache.lruCache = newKeyArc(cache.limit, cache.onEvict)
+		default:
+			cache.lruCache = newKeyLru(cache.limit, cache.onEvict)
+		}
+	}
 	cache.stats = newCacheStat(cache.name, cache.size)
 
 	timingWheel, err := NewTimingWheel(time.Second, slots, func(k, v interface{}) {
@@ -65,7 +117,7 @@ func NewCache(expire time.Duration, opts ...CacheOption) (*Cache, error) {
 			return
 		}
 
-		cache.Del(key)
+		cache.del(key, EvictExpired)
 	})
 	if err != nil {
 		return nil, err
@@ -77,11 +129,7 @@ func NewCache(expire time.Duration, opts ...CacheOption) (*Cache, error) {
 
 // Del deletes the item with the given key from c.
 func (c *Cache) Del(key string) {
-	c.lock.Lock()
-	delete(c.data, key)
-	c.lruCache.remove(key)
-	c.lock.Unlock()
-	c.timingWheel.RemoveTimer(key)
+	c.del(key, EvictRemoved)
 }
 
 // Get returns the item with the given key from c.
@@ -104,9 +152,18 @@ func (c *Cache) Set(key string, value interface{}) {
 // SetWithExpire sets value into c with key and expire with the given value.
 func (c *Cache) SetWithExpire(key string, value interface{}, expire time.Duration) {
 	c.lock.Lock()
-	_, ok := c.data[key]
+	old, ok := c.data[key]
+	if ok {
+		c.usedBytes -= c.sizeOf(old)
+	}
 	c.data[key] = value
+	c.usedBytes += c.sizeOf(value)
 	c.lruCache.add(key)
+	for c.maxBytes > 0 && c.usedBytes > c.maxBytes {
+		if _, evicted := c.lruCache.victim(); !evicted {
+			break
+		}
+	}
 	c.lock.Unlock()
 
 	expiry := c.unstableExpiry.AroundDuration(expire)
@@ -157,6 +214,22 @@ func (c *Cache) Take(key string, fetch func() (interface{}, error)) (interface{}
 	return val, nil
 }
 
+func (c *Cache) del(key string, reason EvictReason) {
+	c.lock.Lock()
+	value, ok := c.data[key]
+	if ok {
+		delete(c.data, key)
+		c.usedBytes -= c.sizeOf(value)
+		c.lruCache.remove(key)
+	}
+	c.lock.Unlock()
+	c.timingWheel.RemoveTimer(key)
+
+	if ok {
+		c.notifyEvict(key, value, reason)
+	}
+}
+
 func (c *Cache) doGet(key string) (interface{}, bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -169,23 +242,73 @@ func (c *Cache) doGet(key string) (interface{}, bool) {
 	return value, ok
 }
 
+func (c *Cache) notifyEvict(key string, value interface{}, reason EvictReason) {
+	if c.evictCallback == nil {
+		return
+	}
+
+	threading.GoSafe(func() {
+		c.evictCallback(key, value, reason)
+	})
+}
+
 func (c *Cache) onEvict(key string) {
 	// already locked
+	value, ok := c.data[key]
 	delete(c.data, key)
+	if ok {
+		c.usedBytes -= c.sizeOf(value)
+		c.notifyEvict(key, value, EvictCapacity)
+	}
 	c.timingWheel.RemoveTimer(key)
 }
 
+func (c *Cache) sizeOf(value interface{}) int64 {
+	if c.sizer == nil {
+		return 0
+	}
+
+	return c.sizer(value)
+}
+
 func (c *Cache) size() int {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	return len(c.data)
 }
 
+// WithEvictCallback customizes a Cache with fn called asynchronously whenever
+// an item is expired, evicted or explicitly removed.
+func WithEvictCallback(fn EvictCallback) CacheOption {
+	return func(cache *Cache) {
+		cache.evictCallback = fn
+	}
+}
+
+// WithEvictPolicy customizes a Cache with the given eviction policy,
+// effective together with WithLimit or WithMaxBytes.
+func WithEvictPolicy(policy EvictPolicy) CacheOption {
+	return func(cache *Cache) {
+		cache.policy = policy
+	}
+}
+
 // WithLimit customizes a Cache with items up to limit.
 func WithLimit(limit int) CacheOption {
 	return func(cache *Cache) {
 		if limit > 0 {
-			cache.lruCache = newKeyLru(limit, cache.onEvict)
+			cache.limit = limit
+		}
+	}
+}
+
+// WithMaxBytes customizes a Cache to bound the memory estimated by sizer to maxBytes,
+// the eviction policy picks the victims when the bound is exceeded.
+func WithMaxBytes(maxBytes int64, sizer Sizer) CacheOption {
+	return func(cache *Cache) {
+		if maxBytes > 0 && sizer != nil {
+			cache.maxBytes = maxBytes
+			cache.sizer = sizer
 		}
 	}
 }
@@ -201,6 +324,9 @@ type (
 	lru interface {
 		add(key string)
 		remove(key string)
+		// victim evicts one item chosen by the policy, and reports whether
+		// there was one to evict.
+		victim() (string, bool)
 	}
 
 	emptyLru struct{}
@@ -219,6 +345,10 @@ func (elru emptyLru) add(string) {
 func (elru emptyLru) remove(string) {
 }
 
+func (elru emptyLru) victim() (string, bool) {
+	return "", false
+}
+
 func newKeyLru(limit int, onEvict func(key string)) *keyLru {
 	return &keyLru{
 		limit:    limit,
@@ -240,28 +370,265 @@ func (klru *keyLru) add(key string) {
 
 	// Verify size not exceeded
 	if klru.evicts.Len() > klru.limit {
-		klru.removeOldest()
+		klru.victim()
 	}
 }
 
 func (klru *keyLru) remove(key string) {
 	if elem, ok := klru.elements[key]; ok {
-		klru.removeElement(elem)
+		klru.evicts.Remove(elem)
+		delete(klru.elements, key)
 	}
 }
 
-func (klru *keyLru) removeOldest() {
+func (klru *keyLru) victim() (string, bool) {
 	elem := klru.evicts.Back()
-	if elem != nil {
-		klru.removeElement(elem)
+	if elem == nil {
+		return "", false
 	}
-}
 
-func (klru *keyLru) removeElement(e *list.Element) {
-	klru.evicts.Remove(e)
-	key := e.Value.(string)
-	delete(klru.elements, key)
+	key := elem.Value.(string)
+	klru.remove(key)
 	klru.onEvict(key)
+	return key, true
+}
+
+type keyLfu struct {
+	limit    int
+	minFreq  int
+	freqs    map[string]int
+	buckets  map[int]*list.List
+	elements map[string]*list.Element
+	onEvict  func(key string)
+}
+
+func newKeyLfu(limit int, onEvict func(key string)) *keyLfu {
+	return &keyLfu{
+		limit:    limit,
+		freqs:    make(map[string]int),
+		buckets:  make(map[int]*list.List),
+		elements: make(map[string]*list.Element),
+		onEvict:  onEvict,
+	}
+}
+
+func (klfu *keyLfu) add(key string) {
+	if _, ok := klfu.elements[key]; ok {
+		klfu.touch(key)
+		return
+	}
+
+	if len(klfu.elements) >= klfu.limit {
+		klfu.victim()
+	}
+	klfu.freqs[key] = 1
+	klfu.elements[key] = klfu.bucket(1).PushBack(key)
+	klfu.minFreq = 1
+}
+
+func (klfu *keyLfu) bucket(freq int) *list.List {
+	b, ok := klfu.buckets[freq]
+	if !ok {
+		b = list.New()
+		klfu.buckets[freq] = b
+	}
+
+	return b
+}
+
+func (klfu *keyLfu) remove(key string) {
+	elem, ok := klfu.elements[key]
+	if !ok {
+		return
+	}
+
+	freq := klfu.freqs[key]
+	bucket := klfu.buckets[freq]
+	bucket.Remove(elem)
+	if bucket.Len() == 0 {
+		delete(klfu.buckets, freq)
+	}
+	delete(klfu.elements, key)
+	delete(klfu.freqs, key)
+}
+
+func (klfu *keyLfu) touch(key string) {
+	freq := klfu.freqs[key]
+	bucket := klfu.buckets[freq]
+	bucket.Remove(klfu.elements[key])
+	if bucket.Len() == 0 {
+		delete(klfu.buckets, freq)
+		if klfu.minFreq == freq {
+			klfu.minFreq++
+		}
+	}
+	klfu.freqs[key] = freq + 1
+	klfu.elements[key] = klfu.bucket(freq + 1).PushBack(key)
+}
+
+func (klfu *keyLfu) victim() (string, bool) {
+	if len(klfu.elements) == 0 {
+		return "", false
+	}
+
+	// minFreq might be stale after removals, scan upwards for the lowest bucket
+	for freq := klfu.minFreq; ; freq++ {
+		if bucket, ok := klfu.buckets[freq]; ok {
+			key := bucket.Front().Value.(string)
+			klfu.minFreq = freq
+			klfu.remove(key)
+			klfu.onEvict(key)
+			return key, true
+		}
+	}
+}
+
+// keyArc implements the adaptive replacement cache policy, t1/t2 hold the
+// cached keys once and frequently used, b1/b2 are their ghost lists, and p
+// is the adaptive target size of t1.
+type keyArc struct {
+	limit   int
+	p       int
+	t1      *list.List
+	t2      *list.List
+	b1      *list.List
+	b2      *list.List
+	items   map[string]*arcItem
+	onEvict func(key string)
+}
+
+type arcItem struct {
+	elem *list.Element
+	list *list.List
+}
+
+func newKeyArc(limit int, onEvict func(key string)) *keyArc {
+	return &keyArc{
+		limit:   limit,
+		t1:      list.New(),
+		t2:      list.New(),
+		b1:      list.New(),
+		b2:      list.New(),
+		items:   make(map[string]*arcItem),
+		onEvict: onEvict,
+	}
+}
+
+func (karc *keyArc) add(key string) {
+	if item, ok := karc.items[key]; ok {
+		switch item.list {
+		case karc.t1, karc.t2:
+		case karc.b1:
+			karc.p = mathx.MinInt(karc.limit, karc.p+karc.ghostDelta(karc.b2, karc.b1))
+			karc.replace(false)
+		case karc.b2:
+			karc.p = mathx.MaxInt(0, karc.p-karc.ghostDelta(karc.b1, karc.b2))
+			karc.replace(true)
+		}
+		item.list.Remove(item.elem)
+		item.elem = karc.t2.PushFront(key)
+		item.list = karc.t2
+		return
+	}
+
+	if karc.t1.Len()+karc.b1.Len() == karc.limit {
+		if karc.t1.Len() < karc.limit {
+			karc.drop(karc.b1)
+			karc.replace(false)
+		} else {
+			karc.evict(karc.t1)
+		}
+	} else if total := karc.t1.Len() + karc.t2.Len() + karc.b1.Len() + karc.b2.Len(); total >= karc.limit {
+		if total == karc.limit<<1 {
+			karc.drop(karc.b2)
+		}
+		karc.replace(false)
+	}
+	karc.items[key] = &arcItem{
+		elem: karc.t1.PushFront(key),
+		list: karc.t1,
+	}
+}
+
+// demote moves the least recently used key of src into its ghost list,
+// and evicts the value.
+func (karc *keyArc) demote(src, ghost *list.List) {
+	elem := src.Back()
+	if elem == nil {
+		return
+	}
+
+	key := elem.Value.(string)
+	src.Remove(elem)
+	item := karc.items[key]
+	item.elem = ghost.PushFront(key)
+	item.list = ghost
+	karc.onEvict(key)
+}
+
+// drop removes the least recently used ghost key entirely.
+func (karc *keyArc) drop(ghost *list.List) {
+	elem := ghost.Back()
+	if elem == nil {
+		return
+	}
+
+	ghost.Remove(elem)
+	delete(karc.items, elem.Value.(string))
+}
+
+// evict removes the least recently used key of src without keeping a ghost.
+func (karc *keyArc) evict(src *list.List) {
+	elem := src.Back()
+	if elem == nil {
+		return
+	}
+
+	key := elem.Value.(string)
+	src.Remove(elem)
+	delete(karc.items, key)
+	karc.onEvict(key)
+}
+
+func (karc *keyArc) ghostDelta(a, b *list.List) int {
+	if b.Len() == 0 {
+		return 1
+	}
+
+	return mathx.MaxInt(a.Len()/b.Len(), 1)
+}
+
+func (karc *keyArc) remove(key string) {
+	if item, ok := karc.items[key]; ok {
+		item.list.Remove(item.elem)
+		delete(karc.items, key)
+	}
+}
+
+func (karc *keyArc) replace(inB2 bool) {
+	if karc.t1.Len() > 0 && (karc.t1.Len() > karc.p || (inB2 && karc.t1.Len() == karc.p)) {
+		karc.demote(karc.t1, karc.b1)
+	} else if karc.t2.Len() > 0 {
+		karc.demote(karc.t2, karc.b2)
+	}
+}
+
+func (karc *keyArc) victim() (string, bool) {
+	var src, ghost *list.List
+	switch {
+	case karc.t1.Len() > 0 && karc.t1.Len() > karc.p:
+		src, ghost = karc.t1, karc.b1
+	case karc.t2.Len() > 0:
+		src, ghost = karc.t2, karc.b2
+	case karc.t1.Len() > 0:
+		src, ghost = karc.t1, karc.b1
+	default:
+		return "", false
+	}
+
+	key := src.Back().Value.(string)
+	karc.demote(src, ghost)
+	return key, true
 }
 
 type cacheStat struct {
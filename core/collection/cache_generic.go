@@ -0,0 +1,62 @@
+package collection
+
+import "time"
+
+// A CacheT is a typed Cache, the keys are strings like in Cache,
+// the values are typed so call sites drop the type assertions.
+type CacheT[K ~string, V any] struct {
+	cache *Cache
+}
+
+// NewCacheT returns a CacheT with given expire.
+func NewCacheT[K ~string, V any](expire time.Duration, opts ...CacheOption) (*CacheT[K, V], error) {
+	cache, err := NewCache(expire, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CacheT[K, V]{
+		cache: cache,
+	}, nil
+}
+
+// Del deletes the item with the given key from c.
+func (c *CacheT[K, V]) Del(key K) {
+	c.cache.Del(string(key))
+}
+
+// Get returns the item with the given key from c.
+func (c *CacheT[K, V]) Get(key K) (V, bool) {
+	value, ok := c.cache.Get(string(key))
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return value.(V), true
+}
+
+// Set sets value into c with key.
+func (c *CacheT[K, V]) Set(key K, value V) {
+	c.cache.Set(string(key), value)
+}
+
+// SetWithExpire sets value into c with key and expire with the given value.
+func (c *CacheT[K, V]) SetWithExpire(key K, value V, expire time.Duration) {
+	c.cache.SetWithExpire(string(key), value, expire)
+}
+
+// Take returns the item with the given key.
+// If the item is in c, return it directly.
+// If not, use fetch method to get the item, set into c and return it.
+func (c *CacheT[K, V]) Take(key K, fetch func() (V, error)) (V, error) {
+	value, err := c.cache.Take(string(key), func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	return value.(V), nil
+}
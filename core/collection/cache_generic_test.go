@@ -0,0 +1,50 @@
+package collection
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheT(t *testing.T) {
+	cache, err := NewCacheT[string, string](time.Minute)
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	value, ok := cache.Get("first")
+	assert.True(t, ok)
+	assert.Equal(t, "first element", value)
+
+	cache.SetWithExpire("second", "second element", time.Minute)
+	cache.Del("second")
+	value, ok = cache.Get("second")
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+}
+
+func TestCacheT_Take(t *testing.T) {
+	cache, err := NewCacheT[string, int](time.Minute)
+	assert.Nil(t, err)
+
+	var count int
+	value, err := cache.Take("any", func() (int, error) {
+		count++
+		return 42, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 42, value)
+	value, err = cache.Take("any", func() (int, error) {
+		count++
+		return 43, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 42, value)
+	assert.Equal(t, 1, count)
+
+	_, err = cache.Take("error", func() (int, error) {
+		return 0, errors.New("fetch failed")
+	})
+	assert.NotNil(t, err)
+}
@@ -188,3 +188,101 @@ func BenchmarkCache(b *testing.B) {
 		}
 	})
 }
+
+func TestCacheWithLfuEvicts(t *testing.T) {
+	cache, err := NewCache(time.Minute, WithLimit(3), WithEvictPolicy(EvictLFU))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.Set("second", "second element")
+	cache.Set("third", "third element")
+	// raise the frequency of first and second
+	for i := 0; i < 3; i++ {
+		cache.Get("first")
+		cache.Get("second")
+	}
+	cache.Set("fourth", "fourth element")
+
+	_, ok := cache.Get("third")
+	assert.False(t, ok)
+	value, ok := cache.Get("first")
+	assert.True(t, ok)
+	assert.Equal(t, "first element", value)
+	value, ok = cache.Get("fourth")
+	assert.True(t, ok)
+	assert.Equal(t, "fourth element", value)
+}
+
+func TestCacheWithArcEvicts(t *testing.T) {
+	cache, err := NewCache(time.Minute, WithLimit(2), WithEvictPolicy(EvictARC))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.Set("second", "second element")
+	// promote first into the frequent list
+	cache.Get("first")
+	cache.Set("third", "third element")
+
+	_, ok := cache.Get("second")
+	assert.False(t, ok)
+	value, ok := cache.Get("first")
+	assert.True(t, ok)
+	assert.Equal(t, "first element", value)
+	value, ok = cache.Get("third")
+	assert.True(t, ok)
+	assert.Equal(t, "third element", value)
+}
+
+func TestCacheWithMaxBytes(t *testing.T) {
+	cache, err := NewCache(time.Minute, WithMaxBytes(10, func(value interface{}) int64 {
+		return int64(len(value.(string)))
+	}))
+	assert.Nil(t, err)
+
+	cache.Set("first", "aaaa")
+	cache.Set("second", "bbbb")
+	cache.Set("third", "cccc")
+
+	_, ok := cache.Get("first")
+	assert.False(t, ok)
+	value, ok := cache.Get("second")
+	assert.True(t, ok)
+	assert.Equal(t, "bbbb", value)
+	value, ok = cache.Get("third")
+	assert.True(t, ok)
+	assert.Equal(t, "cccc", value)
+}
+
+func TestCacheEvictCallback(t *testing.T) {
+	type evictEvent struct {
+		key    string
+		value  interface{}
+		reason EvictReason
+	}
+	events := make(chan evictEvent, 2)
+	cache, err := NewCache(time.Minute, WithLimit(1), WithEvictCallback(
+		func(key string, value interface{}, reason EvictReason) {
+			events <- evictEvent{key: key, value: value, reason: reason}
+		}))
+	assert.Nil(t, err)
+
+	cache.Set("first", "first element")
+	cache.Set("second", "second element")
+	select {
+	case event := <-events:
+		assert.Equal(t, "first", event.key)
+		assert.Equal(t, "first element", event.value)
+		assert.Equal(t, EvictCapacity, event.reason)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the eviction callback")
+	}
+
+	cache.Del("second")
+	select {
+	case event := <-events:
+		assert.Equal(t, "second", event.key)
+		assert.Equal(t, EvictRemoved, event.reason)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the removal callback")
+	}
+}
@@ -0,0 +1,31 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingTLess(t *testing.T) {
+	ring := NewRingT[int](5)
+	for i := 0; i < 3; i++ {
+		ring.Add(i)
+	}
+	elements := ring.Take()
+	assert.ElementsMatch(t, []int{0, 1, 2}, elements)
+}
+
+func TestRingTMore(t *testing.T) {
+	ring := NewRingT[int](5)
+	for i := 0; i < 11; i++ {
+		ring.Add(i)
+	}
+	elements := ring.Take()
+	assert.ElementsMatch(t, []int{6, 7, 8, 9, 10}, elements)
+}
+
+func TestNewRingTPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRingT[int](0)
+	})
+}
@@ -0,0 +1,41 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeMapT(t *testing.T) {
+	m := NewSafeMapT[string, int]()
+	m.Set("first", 1)
+	m.Set("second", 2)
+	assert.Equal(t, 2, m.Size())
+
+	val, ok := m.Get("first")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	m.Del("first")
+	_, ok = m.Get("first")
+	assert.False(t, ok)
+	assert.Equal(t, 1, m.Size())
+
+	var keys []string
+	m.Range(func(key string, val int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []string{"second"}, keys)
+}
+
+func TestSafeMapT_ManyDeletions(t *testing.T) {
+	m := NewSafeMapT[int, int]()
+	for i := 0; i < maxDeletion<<1; i++ {
+		m.Set(i, i)
+	}
+	for i := 0; i < maxDeletion<<1; i++ {
+		m.Del(i)
+	}
+	assert.Equal(t, 0, m.Size())
+}
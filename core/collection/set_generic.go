@@ -0,0 +1,49 @@
+package collection
+
+import "github.com/qkbyte/go-zero/core/lang"
+
+// SetT is a typed Set, the type checks of Set are enforced at compile time.
+// SetT is not thread-safe, for concurrent use, make sure to use it with synchronization.
+type SetT[T comparable] struct {
+	data map[T]lang.PlaceholderType
+}
+
+// NewSetT returns a SetT.
+func NewSetT[T comparable]() *SetT[T] {
+	return &SetT[T]{
+		data: make(map[T]lang.PlaceholderType),
+	}
+}
+
+// Add adds vals into s.
+func (s *SetT[T]) Add(vals ...T) {
+	for _, each := range vals {
+		s.data[each] = lang.Placeholder
+	}
+}
+
+// Contains checks if v is in s.
+func (s *SetT[T]) Contains(v T) bool {
+	_, ok := s.data[v]
+	return ok
+}
+
+// Count returns the number of items in s.
+func (s *SetT[T]) Count() int {
+	return len(s.data)
+}
+
+// Keys returns the keys in s.
+func (s *SetT[T]) Keys() []T {
+	keys := make([]T, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Remove removes v from s.
+func (s *SetT[T]) Remove(v T) {
+	delete(s.data, v)
+}
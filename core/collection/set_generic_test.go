@@ -0,0 +1,21 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetT(t *testing.T) {
+	set := NewSetT[int]()
+	values := []int{1, 2, 3, 2, 1}
+	set.Add(values...)
+	assert.Equal(t, 3, set.Count())
+	assert.True(t, set.Contains(1))
+	assert.False(t, set.Contains(4))
+	assert.ElementsMatch(t, []int{1, 2, 3}, set.Keys())
+
+	set.Remove(2)
+	assert.False(t, set.Contains(2))
+	assert.Equal(t, 2, set.Count())
+}
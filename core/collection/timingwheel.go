@@ -2,8 +2,11 @@ package collection
 
 import (
 	"container/list"
+	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/qkbyte/go-zero/core/lang"
@@ -24,6 +27,7 @@ type (
 
 	// A TimingWheel is a timing wheel object to schedule tasks.
 	TimingWheel struct {
+		fired         int64
 		interval      time.Duration
 		ticker        timex.Ticker
 		slots         []*list.List
@@ -34,6 +38,7 @@ type (
 		setChannel    chan timingEntry
 		moveChannel   chan baseEntry
 		removeChannel chan interface{}
+		prefixChannel chan prefixEntry
 		drainChannel  chan func(key, value interface{})
 		stopChannel   chan lang.PlaceholderType
 	}
@@ -41,6 +46,7 @@ type (
 	timingEntry struct {
 		baseEntry
 		value   interface{}
+		ctx     context.Context
 		circle  int
 		diff    int
 		removed bool
@@ -56,9 +62,16 @@ type (
 		item *timingEntry
 	}
 
+	prefixEntry struct {
+		prefix string
+		delay  time.Duration
+		remove bool
+	}
+
 	timingTask struct {
 		key   interface{}
 		value interface{}
+		ctx   context.Context
 	}
 )
 
@@ -85,6 +98,7 @@ func newTimingWheelWithClock(interval time.Duration, numSlots int, execute Execu
 		setChannel:    make(chan timingEntry),
 		moveChannel:   make(chan baseEntry),
 		removeChannel: make(chan interface{}),
+		prefixChannel: make(chan prefixEntry),
 		drainChannel:  make(chan func(key, value interface{})),
 		stopChannel:   make(chan lang.PlaceholderType),
 	}
@@ -105,6 +119,11 @@ func (tw *TimingWheel) Drain(fn func(key, value interface{})) error {
 	}
 }
 
+// FiredTasks returns how many tasks have been fired since tw started.
+func (tw *TimingWheel) FiredTasks() int64 {
+	return atomic.LoadInt64(&tw.fired)
+}
+
 // MoveTimer moves the task with the given key to the given delay.
 func (tw *TimingWheel) MoveTimer(key interface{}, delay time.Duration) error {
 	if delay <= 0 || key == nil {
@@ -122,6 +141,29 @@ func (tw *TimingWheel) MoveTimer(key interface{}, delay time.Duration) error {
 	}
 }
 
+// MoveTimersWithPrefix moves all the tasks whose string keys have the given
+// prefix to the given delay.
+func (tw *TimingWheel) MoveTimersWithPrefix(prefix string, delay time.Duration) error {
+	if delay <= 0 {
+		return ErrArgument
+	}
+
+	select {
+	case tw.prefixChannel <- prefixEntry{
+		prefix: prefix,
+		delay:  delay,
+	}:
+		return nil
+	case <-tw.stopChannel:
+		return ErrClosed
+	}
+}
+
+// PendingTasks returns how many tasks are waiting to be fired.
+func (tw *TimingWheel) PendingTasks() int {
+	return tw.timers.Size()
+}
+
 // RemoveTimer removes the task with the given key.
 func (tw *TimingWheel) RemoveTimer(key interface{}) error {
 	if key == nil {
@@ -136,8 +178,28 @@ func (tw *TimingWheel) RemoveTimer(key interface{}) error {
 	}
 }
 
+// RemoveTimersWithPrefix removes all the tasks whose string keys have the given prefix.
+func (tw *TimingWheel) RemoveTimersWithPrefix(prefix string) error {
+	select {
+	case tw.prefixChannel <- prefixEntry{
+		prefix: prefix,
+		remove: true,
+	}:
+		return nil
+	case <-tw.stopChannel:
+		return ErrClosed
+	}
+}
+
 // SetTimer sets the task value with the given key to the delay.
 func (tw *TimingWheel) SetTimer(key, value interface{}, delay time.Duration) error {
+	return tw.SetTimerCtx(context.Background(), key, value, delay)
+}
+
+// SetTimerCtx is like SetTimer, but the task is skipped if ctx is done
+// before it fires.
+func (tw *TimingWheel) SetTimerCtx(ctx context.Context, key, value interface{},
+	delay time.Duration) error {
 	if delay <= 0 || key == nil {
 		return ErrArgument
 	}
@@ -149,6 +211,7 @@ func (tw *TimingWheel) SetTimer(key, value interface{}, delay time.Duration) err
 			key:   key,
 		},
 		value: value,
+		ctx:   ctx,
 	}:
 		return nil
 	case <-tw.stopChannel:
@@ -169,7 +232,7 @@ func (tw *TimingWheel) drainAll(fn func(key, value interface{})) {
 			next := e.Next()
 			slot.Remove(e)
 			e = next
-			if !task.removed {
+			if !task.removed && !taskCanceled(task.ctx) {
 				runner.Schedule(func() {
 					fn(task.key, task.value)
 				})
@@ -200,8 +263,14 @@ func (tw *TimingWheel) moveTask(task baseEntry) {
 
 	timer := val.(*positionEntry)
 	if task.delay < tw.interval {
+		item := timer.item
 		threading.GoSafe(func() {
-			tw.execute(timer.item.key, timer.item.value)
+			if taskCanceled(item.ctx) {
+				return
+			}
+
+			atomic.AddInt64(&tw.fired, 1)
+			tw.execute(item.key, item.value)
 		})
 		return
 	}
@@ -219,6 +288,7 @@ func (tw *TimingWheel) moveTask(task baseEntry) {
 		newItem := &timingEntry{
 			baseEntry: task,
 			value:     timer.item.value,
+			ctx:       timer.item.ctx,
 		}
 		tw.slots[pos].PushBack(newItem)
 		tw.setTimerPosition(pos, newItem)
@@ -251,6 +321,8 @@ func (tw *TimingWheel) run() {
 			tw.setTask(&task)
 		case key := <-tw.removeChannel:
 			tw.removeTask(key)
+		case op := <-tw.prefixChannel:
+			tw.prefixTasks(op)
 		case task := <-tw.moveChannel:
 			tw.moveTask(task)
 		case fn := <-tw.drainChannel:
@@ -269,6 +341,11 @@ func (tw *TimingWheel) runTasks(tasks []timingTask) {
 
 	go func() {
 		for i := range tasks {
+			if taskCanceled(tasks[i].ctx) {
+				continue
+			}
+
+			atomic.AddInt64(&tw.fired, 1)
 			threading.RunSafe(func() {
 				tw.execute(tasks[i].key, tasks[i].value)
 			})
@@ -306,6 +383,7 @@ func (tw *TimingWheel) scanAndRunTasks(l *list.List) {
 		tasks = append(tasks, timingTask{
 			key:   task.key,
 			value: task.value,
+			ctx:   task.ctx,
 		})
 		next := e.Next()
 		l.Remove(e)
@@ -324,6 +402,7 @@ func (tw *TimingWheel) setTask(task *timingEntry) {
 	if val, ok := tw.timers.Get(task.key); ok {
 		entry := val.(*positionEntry)
 		entry.item.value = task.value
+		entry.item.ctx = task.ctx
 		tw.moveTask(task.baseEntry)
 	} else {
 		pos, circle := tw.getPositionAndCircle(task.delay)
@@ -333,6 +412,33 @@ func (tw *TimingWheel) setTask(task *timingEntry) {
 	}
 }
 
+// prefixTasks removes or moves all the tasks whose string keys have the given prefix.
+func (tw *TimingWheel) prefixTasks(op prefixEntry) {
+	var keys []interface{}
+	for _, slot := range tw.slots {
+		for e := slot.Front(); e != nil; e = e.Next() {
+			task := e.Value.(*timingEntry)
+			if task.removed {
+				continue
+			}
+			if key, ok := task.key.(string); ok && strings.HasPrefix(key, op.prefix) {
+				keys = append(keys, task.key)
+			}
+		}
+	}
+
+	for _, key := range keys {
+		if op.remove {
+			tw.removeTask(key)
+		} else {
+			tw.moveTask(baseEntry{
+				delay: op.delay,
+				key:   key,
+			})
+		}
+	}
+}
+
 func (tw *TimingWheel) setTimerPosition(pos int, task *timingEntry) {
 	if val, ok := tw.timers.Get(task.key); ok {
 		timer := val.(*positionEntry)
@@ -345,3 +451,7 @@ func (tw *TimingWheel) setTimerPosition(pos int, task *timingEntry) {
 		})
 	}
 }
+
+func taskCanceled(ctx context.Context) bool {
+	return ctx != nil && ctx.Err() != nil
+}
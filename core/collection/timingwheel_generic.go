@@ -0,0 +1,92 @@
+package collection
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// ExecuteT defines the method to execute the typed task.
+	ExecuteT[K comparable, V any] func(key K, value V)
+
+	// A TimingWheelT is a typed TimingWheel to schedule tasks,
+	// it avoids the type assertions of the interface{} based one.
+	TimingWheelT[K comparable, V any] struct {
+		tw *TimingWheel
+	}
+)
+
+// NewTimingWheelT returns a TimingWheelT.
+func NewTimingWheelT[K comparable, V any](interval time.Duration, numSlots int,
+	execute ExecuteT[K, V]) (*TimingWheelT[K, V], error) {
+	if execute == nil {
+		return nil, ErrArgument
+	}
+
+	tw, err := NewTimingWheel(interval, numSlots, func(key, value interface{}) {
+		execute(key.(K), value.(V))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TimingWheelT[K, V]{
+		tw: tw,
+	}, nil
+}
+
+// Drain drains all items and executes them.
+func (t *TimingWheelT[K, V]) Drain(fn func(key K, value V)) error {
+	return t.tw.Drain(func(key, value interface{}) {
+		fn(key.(K), value.(V))
+	})
+}
+
+// FiredTasks returns how many tasks have been fired since t started.
+func (t *TimingWheelT[K, V]) FiredTasks() int64 {
+	return t.tw.FiredTasks()
+}
+
+// MoveTimer moves the task with the given key to the given delay.
+func (t *TimingWheelT[K, V]) MoveTimer(key K, delay time.Duration) error {
+	return t.tw.MoveTimer(key, delay)
+}
+
+// MoveTimersWithPrefix moves all the tasks whose keys have the given prefix
+// to the given delay, only applies when K is string.
+func (t *TimingWheelT[K, V]) MoveTimersWithPrefix(prefix string, delay time.Duration) error {
+	return t.tw.MoveTimersWithPrefix(prefix, delay)
+}
+
+// PendingTasks returns how many tasks are waiting to be fired.
+func (t *TimingWheelT[K, V]) PendingTasks() int {
+	return t.tw.PendingTasks()
+}
+
+// RemoveTimer removes the task with the given key.
+func (t *TimingWheelT[K, V]) RemoveTimer(key K) error {
+	return t.tw.RemoveTimer(key)
+}
+
+// RemoveTimersWithPrefix removes all the tasks whose keys have the given
+// prefix, only applies when K is string.
+func (t *TimingWheelT[K, V]) RemoveTimersWithPrefix(prefix string) error {
+	return t.tw.RemoveTimersWithPrefix(prefix)
+}
+
+// SetTimer sets the task value with the given key to the delay.
+func (t *TimingWheelT[K, V]) SetTimer(key K, value V, delay time.Duration) error {
+	return t.tw.SetTimer(key, value, delay)
+}
+
+// SetTimerCtx is like SetTimer, but the task is skipped if ctx is done
+// before it fires.
+func (t *TimingWheelT[K, V]) SetTimerCtx(ctx context.Context, key K, value V,
+	delay time.Duration) error {
+	return t.tw.SetTimerCtx(ctx, key, value, delay)
+}
+
+// Stop stops t. No more actions after stopping a TimingWheelT.
+func (t *TimingWheelT[K, V]) Stop() {
+	t.tw.Stop()
+}
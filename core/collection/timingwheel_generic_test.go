@@ -0,0 +1,45 @@
+package collection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/timex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTimingWheelT(t *testing.T) {
+	_, err := NewTimingWheelT[string, int](time.Second, 10, nil)
+	assert.Equal(t, ErrArgument, err)
+
+	_, err = NewTimingWheelT[string, int](0, 10, func(key string, value int) {})
+	assert.NotNil(t, err)
+}
+
+func TestTimingWheelT(t *testing.T) {
+	ticker := timex.NewFakeTicker()
+	done := make(chan struct{})
+	tw, err := newTimingWheelWithClock(testStep, 10, func(k, v interface{}) {
+		assert.Equal(t, "any", k)
+		assert.Equal(t, 3, v.(int))
+		close(done)
+		ticker.Done()
+	}, ticker)
+	assert.Nil(t, err)
+	typed := &TimingWheelT[string, int]{tw: tw}
+	defer typed.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Nil(t, typed.SetTimerCtx(ctx, "any", 3, testStep*3))
+	assert.Equal(t, 1, typed.PendingTasks())
+	assert.Nil(t, typed.MoveTimer("any", testStep*2))
+
+	for i := 0; i < 4; i++ {
+		ticker.Tick()
+	}
+	assert.Nil(t, ticker.Wait(waitTime))
+	<-done
+	assert.Equal(t, int64(1), typed.FiredTasks())
+}
@@ -1,6 +1,7 @@
 package collection
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -640,3 +641,72 @@ func BenchmarkTimingWheel(b *testing.B) {
 		tw.RemoveTimer(i)
 	}
 }
+
+func TestTimingWheel_SetTimerCtx(t *testing.T) {
+	var count int32
+	ticker := timex.NewFakeTicker()
+	tw, _ := newTimingWheelWithClock(testStep, 10, func(k, v interface{}) {
+		assert.Equal(t, "active", k)
+		atomic.AddInt32(&count, 1)
+		ticker.Done()
+	}, ticker)
+	defer tw.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.Nil(t, tw.SetTimerCtx(ctx, "canceled", 1, testStep*3))
+	assert.Nil(t, tw.SetTimer("active", 2, testStep*3))
+	cancel()
+
+	for i := 0; i < 5; i++ {
+		ticker.Tick()
+	}
+	assert.Nil(t, ticker.Wait(waitTime))
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+	assert.Equal(t, int64(1), tw.FiredTasks())
+}
+
+func TestTimingWheel_RemoveTimersWithPrefix(t *testing.T) {
+	var count int32
+	ticker := timex.NewFakeTicker()
+	tw, _ := newTimingWheelWithClock(testStep, 10, func(k, v interface{}) {
+		assert.Equal(t, "other", k)
+		atomic.AddInt32(&count, 1)
+		ticker.Done()
+	}, ticker)
+	defer tw.Stop()
+
+	assert.Nil(t, tw.SetTimer("task:1", 1, testStep*3))
+	assert.Nil(t, tw.SetTimer("task:2", 2, testStep*3))
+	assert.Nil(t, tw.SetTimer("other", 3, testStep*3))
+	assert.Equal(t, 3, tw.PendingTasks())
+	assert.Nil(t, tw.RemoveTimersWithPrefix("task:"))
+
+	for i := 0; i < 5; i++ {
+		ticker.Tick()
+	}
+	assert.Nil(t, ticker.Wait(waitTime))
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestTimingWheel_MoveTimersWithPrefix(t *testing.T) {
+	run := syncx.NewAtomicBool()
+	ticker := timex.NewFakeTicker()
+	tw, _ := newTimingWheelWithClock(testStep, 10, func(k, v interface{}) {
+		assert.True(t, run.CompareAndSwap(false, true))
+		assert.Equal(t, "task:1", k)
+		ticker.Done()
+	}, ticker)
+	defer tw.Stop()
+
+	assert.Nil(t, tw.SetTimer("task:1", 1, testStep*8))
+	assert.NotNil(t, tw.MoveTimersWithPrefix("task:", 0))
+	assert.Nil(t, tw.MoveTimersWithPrefix("task:", testStep*2))
+
+	for i := 0; i < 3; i++ {
+		ticker.Tick()
+	}
+	assert.Nil(t, ticker.Wait(waitTime))
+	assert.True(t, run.True())
+}
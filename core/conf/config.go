@@ -10,6 +10,12 @@ import (
 	"github.com/qkbyte/go-zero/core/mapping"
 )
 
+// Validator is the interface that config structs can implement to validate
+// themselves, Validate is called after the config is loaded.
+type Validator interface {
+	Validate() error
+}
+
 var loaders = map[string]func([]byte, interface{}) error{
 	".json": LoadFromJsonBytes,
 	".toml": LoadFromTomlBytes,
@@ -35,12 +41,26 @@ func Load(file string, v interface{}, opts ...Option) error {
 	}
 
 	if opt.env {
-		return loader([]byte(os.ExpandEnv(string(content))), v)
+		return loader([]byte(expandEnv(string(content))), v)
 	}
 
 	return loader(content, v)
 }
 
+// expandEnv replaces ${var} or $var in content with the environment values,
+// ${var:default} falls back to default when var is unset or empty.
+func expandEnv(content string) string {
+	return os.Expand(content, func(name string) string {
+		name, def, ok := strings.Cut(name, ":")
+		val := os.Getenv(name)
+		if ok && len(val) == 0 {
+			return def
+		}
+
+		return val
+	})
+}
+
 // LoadConfig loads config into v from file, .json, .yaml and .yml are acceptable.
 // Deprecated: use Load instead.
 func LoadConfig(file string, v interface{}, opts ...Option) error {
@@ -49,7 +69,11 @@ func LoadConfig(file string, v interface{}, opts ...Option) error {
 
 // LoadFromJsonBytes loads config into v from content json bytes.
 func LoadFromJsonBytes(content []byte, v interface{}) error {
-	return mapping.UnmarshalJsonBytes(content, v)
+	if err := mapping.UnmarshalJsonBytes(content, v); err != nil {
+		return err
+	}
+
+	return validate(v)
 }
 
 // LoadConfigFromJsonBytes loads config into v from content json bytes.
@@ -60,12 +84,20 @@ func LoadConfigFromJsonBytes(content []byte, v interface{}) error {
 
 // LoadFromTomlBytes loads config into v from content toml bytes.
 func LoadFromTomlBytes(content []byte, v interface{}) error {
-	return mapping.UnmarshalTomlBytes(content, v)
+	if err := mapping.UnmarshalTomlBytes(content, v); err != nil {
+		return err
+	}
+
+	return validate(v)
 }
 
 // LoadFromYamlBytes loads config into v from content yaml bytes.
 func LoadFromYamlBytes(content []byte, v interface{}) error {
-	return mapping.UnmarshalYamlBytes(content, v)
+	if err := mapping.UnmarshalYamlBytes(content, v); err != nil {
+		return err
+	}
+
+	return validate(v)
 }
 
 // LoadConfigFromYamlBytes loads config into v from content yaml bytes.
@@ -74,6 +106,15 @@ func LoadConfigFromYamlBytes(content []byte, v interface{}) error {
 	return LoadFromYamlBytes(content, v)
 }
 
+// validate calls Validate on v if it implements the Validator interface.
+func validate(v interface{}) error {
+	if vd, ok := v.(Validator); ok {
+		return vd.Validate()
+	}
+
+	return nil
+}
+
 // MustLoad loads config into v from path, exits on error.
 func MustLoad(path string, v interface{}, opts ...Option) {
 	if err := Load(path, v, opts...); err != nil {
@@ -1,6 +1,7 @@
 package conf
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -144,6 +145,74 @@ func TestConfigJsonEnv(t *testing.T) {
 	}
 }
 
+type validatorConf struct {
+	Port int `json:"port"`
+}
+
+func (c *validatorConf) Validate() error {
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", c.Port)
+	}
+
+	return nil
+}
+
+func TestConfigValidate(t *testing.T) {
+	tmpfile, err := createTempFile(".json", `{"port": 8080}`)
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile)
+
+	var val validatorConf
+	assert.Nil(t, Load(tmpfile, &val))
+	assert.Equal(t, 8080, val.Port)
+}
+
+func TestConfigValidateError(t *testing.T) {
+	tmpfile, err := createTempFile(".json", `{"port": -1}`)
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile)
+
+	var val validatorConf
+	err = Load(tmpfile, &val)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid port")
+}
+
+func TestConfigEnvDefault(t *testing.T) {
+	tests := []string{
+		".json",
+		".yaml",
+		".yml",
+	}
+	text := `{
+	"a": "${FOO:foo}",
+	"b": "${BAR:bar}",
+	"c": "${EMPTY:fallback}"
+}`
+	for _, test := range tests {
+		test := test
+		t.Run(test, func(t *testing.T) {
+			os.Setenv("FOO", "2")
+			defer os.Unsetenv("FOO")
+			os.Setenv("EMPTY", "")
+			defer os.Unsetenv("EMPTY")
+			tmpfile, err := createTempFile(test, text)
+			assert.Nil(t, err)
+			defer os.Remove(tmpfile)
+
+			var val struct {
+				A string `json:"a"`
+				B string `json:"b"`
+				C string `json:"c"`
+			}
+			MustLoad(tmpfile, &val, UseEnv())
+			assert.Equal(t, "2", val.A)
+			assert.Equal(t, "bar", val.B)
+			assert.Equal(t, "fallback", val.C)
+		})
+	}
+}
+
 func createTempFile(ext, text string) (string, error) {
 	tmpfile, err := os.CreateTemp(os.TempDir(), hash.Md5Hex([]byte(text))+"*"+ext)
 	if err != nil {
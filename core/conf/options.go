@@ -9,7 +9,8 @@ type (
 	}
 )
 
-// UseEnv customizes the config to use environment variables.
+// UseEnv customizes the config to use environment variables,
+// ${var:default} placeholders fall back to default when var is unset or empty.
 func UseEnv() Option {
 	return func(opt *options) {
 		opt.env = true
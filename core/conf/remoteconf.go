@@ -0,0 +1,133 @@
+package conf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/stores/kvstore"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+type (
+	// ChangeListener is called with the freshly loaded config on each change.
+	ChangeListener[T any] func(v T)
+
+	// A RemoteConf loads typed config from a key in a configuration center
+	// and reloads it on changes, so tunables like log level, limits and
+	// feature flags can be adjusted without redeploys.
+	// Any backend implementing kvstore.Store works, like etcd or redis.
+	RemoteConf[T any] struct {
+		cancel    context.CancelFunc
+		lock      sync.RWMutex
+		current   T
+		listeners []ChangeListener[T]
+	}
+)
+
+// NewRemoteConf loads config of the given key from store, and watches the key
+// for changes. ext determines the config format like in Load, .json, .toml,
+// .yaml and .yml are acceptable. A change that fails to unmarshal is logged
+// and ignored, the previous config stays in effect.
+func NewRemoteConf[T any](store kvstore.Store, key, ext string, opts ...Option) (*RemoteConf[T], error) {
+	loader, ok := loaders[strings.ToLower(ext)]
+	if !ok {
+		return nil, fmt.Errorf("unrecognized config ext: %s", ext)
+	}
+
+	var opt options
+	for _, o := range opts {
+		o(&opt)
+	}
+	unmarshal := func(content string) (v T, err error) {
+		if opt.env {
+			content = expandEnv(content)
+		}
+
+		err = loader([]byte(content), &v)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	content, found, err := store.Get(ctx, key)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if !found {
+		cancel()
+		return nil, fmt.Errorf("config key not found: %s", key)
+	}
+
+	current, err := unmarshal(content)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	rc := &RemoteConf[T]{
+		cancel:  cancel,
+		current: current,
+	}
+	if err := store.Watch(ctx, key, func(event kvstore.Event) {
+		if event.Type != kvstore.EventPut {
+			return
+		}
+
+		v, err := unmarshal(event.Value)
+		if err != nil {
+			logx.Errorf("ignore bad config of key %s: %s", key, err.Error())
+			return
+		}
+
+		rc.update(v)
+	}); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return rc, nil
+}
+
+// MustNewRemoteConf returns a RemoteConf like NewRemoteConf, exits on error.
+func MustNewRemoteConf[T any](store kvstore.Store, key, ext string, opts ...Option) *RemoteConf[T] {
+	rc, err := NewRemoteConf[T](store, key, ext, opts...)
+	logx.Must(err)
+	return rc
+}
+
+// OnChange registers listener to be called with the new config on each change.
+func (rc *RemoteConf[T]) OnChange(listener ChangeListener[T]) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	rc.listeners = append(rc.listeners, listener)
+}
+
+// Stop stops watching for changes.
+func (rc *RemoteConf[T]) Stop() {
+	rc.cancel()
+}
+
+// Value returns the current config.
+func (rc *RemoteConf[T]) Value() T {
+	rc.lock.RLock()
+	defer rc.lock.RUnlock()
+	return rc.current
+}
+
+func (rc *RemoteConf[T]) update(v T) {
+	rc.lock.Lock()
+	rc.current = v
+	listeners := make([]ChangeListener[T], len(rc.listeners))
+	copy(listeners, rc.listeners)
+	rc.lock.Unlock()
+
+	for _, listener := range listeners {
+		listener := listener
+		threading.RunSafe(func() {
+			listener(v)
+		})
+	}
+}
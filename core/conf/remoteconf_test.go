@@ -0,0 +1,79 @@
+package conf
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/stores/kvstore"
+	"github.com/stretchr/testify/assert"
+)
+
+type remoteTestConf struct {
+	Name    string `json:"name"`
+	Threads int    `json:"threads,default=4"`
+}
+
+func TestRemoteConf(t *testing.T) {
+	store := kvstore.NewMemoryStore()
+	ctx := context.Background()
+	assert.Nil(t, store.Set(ctx, "app", `{"name": "foo"}`, 0))
+
+	rc, err := NewRemoteConf[remoteTestConf](store, "app", ".json")
+	assert.Nil(t, err)
+	defer rc.Stop()
+
+	val := rc.Value()
+	assert.Equal(t, "foo", val.Name)
+	assert.Equal(t, 4, val.Threads)
+
+	var changes int32
+	changed := make(chan remoteTestConf, 1)
+	rc.OnChange(func(v remoteTestConf) {
+		atomic.AddInt32(&changes, 1)
+		changed <- v
+	})
+
+	assert.Nil(t, store.Set(ctx, "app", `{"name": "bar", "threads": 8}`, 0))
+	select {
+	case v := <-changed:
+		assert.Equal(t, "bar", v.Name)
+		assert.Equal(t, 8, v.Threads)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for change")
+	}
+	val = rc.Value()
+	assert.Equal(t, "bar", val.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&changes))
+}
+
+func TestRemoteConfBadChange(t *testing.T) {
+	store := kvstore.NewMemoryStore()
+	ctx := context.Background()
+	assert.Nil(t, store.Set(ctx, "app", `{"name": "foo"}`, 0))
+
+	rc, err := NewRemoteConf[remoteTestConf](store, "app", ".json")
+	assert.Nil(t, err)
+	defer rc.Stop()
+
+	assert.Nil(t, store.Set(ctx, "app", `not json`, 0))
+	assert.Nil(t, store.Set(ctx, "app", `{"name": "baz"}`, 0))
+	assert.Eventually(t, func() bool {
+		return rc.Value().Name == "baz"
+	}, time.Second, time.Millisecond*10)
+}
+
+func TestRemoteConfErrors(t *testing.T) {
+	store := kvstore.NewMemoryStore()
+
+	_, err := NewRemoteConf[remoteTestConf](store, "app", ".properties")
+	assert.NotNil(t, err)
+
+	_, err = NewRemoteConf[remoteTestConf](store, "missing", ".json")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, store.Set(context.Background(), "app", `not json`, 0))
+	_, err = NewRemoteConf[remoteTestConf](store, "app", ".json")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,96 @@
+package conf
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/qkbyte/go-zero/core/lang"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+// debounce the burst of events on a single config update,
+// kubernetes swaps ConfigMap files with several quick renames.
+const watchDebounceInterval = time.Millisecond * 100
+
+// LoadAndWatch loads config into v from file like Load, and watches the file
+// for changes. On each change the file is reloaded into a fresh value that is
+// passed to onChange, v itself is not touched again, so readers never observe
+// a half-updated config. A change that fails to load is logged and ignored.
+// The watch follows file replaces, so ConfigMap updates in kubernetes are
+// picked up live. The returned func stops the watching.
+func LoadAndWatch[T any](file string, v *T, onChange func(v T), opts ...Option) (func(), error) {
+	if err := Load(file, v, opts...); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// watch the directory instead of the file itself,
+	// editors and kubernetes replace the file on updates.
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	done := make(chan lang.PlaceholderType)
+	threading.GoSafe(func() {
+		defer watcher.Close()
+
+		target := filepath.Clean(file)
+		timer := time.NewTimer(watchDebounceInterval)
+		if !timer.Stop() {
+			<-timer.C
+		}
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != target {
+					continue
+				}
+
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(watchDebounceInterval)
+			case <-timer.C:
+				var fresh T
+				if err := Load(file, &fresh, opts...); err != nil {
+					logx.Errorf("ignore bad config file %s: %s", file, err.Error())
+					continue
+				}
+
+				threading.RunSafe(func() {
+					onChange(fresh)
+				})
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logx.Errorf("watch config file %s: %s", file, err.Error())
+			}
+		}
+	})
+
+	var stopOnce sync.Once
+	return func() {
+		stopOnce.Do(func() {
+			close(done)
+		})
+	}, nil
+}
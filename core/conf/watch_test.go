@@ -0,0 +1,70 @@
+package conf
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAndWatch(t *testing.T) {
+	tmpfile, err := createTempFile(".json", `{"name": "foo"}`)
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile)
+
+	var val struct {
+		Name string `json:"name"`
+	}
+	changed := make(chan string, 1)
+	stop, err := LoadAndWatch(tmpfile, &val, func(v struct {
+		Name string `json:"name"`
+	}) {
+		changed <- v.Name
+	})
+	assert.Nil(t, err)
+	defer stop()
+	assert.Equal(t, "foo", val.Name)
+
+	assert.Nil(t, os.WriteFile(tmpfile, []byte(`{"name": "bar"}`), 0o644))
+	select {
+	case name := <-changed:
+		assert.Equal(t, "bar", name)
+	case <-time.After(time.Second * 3):
+		t.Fatal("timeout waiting for change")
+	}
+}
+
+func TestLoadAndWatchBadChange(t *testing.T) {
+	tmpfile, err := createTempFile(".json", `{"name": "foo"}`)
+	assert.Nil(t, err)
+	defer os.Remove(tmpfile)
+
+	var val struct {
+		Name string `json:"name"`
+	}
+	changed := make(chan string, 2)
+	stop, err := LoadAndWatch(tmpfile, &val, func(v struct {
+		Name string `json:"name"`
+	}) {
+		changed <- v.Name
+	})
+	assert.Nil(t, err)
+	defer stop()
+
+	assert.Nil(t, os.WriteFile(tmpfile, []byte(`not json`), 0o644))
+	time.Sleep(watchDebounceInterval * 3)
+	assert.Nil(t, os.WriteFile(tmpfile, []byte(`{"name": "baz"}`), 0o644))
+	select {
+	case name := <-changed:
+		assert.Equal(t, "baz", name)
+	case <-time.After(time.Second * 3):
+		t.Fatal("timeout waiting for change")
+	}
+}
+
+func TestLoadAndWatchNotExists(t *testing.T) {
+	var val struct{}
+	_, err := LoadAndWatch("not-exist.json", &val, func(struct{}) {})
+	assert.NotNil(t, err)
+}
@@ -52,6 +52,16 @@ func (be *BulkExecutor) Flush() {
 	be.executor.Flush()
 }
 
+// OnError registers fn to be called when executing a batch panics.
+func (be *BulkExecutor) OnError(fn ErrorListener) {
+	be.executor.OnError(fn)
+}
+
+// OnFlush registers fn to be called after each executed batch.
+func (be *BulkExecutor) OnFlush(fn FlushListener) {
+	be.executor.OnFlush(fn)
+}
+
 // Wait waits be to done with the task execution.
 func (be *BulkExecutor) Wait() {
 	be.executor.Wait()
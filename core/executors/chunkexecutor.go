@@ -55,6 +55,16 @@ func (ce *ChunkExecutor) Flush() {
 	ce.executor.Flush()
 }
 
+// OnError registers fn to be called when executing a batch panics.
+func (ce *ChunkExecutor) OnError(fn ErrorListener) {
+	ce.executor.OnError(fn)
+}
+
+// OnFlush registers fn to be called after each executed batch.
+func (ce *ChunkExecutor) OnFlush(fn FlushListener) {
+	ce.executor.OnFlush(fn)
+}
+
 // Wait waits the execution to be done.
 func (ce *ChunkExecutor) Wait() {
 	ce.executor.Wait()
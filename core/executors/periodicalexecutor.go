@@ -1,6 +1,7 @@
 package executors
 
 import (
+	"fmt"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -28,6 +29,21 @@ type (
 		RemoveAll() interface{}
 	}
 
+	// FlushMeta carries the metadata of a flushed batch.
+	FlushMeta struct {
+		// Tasks is how many tasks the batch contained.
+		Tasks int
+		// Duration is how long the execution took.
+		Duration time.Duration
+	}
+
+	// FlushListener is called after a batch got executed.
+	FlushListener func(meta FlushMeta)
+
+	// ErrorListener is called when executing a batch panics,
+	// without one registered the panic propagates as before.
+	ErrorListener func(meta FlushMeta, err error)
+
 	// A PeriodicalExecutor is an executor that periodically execute tasks.
 	PeriodicalExecutor struct {
 		commander chan interface{}
@@ -35,12 +51,14 @@ type (
 		container TaskContainer
 		waitGroup sync.WaitGroup
 		// avoid race condition on waitGroup when calling wg.Add/Done/Wait(...)
-		wgBarrier   syncx.Barrier
-		confirmChan chan lang.PlaceholderType
-		inflight    int32
-		guarded     bool
-		newTicker   func(duration time.Duration) timex.Ticker
-		lock        sync.Mutex
+		wgBarrier     syncx.Barrier
+		confirmChan   chan lang.PlaceholderType
+		inflight      int32
+		guarded       bool
+		newTicker     func(duration time.Duration) timex.Ticker
+		flushListener FlushListener
+		errorListener ErrorListener
+		lock          sync.Mutex
 	}
 )
 
@@ -56,9 +74,11 @@ func NewPeriodicalExecutor(interval time.Duration, container TaskContainer) *Per
 			return timex.NewTicker(d)
 		},
 	}
-	proc.AddShutdownListener(func() {
-		executor.Flush()
-	})
+	// flush and wait for the in-flight batches on shutdown,
+	// so the tail batches are not lost on deploys.
+	proc.AddShutdownListenerWithPriority(func() {
+		executor.Wait()
+	}, proc.PriorityFlush)
 
 	return executor
 }
@@ -81,6 +101,22 @@ func (pe *PeriodicalExecutor) Flush() bool {
 	}())
 }
 
+// OnError registers fn to be called when executing a batch panics,
+// the panic no longer propagates once a listener is registered.
+func (pe *PeriodicalExecutor) OnError(fn ErrorListener) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	pe.errorListener = fn
+}
+
+// OnFlush registers fn to be called after each executed batch,
+// can be used to export flush metrics.
+func (pe *PeriodicalExecutor) OnFlush(fn FlushListener) {
+	pe.lock.Lock()
+	defer pe.lock.Unlock()
+	pe.flushListener = fn
+}
+
 // Sync lets caller to run fn thread-safe with pe, especially for the underlying container.
 func (pe *PeriodicalExecutor) Sync(fn func()) {
 	pe.lock.Lock()
@@ -160,12 +196,48 @@ func (pe *PeriodicalExecutor) enterExecution() {
 func (pe *PeriodicalExecutor) executeTasks(tasks interface{}) bool {
 	defer pe.doneExecution()
 
-	ok := pe.hasTasks(tasks)
-	if ok {
-		pe.container.Execute(tasks)
+	if !pe.hasTasks(tasks) {
+		return false
+	}
+
+	pe.lock.Lock()
+	flushListener := pe.flushListener
+	errorListener := pe.errorListener
+	pe.lock.Unlock()
+
+	meta := FlushMeta{
+		Tasks: pe.countTasks(tasks),
 	}
+	start := timex.Now()
+	defer func() {
+		meta.Duration = timex.Since(start)
+		if r := recover(); r != nil {
+			if errorListener == nil {
+				panic(r)
+			}
+
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			errorListener(meta, err)
+		} else if flushListener != nil {
+			flushListener(meta)
+		}
+	}()
+	pe.container.Execute(tasks)
+
+	return true
+}
 
-	return ok
+func (pe *PeriodicalExecutor) countTasks(tasks interface{}) int {
+	val := reflect.ValueOf(tasks)
+	switch val.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
+		return val.Len()
+	default:
+		return 1
+	}
 }
 
 func (pe *PeriodicalExecutor) hasTasks(tasks interface{}) bool {
@@ -169,3 +169,44 @@ func BenchmarkExecutor(b *testing.B) {
 		executor.Add(1)
 	}
 }
+
+func TestPeriodicalExecutor_OnFlush(t *testing.T) {
+	var meta FlushMeta
+	exec := NewPeriodicalExecutor(time.Second, newContainer(0, func(tasks interface{}) {}))
+	exec.OnFlush(func(m FlushMeta) {
+		meta = m
+	})
+	exec.Add(1)
+	exec.Add(2)
+	exec.Add(3)
+	exec.Flush()
+	assert.Equal(t, 3, meta.Tasks)
+	assert.True(t, meta.Duration >= 0)
+}
+
+func TestPeriodicalExecutor_OnError(t *testing.T) {
+	var meta FlushMeta
+	var execErr error
+	exec := NewPeriodicalExecutor(time.Second, newContainer(0, func(tasks interface{}) {
+		panic("mock panic")
+	}))
+	exec.OnError(func(m FlushMeta, err error) {
+		meta = m
+		execErr = err
+	})
+	exec.Add(1)
+	exec.Flush()
+	assert.NotNil(t, execErr)
+	assert.Equal(t, "mock panic", execErr.Error())
+	assert.Equal(t, 1, meta.Tasks)
+}
+
+func TestPeriodicalExecutor_PanicWithoutErrorListener(t *testing.T) {
+	exec := NewPeriodicalExecutor(time.Second, newContainer(0, func(tasks interface{}) {
+		panic("mock panic")
+	}))
+	exec.Add(1)
+	assert.Panics(t, func() {
+		exec.Flush()
+	})
+}
@@ -3,6 +3,7 @@ package fx
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/qkbyte/go-zero/core/collection"
 	"github.com/qkbyte/go-zero/core/lang"
@@ -20,8 +21,12 @@ type (
 		workers          int
 	}
 
+	// EqualFunc defines the method to compare the elements in a Stream for equality.
+	EqualFunc func(a, b interface{}) bool
 	// FilterFunc defines the method to filter a Stream.
 	FilterFunc func(item interface{}) bool
+	// FlatMapFunc defines the method to map each element to zero or more elements in a Stream.
+	FlatMapFunc func(item interface{}) []interface{}
 	// ForAllFunc defines the method to handle all elements in a Stream.
 	ForAllFunc func(pipe <-chan interface{})
 	// ForEachFunc defines the method to handle each element in a Stream.
@@ -114,6 +119,62 @@ func (s Stream) AnyMach(predicate func(item interface{}) bool) bool {
 	return false
 }
 
+// Batch groups the elements into chunks with size up to n,
+// a chunk is emitted earlier if maxWait elapsed before it's full,
+// so event-processing pipelines can micro-batch writes without hand-rolled tickers.
+func (s Stream) Batch(n int, maxWait time.Duration) Stream {
+	if n < 1 {
+		panic("n should be greater than 0")
+	}
+	if maxWait <= 0 {
+		panic("maxWait should be greater than 0")
+	}
+
+	source := make(chan interface{})
+
+	go func() {
+		timer := time.NewTimer(maxWait)
+		defer timer.Stop()
+
+		var chunk []interface{}
+		flush := func() {
+			if len(chunk) > 0 {
+				source <- chunk
+				chunk = nil
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(maxWait)
+		}
+
+		for {
+			select {
+			case item, ok := <-s.source:
+				if !ok {
+					if len(chunk) > 0 {
+						source <- chunk
+					}
+					close(source)
+					return
+				}
+
+				chunk = append(chunk, item)
+				if len(chunk) == n {
+					flush()
+				}
+			case <-timer.C:
+				flush()
+			}
+		}
+	}()
+
+	return Range(source)
+}
+
 // Buffer buffers the items into a queue with size n.
 // It can balance the producer and the consumer if their processing throughput don't match.
 func (s Stream) Buffer(n int) Stream {
@@ -188,6 +249,33 @@ func (s Stream) Distinct(fn KeyFunc) Stream {
 	return Range(source)
 }
 
+// DistinctBy removes the duplicated items determined by the given EqualFunc,
+// for the elements whose keys are not comparable, prefer Distinct otherwise.
+func (s Stream) DistinctBy(fn EqualFunc) Stream {
+	source := make(chan interface{})
+
+	threading.GoSafe(func() {
+		defer close(source)
+
+		var seen []interface{}
+		for item := range s.source {
+			var dup bool
+			for _, prev := range seen {
+				if fn(prev, item) {
+					dup = true
+					break
+				}
+			}
+			if !dup {
+				seen = append(seen, item)
+				source <- item
+			}
+		}
+	})
+
+	return Range(source)
+}
+
 // Done waits all upstreaming operations to be done.
 func (s Stream) Done() {
 	drain(s.source)
@@ -213,6 +301,15 @@ func (s Stream) First() interface{} {
 	return nil
 }
 
+// FlatMap converts each item to zero or more corresponding items, which means it's a 1:N model.
+func (s Stream) FlatMap(fn FlatMapFunc, opts ...Option) Stream {
+	return s.Walk(func(item interface{}, pipe chan<- interface{}) {
+		for _, each := range fn(item) {
+			pipe <- each
+		}
+	}, opts...)
+}
+
 // ForAll handles the streaming elements from the source and no later streams.
 func (s Stream) ForAll(fn ForAllFunc) {
 	fn(s.source)
@@ -435,6 +532,44 @@ func (s Stream) Tail(n int64) Stream {
 	return Range(source)
 }
 
+// Window groups the elements arriving within each window duration into chunks,
+// empty windows are skipped.
+func (s Stream) Window(window time.Duration) Stream {
+	if window <= 0 {
+		panic("window should be greater than 0")
+	}
+
+	source := make(chan interface{})
+
+	go func() {
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+
+		var chunk []interface{}
+		for {
+			select {
+			case item, ok := <-s.source:
+				if !ok {
+					if len(chunk) > 0 {
+						source <- chunk
+					}
+					close(source)
+					return
+				}
+
+				chunk = append(chunk, item)
+			case <-ticker.C:
+				if len(chunk) > 0 {
+					source <- chunk
+					chunk = nil
+				}
+			}
+		}
+	}()
+
+	return Range(source)
+}
+
 // Walk lets the callers handle each item, the caller may write zero, one or more items base on the given item.
 func (s Stream) Walk(fn WalkFunc, opts ...Option) Stream {
 	option := buildOptions(opts...)
@@ -547,6 +547,99 @@ func BenchmarkMapReduce(b *testing.B) {
 	}).Map(mapper).Reduce(reducer)
 }
 
+func TestBatch(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var chunks [][]interface{}
+		Just(1, 2, 3, 4, 5).Batch(2, time.Minute).ForEach(func(item interface{}) {
+			chunks = append(chunks, item.([]interface{}))
+		})
+		assert.Equal(t, 3, len(chunks))
+		assert.Equal(t, []interface{}{1, 2}, chunks[0])
+		assert.Equal(t, []interface{}{3, 4}, chunks[1])
+		assert.Equal(t, []interface{}{5}, chunks[2])
+	})
+}
+
+func TestBatchMaxWait(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var chunks [][]interface{}
+		From(func(source chan<- interface{}) {
+			source <- 1
+			time.Sleep(time.Millisecond * 200)
+			source <- 2
+		}).Batch(10, time.Millisecond*50).ForEach(func(item interface{}) {
+			chunks = append(chunks, item.([]interface{}))
+		})
+		assert.Equal(t, 2, len(chunks))
+		assert.Equal(t, []interface{}{1}, chunks[0])
+		assert.Equal(t, []interface{}{2}, chunks[1])
+	})
+}
+
+func TestBatchPanic(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		assert.Panics(t, func() {
+			Just(1).Batch(0, time.Minute)
+		})
+		assert.Panics(t, func() {
+			Just(1).Batch(1, 0)
+		})
+	})
+}
+
+func TestDistinctBy(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var result int
+		Just(4, 1, 3, 2, 3, 4).DistinctBy(func(a, b interface{}) bool {
+			return a.(int) == b.(int)
+		}).ForEach(func(item interface{}) {
+			result += item.(int)
+		})
+		assert.Equal(t, 10, result)
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var result int32
+		Just(1, 2, 3).FlatMap(func(item interface{}) []interface{} {
+			i := item.(int)
+			return []interface{}{i, i * 10}
+		}).ForEach(func(item interface{}) {
+			atomic.AddInt32(&result, int32(item.(int)))
+		})
+		assert.Equal(t, int32(66), result)
+	})
+}
+
+func TestWindow(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var chunks [][]interface{}
+		From(func(source chan<- interface{}) {
+			source <- 1
+			source <- 2
+			time.Sleep(time.Millisecond * 200)
+			source <- 3
+		}).Window(time.Millisecond * 50).ForEach(func(item interface{}) {
+			chunks = append(chunks, item.([]interface{}))
+		})
+		assert.True(t, len(chunks) >= 2)
+		var count int
+		for _, chunk := range chunks {
+			count += len(chunk)
+		}
+		assert.Equal(t, 3, count)
+	})
+}
+
+func TestWindowPanic(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		assert.Panics(t, func() {
+			Just(1).Window(0)
+		})
+	})
+}
+
 func assetEqual(t *testing.T, except, data interface{}) {
 	if !reflect.DeepEqual(except, data) {
 		t.Errorf(" %v, want %v", data, except)
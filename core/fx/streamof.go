@@ -0,0 +1,176 @@
+package fx
+
+import (
+	"sync"
+
+	"github.com/qkbyte/go-zero/core/lang"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+// A StreamOf is a typed stream that can be used to do stream processing,
+// without the interface{} casts of Stream.
+// Methods can only keep the element type, use MapOf, ReduceOf and GroupOf
+// for the operators that change it.
+type StreamOf[T any] struct {
+	source <-chan T
+}
+
+// FromOf constructs a StreamOf from the given generate func.
+func FromOf[T any](generate func(source chan<- T)) StreamOf[T] {
+	source := make(chan T)
+
+	threading.GoSafe(func() {
+		defer close(source)
+		generate(source)
+	})
+
+	return RangeOf(source)
+}
+
+// JustOf converts the given items to a StreamOf.
+func JustOf[T any](items ...T) StreamOf[T] {
+	source := make(chan T, len(items))
+	for _, item := range items {
+		source <- item
+	}
+	close(source)
+
+	return RangeOf(source)
+}
+
+// RangeOf converts the given channel to a StreamOf.
+func RangeOf[T any](source <-chan T) StreamOf[T] {
+	return StreamOf[T]{
+		source: source,
+	}
+}
+
+// GroupOf groups the elements into different groups based on their keys.
+func GroupOf[T any, K comparable](s StreamOf[T], fn func(item T) K) StreamOf[[]T] {
+	groups := make(map[K][]T)
+	for item := range s.source {
+		key := fn(item)
+		groups[key] = append(groups[key], item)
+	}
+
+	source := make(chan []T)
+	go func() {
+		for _, group := range groups {
+			source <- group
+		}
+		close(source)
+	}()
+
+	return RangeOf(source)
+}
+
+// MapOf converts each item to another corresponding item, which means it's a 1:1 model.
+func MapOf[T, U any](s StreamOf[T], fn func(item T) U, opts ...Option) StreamOf[U] {
+	return walkOf(s, func(item T, pipe chan<- U) {
+		pipe <- fn(item)
+	}, opts...)
+}
+
+// ReduceOf is a utility method to let the caller deal with the underlying channel.
+func ReduceOf[T, U any](s StreamOf[T], fn func(pipe <-chan T) (U, error)) (U, error) {
+	return fn(s.source)
+}
+
+// Done waits all upstreaming operations to be done.
+func (s StreamOf[T]) Done() {
+	drainOf(s.source)
+}
+
+// Filter filters the items by the given filter func.
+func (s StreamOf[T]) Filter(fn func(item T) bool, opts ...Option) StreamOf[T] {
+	return walkOf(s, func(item T, pipe chan<- T) {
+		if fn(item) {
+			pipe <- item
+		}
+	}, opts...)
+}
+
+// ForEach seals the StreamOf with the fn on each item, no successive operations.
+func (s StreamOf[T]) ForEach(fn func(item T)) {
+	for item := range s.source {
+		fn(item)
+	}
+}
+
+// Walk lets the callers handle each item, the caller may write zero, one or more items base on the given item.
+func (s StreamOf[T]) Walk(fn func(item T, pipe chan<- T), opts ...Option) StreamOf[T] {
+	return walkOf(s, fn, opts...)
+}
+
+// drainOf drains the given channel.
+func drainOf[T any](channel <-chan T) {
+	for range channel {
+	}
+}
+
+// walkOf is the typed version of Stream.Walk, free function to let the item type change.
+func walkOf[T, U any](s StreamOf[T], fn func(item T, pipe chan<- U), opts ...Option) StreamOf[U] {
+	option := buildOptions(opts...)
+	if option.unlimitedWorkers {
+		return walkOfUnlimited(s, fn, option)
+	}
+
+	return walkOfLimited(s, fn, option)
+}
+
+func walkOfLimited[T, U any](s StreamOf[T], fn func(item T, pipe chan<- U),
+	option *rxOptions) StreamOf[U] {
+	pipe := make(chan U, option.workers)
+
+	go func() {
+		var wg sync.WaitGroup
+		pool := make(chan lang.PlaceholderType, option.workers)
+
+		for item := range s.source {
+			// important, used in another goroutine
+			val := item
+			pool <- lang.Placeholder
+			wg.Add(1)
+
+			// better to safely run caller defined method
+			threading.GoSafe(func() {
+				defer func() {
+					wg.Done()
+					<-pool
+				}()
+
+				fn(val, pipe)
+			})
+		}
+
+		wg.Wait()
+		close(pipe)
+	}()
+
+	return RangeOf(pipe)
+}
+
+func walkOfUnlimited[T, U any](s StreamOf[T], fn func(item T, pipe chan<- U),
+	option *rxOptions) StreamOf[U] {
+	pipe := make(chan U, option.workers)
+
+	go func() {
+		var wg sync.WaitGroup
+
+		for item := range s.source {
+			// important, used in another goroutine
+			val := item
+			wg.Add(1)
+			// better to safely run caller defined method
+			threading.GoSafe(func() {
+				defer wg.Done()
+				fn(val, pipe)
+			})
+		}
+
+		wg.Wait()
+		close(pipe)
+	}()
+
+	return RangeOf(pipe)
+}
@@ -0,0 +1,105 @@
+package fx
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromOf(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var result int
+		FromOf(func(source chan<- int) {
+			for i := 1; i < 5; i++ {
+				source <- i
+			}
+		}).ForEach(func(item int) {
+			result += item
+		})
+		assert.Equal(t, 10, result)
+	})
+}
+
+func TestFilterOf(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var result int32
+		JustOf(1, 2, 3, 4).Filter(func(item int) bool {
+			return item%2 == 0
+		}).ForEach(func(item int) {
+			atomic.AddInt32(&result, int32(item))
+		})
+		assert.Equal(t, int32(6), result)
+	})
+}
+
+func TestMapOf(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var lock sync.Mutex
+		var result []string
+		MapOf(JustOf(1, 2, 3), func(item int) string {
+			return string(rune('a' + item - 1))
+		}).ForEach(func(item string) {
+			lock.Lock()
+			result = append(result, item)
+			lock.Unlock()
+		})
+		sort.Strings(result)
+		assert.Equal(t, []string{"a", "b", "c"}, result)
+	})
+}
+
+func TestReduceOf(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		result, err := ReduceOf(JustOf(1, 2, 3, 4), func(pipe <-chan int) (int, error) {
+			var sum int
+			for item := range pipe {
+				sum += item
+			}
+			return sum, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 10, result)
+	})
+}
+
+func TestGroupOf(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var groups [][]int
+		GroupOf(JustOf(10, 11, 20, 21), func(item int) int {
+			return item / 10
+		}).ForEach(func(group []int) {
+			groups = append(groups, group)
+		})
+		assert.Equal(t, 2, len(groups))
+		for _, group := range groups {
+			assert.Equal(t, 2, len(group))
+		}
+	})
+}
+
+func TestWalkOf(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var result int32
+		JustOf(1, 2, 3).Walk(func(item int, pipe chan<- int) {
+			pipe <- item
+			pipe <- item * 10
+		}, UnlimitedWorkers()).ForEach(func(item int) {
+			atomic.AddInt32(&result, int32(item))
+		})
+		assert.Equal(t, int32(66), result)
+	})
+}
+
+func TestDoneOf(t *testing.T) {
+	runCheckedTest(t, func(t *testing.T) {
+		var count int32
+		JustOf(1, 2, 3).Walk(func(item int, pipe chan<- int) {
+			atomic.AddInt32(&count, 1)
+			pipe <- item
+		}).Done()
+		assert.Equal(t, int32(3), count)
+	})
+}
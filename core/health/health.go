@@ -0,0 +1,118 @@
+// Package health provides a unified health check registry. Components like
+// redis, sql or queue consumers register probes, and the aggregated results
+// are served through the rest /healthz and /readyz endpoints and the zrpc
+// health service, instead of the port-open-only health semantics.
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/qkbyte/go-zero/core/errorx"
+)
+
+// CheckFunc is a health probe, nil means healthy.
+type CheckFunc func() error
+
+type manager struct {
+	lock      sync.RWMutex
+	liveness  map[string]CheckFunc
+	readiness map[string]CheckFunc
+}
+
+var defaultManager = newManager()
+
+func newManager() *manager {
+	return &manager{
+		liveness:  make(map[string]CheckFunc),
+		readiness: make(map[string]CheckFunc),
+	}
+}
+
+// AddLivenessCheck registers a liveness probe with the given name.
+// Liveness probes should only fail when the process is beyond repair
+// and needs a restart.
+func AddLivenessCheck(name string, check CheckFunc) {
+	defaultManager.lock.Lock()
+	defer defaultManager.lock.Unlock()
+	defaultManager.liveness[name] = check
+}
+
+// AddReadinessCheck registers a readiness probe with the given name.
+// Readiness probes fail when the service temporarily can't serve traffic,
+// like a lost database connection or a lagging consumer.
+func AddReadinessCheck(name string, check CheckFunc) {
+	defaultManager.lock.Lock()
+	defer defaultManager.lock.Unlock()
+	defaultManager.readiness[name] = check
+}
+
+// RemoveCheck removes the probes registered with the given name.
+func RemoveCheck(name string) {
+	defaultManager.lock.Lock()
+	defer defaultManager.lock.Unlock()
+	delete(defaultManager.liveness, name)
+	delete(defaultManager.readiness, name)
+}
+
+// CheckLive runs all liveness probes, the failures are aggregated.
+func CheckLive() error {
+	return defaultManager.check(func(m *manager) map[string]CheckFunc {
+		return m.liveness
+	})
+}
+
+// CheckReady runs all liveness and readiness probes, a dead process is not
+// ready either, the failures are aggregated.
+func CheckReady() error {
+	if err := CheckLive(); err != nil {
+		return err
+	}
+
+	return defaultManager.check(func(m *manager) map[string]CheckFunc {
+		return m.readiness
+	})
+}
+
+// LiveHandler returns an http handler that serves the liveness state,
+// 200 when live, 503 with the failures otherwise.
+func LiveHandler() http.Handler {
+	return checkHandler(CheckLive)
+}
+
+// ReadyHandler returns an http handler that serves the readiness state,
+// 200 when ready, 503 with the failures otherwise.
+func ReadyHandler() http.Handler {
+	return checkHandler(CheckReady)
+}
+
+func (m *manager) check(probes func(m *manager) map[string]CheckFunc) error {
+	m.lock.RLock()
+	checks := make(map[string]CheckFunc, len(probes(m)))
+	for name, check := range probes(m) {
+		checks[name] = check
+	}
+	m.lock.RUnlock()
+
+	var batchErr errorx.BatchError
+	for name, check := range checks {
+		if err := check(); err != nil {
+			batchErr.Add(fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return batchErr.Err()
+}
+
+func checkHandler(check func() error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := check(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "OK")
+	})
+}
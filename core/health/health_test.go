@@ -0,0 +1,86 @@
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLive(t *testing.T) {
+	defer RemoveCheck("live")
+
+	assert.Nil(t, CheckLive())
+
+	AddLivenessCheck("live", func() error {
+		return nil
+	})
+	assert.Nil(t, CheckLive())
+
+	AddLivenessCheck("live", func() error {
+		return errors.New("dead")
+	})
+	err := CheckLive()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "live: dead")
+}
+
+func TestCheckReady(t *testing.T) {
+	defer RemoveCheck("db")
+	defer RemoveCheck("proc")
+
+	AddReadinessCheck("db", func() error {
+		return errors.New("connection refused")
+	})
+	err := CheckReady()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "db: connection refused")
+
+	// a dead process is not ready either
+	AddReadinessCheck("db", func() error {
+		return nil
+	})
+	AddLivenessCheck("proc", func() error {
+		return errors.New("deadlocked")
+	})
+	err = CheckReady()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "proc: deadlocked")
+
+	AddLivenessCheck("proc", func() error {
+		return nil
+	})
+	assert.Nil(t, CheckReady())
+}
+
+func TestHandlers(t *testing.T) {
+	defer RemoveCheck("redis")
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	resp := httptest.NewRecorder()
+	ReadyHandler().ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	AddReadinessCheck("redis", func() error {
+		return errors.New("timeout")
+	})
+	resp = httptest.NewRecorder()
+	ReadyHandler().ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Contains(t, resp.Body.String(), "redis: timeout")
+
+	resp = httptest.NewRecorder()
+	LiveHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestRemoveCheck(t *testing.T) {
+	AddReadinessCheck("gone", func() error {
+		return errors.New("boom")
+	})
+	assert.NotNil(t, CheckReady())
+	RemoveCheck("gone")
+	assert.Nil(t, CheckReady())
+}
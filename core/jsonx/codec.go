@@ -0,0 +1,66 @@
+package jsonx
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+var (
+	codec     Codec = stdCodec{}
+	codecLock sync.RWMutex
+)
+
+type (
+	// A Codec is a pluggable JSON implementation, a faster codec
+	// (e.g. sonic, go-json) can be registered with SetCodec.
+	Codec interface {
+		Marshal(v interface{}) ([]byte, error)
+		Unmarshal(data []byte, v interface{}) error
+		NewEncoder(writer io.Writer) Encoder
+		NewDecoder(reader io.Reader) Decoder
+	}
+
+	// An Encoder encodes values into a stream.
+	Encoder interface {
+		Encode(v interface{}) error
+	}
+
+	// A Decoder decodes values from a stream.
+	Decoder interface {
+		Decode(v interface{}) error
+		UseNumber()
+	}
+
+	stdCodec struct{}
+)
+
+// SetCodec replaces the JSON implementation used by this package,
+// typically called once on startup before serving requests.
+func SetCodec(c Codec) {
+	codecLock.Lock()
+	defer codecLock.Unlock()
+	codec = c
+}
+
+func getCodec() Codec {
+	codecLock.RLock()
+	defer codecLock.RUnlock()
+	return codec
+}
+
+func (stdCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (stdCodec) NewEncoder(writer io.Writer) Encoder {
+	return json.NewEncoder(writer)
+}
+
+func (stdCodec) NewDecoder(reader io.Reader) Decoder {
+	return json.NewDecoder(reader)
+}
@@ -0,0 +1,63 @@
+package jsonx
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingCodec struct {
+	stdCodec
+	marshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return c.stdCodec.Marshal(v)
+}
+
+func TestSetCodec(t *testing.T) {
+	counting := new(countingCodec)
+	SetCodec(counting)
+	defer SetCodec(stdCodec{})
+
+	data, err := Marshal(map[string]interface{}{"name": "apple"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name":"apple"}`, string(data))
+	assert.Equal(t, 1, counting.marshals)
+}
+
+func TestMarshalToWriter(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, MarshalToWriter(&buf, map[string]interface{}{"name": "apple"}))
+	assert.Equal(t, `{"name":"apple"}`, strings.TrimSpace(buf.String()))
+}
+
+func TestNewEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf)
+	assert.Nil(t, encoder.Encode(map[string]interface{}{"id": 1}))
+	assert.Nil(t, encoder.Encode(map[string]interface{}{"id": 2}))
+	assert.Equal(t, "{\"id\":1}\n{\"id\":2}", strings.TrimSpace(buf.String()))
+}
+
+func TestNewDecoder(t *testing.T) {
+	decoder := NewDecoder(strings.NewReader("{\"id\":1}\n{\"id\":2}"))
+	var count int
+	for {
+		var v struct {
+			Id int `json:"id"`
+		}
+		if err := decoder.Decode(&v); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+		count++
+		assert.Equal(t, count, v.Id)
+	}
+	assert.Equal(t, 2, count)
+}
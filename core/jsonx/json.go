@@ -2,7 +2,6 @@ package jsonx
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -10,7 +9,7 @@ import (
 
 // Marshal marshals v into json bytes.
 func Marshal(v interface{}) ([]byte, error) {
-	return json.Marshal(v)
+	return getCodec().Marshal(v)
 }
 
 // MarshalToString marshals v into a string.
@@ -23,9 +22,27 @@ func MarshalToString(v interface{}) (string, error) {
 	return string(data), nil
 }
 
+// MarshalToWriter marshals v into writer without buffering the whole payload,
+// preferred over Marshal for large payloads.
+func MarshalToWriter(writer io.Writer, v interface{}) error {
+	return getCodec().NewEncoder(writer).Encode(v)
+}
+
+// NewEncoder returns an Encoder that encodes into writer,
+// it can be used to stream multiple values.
+func NewEncoder(writer io.Writer) Encoder {
+	return getCodec().NewEncoder(writer)
+}
+
+// NewDecoder returns a Decoder that decodes from reader,
+// it can be used to stream multiple values.
+func NewDecoder(reader io.Reader) Decoder {
+	return getCodec().NewDecoder(reader)
+}
+
 // Unmarshal unmarshals data bytes into v.
 func Unmarshal(data []byte, v interface{}) error {
-	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder := getCodec().NewDecoder(bytes.NewReader(data))
 	if err := unmarshalUseNumber(decoder, v); err != nil {
 		return formatError(string(data), err)
 	}
@@ -35,7 +52,7 @@ func Unmarshal(data []byte, v interface{}) error {
 
 // UnmarshalFromString unmarshals v from str.
 func UnmarshalFromString(str string, v interface{}) error {
-	decoder := json.NewDecoder(strings.NewReader(str))
+	decoder := getCodec().NewDecoder(strings.NewReader(str))
 	if err := unmarshalUseNumber(decoder, v); err != nil {
 		return formatError(str, err)
 	}
@@ -47,7 +64,7 @@ func UnmarshalFromString(str string, v interface{}) error {
 func UnmarshalFromReader(reader io.Reader, v interface{}) error {
 	var buf strings.Builder
 	teeReader := io.TeeReader(reader, &buf)
-	decoder := json.NewDecoder(teeReader)
+	decoder := getCodec().NewDecoder(teeReader)
 	if err := unmarshalUseNumber(decoder, v); err != nil {
 		return formatError(buf.String(), err)
 	}
@@ -55,7 +72,7 @@ func UnmarshalFromReader(reader io.Reader, v interface{}) error {
 	return nil
 }
 
-func unmarshalUseNumber(decoder *json.Decoder, v interface{}) error {
+func unmarshalUseNumber(decoder Decoder, v interface{}) error {
 	decoder.UseNumber()
 	return decoder.Decode(v)
 }
@@ -0,0 +1,155 @@
+package limit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/lang"
+)
+
+var (
+	// ErrConcurrencyLimit is returned when the in-flight cap is reached
+	// and no wait queue slot is available.
+	ErrConcurrencyLimit = errors.New("concurrency limit reached")
+	// ErrWaitTimeout is returned when waiting for a permit timed out.
+	ErrWaitTimeout = errors.New("waiting for permit timed out")
+)
+
+type (
+	// ConcurrencyOption defines the method to customize a ConcurrencyLimit.
+	ConcurrencyOption func(cl *ConcurrencyLimit)
+
+	// A ConcurrencyLimit caps in-flight requests per key, with an optional
+	// bounded wait queue requests wait for a permit up to a timeout instead
+	// of failing fast. Useful for downstreams that care about concurrency,
+	// not rate.
+	ConcurrencyLimit struct {
+		limit   int
+		queue   int
+		timeout time.Duration
+		lock    sync.Mutex
+		entries map[string]*concurrencyEntry
+	}
+
+	concurrencyEntry struct {
+		sem     chan lang.PlaceholderType
+		waiting int
+	}
+)
+
+// NewConcurrencyLimit returns a ConcurrencyLimit that allows up to limit
+// in-flight requests per key.
+func NewConcurrencyLimit(limit int, opts ...ConcurrencyOption) *ConcurrencyLimit {
+	cl := &ConcurrencyLimit{
+		limit:   limit,
+		entries: make(map[string]*concurrencyEntry),
+	}
+	for _, opt := range opts {
+		opt(cl)
+	}
+
+	return cl
+}
+
+// WithQueue returns a func to customize a ConcurrencyLimit with a wait queue
+// of up to size requests per key, each waiting at most timeout for a permit.
+func WithQueue(size int, timeout time.Duration) ConcurrencyOption {
+	return func(cl *ConcurrencyLimit) {
+		cl.queue = size
+		cl.timeout = timeout
+	}
+}
+
+// Do runs fn with a permit for key, the permit is returned when fn returns.
+func (cl *ConcurrencyLimit) Do(key string, fn func() error) error {
+	return cl.DoCtx(context.Background(), key, fn)
+}
+
+// DoCtx runs fn with a permit for key, the permit is returned when fn returns.
+func (cl *ConcurrencyLimit) DoCtx(ctx context.Context, key string, fn func() error) error {
+	if err := cl.TakeCtx(ctx, key); err != nil {
+		return err
+	}
+	defer cl.Return(key)
+
+	return fn()
+}
+
+// Take acquires a permit for key, the caller is responsible for calling
+// Return once done.
+func (cl *ConcurrencyLimit) Take(key string) error {
+	return cl.TakeCtx(context.Background(), key)
+}
+
+// TakeCtx acquires a permit for key, the caller is responsible for calling
+// Return once done.
+func (cl *ConcurrencyLimit) TakeCtx(ctx context.Context, key string) error {
+	cl.lock.Lock()
+	entry, ok := cl.entries[key]
+	if !ok {
+		entry = &concurrencyEntry{
+			sem: make(chan lang.PlaceholderType, cl.limit),
+		}
+		cl.entries[key] = entry
+	}
+
+	select {
+	case entry.sem <- lang.Placeholder:
+		cl.lock.Unlock()
+		return nil
+	default:
+	}
+
+	if cl.queue <= 0 || entry.waiting >= cl.queue {
+		cl.lock.Unlock()
+		return ErrConcurrencyLimit
+	}
+
+	entry.waiting++
+	cl.lock.Unlock()
+	defer func() {
+		cl.lock.Lock()
+		entry.waiting--
+		cl.cleanup(key, entry)
+		cl.lock.Unlock()
+	}()
+
+	timer := time.NewTimer(cl.timeout)
+	defer timer.Stop()
+
+	select {
+	case entry.sem <- lang.Placeholder:
+		return nil
+	case <-timer.C:
+		return ErrWaitTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Return gives back a permit for key, extra returns are ignored.
+func (cl *ConcurrencyLimit) Return(key string) {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+
+	entry, ok := cl.entries[key]
+	if !ok {
+		return
+	}
+
+	select {
+	case <-entry.sem:
+	default:
+	}
+
+	cl.cleanup(key, entry)
+}
+
+// cleanup drops the entry of key once it's idle, callers must hold the lock.
+func (cl *ConcurrencyLimit) cleanup(key string, entry *concurrencyEntry) {
+	if len(entry.sem) == 0 && entry.waiting == 0 {
+		delete(cl.entries, key)
+	}
+}
@@ -0,0 +1,100 @@
+package limit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimit_Take(t *testing.T) {
+	cl := NewConcurrencyLimit(2)
+	assert.Nil(t, cl.Take("first"))
+	assert.Nil(t, cl.Take("first"))
+	assert.Equal(t, ErrConcurrencyLimit, cl.Take("first"))
+	// keys have independent permits.
+	assert.Nil(t, cl.Take("second"))
+
+	cl.Return("first")
+	assert.Nil(t, cl.Take("first"))
+
+	cl.Return("first")
+	cl.Return("first")
+	cl.Return("second")
+	// extra returns are ignored.
+	cl.Return("second")
+	assert.Equal(t, 0, len(cl.entries))
+}
+
+func TestConcurrencyLimit_Queue(t *testing.T) {
+	cl := NewConcurrencyLimit(1, WithQueue(1, time.Second))
+	assert.Nil(t, cl.Take("first"))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// waits in the queue until the permit is returned.
+		assert.Nil(t, cl.Take("first"))
+		cl.Return("first")
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	cl.Return("first")
+	wg.Wait()
+	assert.Equal(t, 0, len(cl.entries))
+}
+
+func TestConcurrencyLimit_QueueTimeout(t *testing.T) {
+	cl := NewConcurrencyLimit(1, WithQueue(1, time.Millisecond*50))
+	assert.Nil(t, cl.Take("first"))
+	assert.Equal(t, ErrWaitTimeout, cl.Take("first"))
+	cl.Return("first")
+}
+
+func TestConcurrencyLimit_QueueFull(t *testing.T) {
+	cl := NewConcurrencyLimit(1, WithQueue(1, time.Second))
+	assert.Nil(t, cl.Take("first"))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Nil(t, cl.Take("first"))
+		cl.Return("first")
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	// the single queue slot is occupied.
+	assert.Equal(t, ErrConcurrencyLimit, cl.Take("first"))
+	cl.Return("first")
+	wg.Wait()
+}
+
+func TestConcurrencyLimit_TakeCtx(t *testing.T) {
+	cl := NewConcurrencyLimit(1, WithQueue(1, time.Minute))
+	assert.Nil(t, cl.Take("first"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		cancel()
+	}()
+	assert.Equal(t, context.Canceled, cl.TakeCtx(ctx, "first"))
+	cl.Return("first")
+}
+
+func TestConcurrencyLimit_Do(t *testing.T) {
+	cl := NewConcurrencyLimit(1)
+	var ran bool
+	assert.Nil(t, cl.Do("first", func() error {
+		ran = true
+		assert.Equal(t, ErrConcurrencyLimit, cl.Take("first"))
+		return nil
+	}))
+	assert.True(t, ran)
+	assert.Nil(t, cl.Take("first"))
+	cl.Return("first")
+}
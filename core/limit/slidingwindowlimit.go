@@ -0,0 +1,90 @@
+package limit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/stores/redis"
+)
+
+// two counters, the previous window weighted by the elapsed share of the
+// current one, which smooths out the 2x bursts fixed windows allow at
+// window boundaries.
+const slidingWindowScript = `local quota = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+local previous = tonumber(redis.call("GET", KEYS[2])) or 0
+local current = tonumber(redis.call("GET", KEYS[1])) or 0
+local weighted = math.floor(previous * (window - elapsed) / window) + current
+if weighted >= quota then
+    return 0
+end
+current = redis.call("INCRBY", KEYS[1], 1)
+if current == 1 then
+    redis.call("PEXPIRE", KEYS[1], window * 2)
+end
+if weighted + 1 >= quota then
+    return 2
+else
+    return 1
+end`
+
+// A SlidingWindowLimit is used to limit requests over a sliding window of time,
+// backed by redis so the quota is shared across processes.
+type SlidingWindowLimit struct {
+	window     time.Duration
+	quota      int
+	limitStore *redis.Redis
+	keyPrefix  string
+}
+
+// NewSlidingWindowLimit returns a SlidingWindowLimit with given parameters.
+func NewSlidingWindowLimit(window time.Duration, quota int, limitStore *redis.Redis,
+	keyPrefix string) *SlidingWindowLimit {
+	return &SlidingWindowLimit{
+		window:     window,
+		quota:      quota,
+		limitStore: limitStore,
+		keyPrefix:  keyPrefix,
+	}
+}
+
+// Take requests a permit, it returns the permit state.
+func (h *SlidingWindowLimit) Take(key string) (int, error) {
+	return h.TakeCtx(context.Background(), key)
+}
+
+// TakeCtx requests a permit with context, it returns the permit state.
+func (h *SlidingWindowLimit) TakeCtx(ctx context.Context, key string) (int, error) {
+	now := time.Now().UnixMilli()
+	window := h.window.Milliseconds()
+	index := now / window
+	resp, err := h.limitStore.EvalCtx(ctx, slidingWindowScript, []string{
+		h.keyPrefix + key + ":" + strconv.FormatInt(index, 10),
+		h.keyPrefix + key + ":" + strconv.FormatInt(index-1, 10),
+	}, []string{
+		strconv.Itoa(h.quota),
+		strconv.FormatInt(window, 10),
+		strconv.FormatInt(now%window, 10),
+	})
+	if err != nil {
+		return Unknown, err
+	}
+
+	code, ok := resp.(int64)
+	if !ok {
+		return Unknown, ErrUnknownCode
+	}
+
+	switch code {
+	case internalOverQuota:
+		return OverQuota, nil
+	case internalAllowed:
+		return Allowed, nil
+	case internalHitQuota:
+		return HitQuota, nil
+	default:
+		return Unknown, ErrUnknownCode
+	}
+}
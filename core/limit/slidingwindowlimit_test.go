@@ -0,0 +1,77 @@
+package limit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/qkbyte/go-zero/core/stores/redis"
+	"github.com/qkbyte/go-zero/core/stores/redis/redistest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlidingWindowLimit_Take(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	const (
+		total = 100
+		quota = 5
+	)
+	l := NewSlidingWindowLimit(time.Minute, quota, store, "slidingwindowlimit")
+	var allowed, hitQuota, overQuota int
+	for i := 0; i < total; i++ {
+		val, err := l.Take("first")
+		if err != nil {
+			t.Error(err)
+		}
+		switch val {
+		case Allowed:
+			allowed++
+		case HitQuota:
+			hitQuota++
+		case OverQuota:
+			overQuota++
+		default:
+			t.Error("unknown status")
+		}
+	}
+
+	assert.Equal(t, quota-1, allowed)
+	assert.Equal(t, 1, hitQuota)
+	assert.Equal(t, total-quota, overQuota)
+
+	// an untouched key has its own quota.
+	val, err := l.Take("second")
+	assert.Nil(t, err)
+	assert.Equal(t, Allowed, val)
+}
+
+func TestSlidingWindowLimit_Recover(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	l := NewSlidingWindowLimit(time.Millisecond*100, 1, store, "slidingwindowlimit")
+	val, err := l.Take("first")
+	assert.Nil(t, err)
+	assert.NotEqual(t, OverQuota, val)
+
+	// once the window fully slid past, requests are allowed again.
+	time.Sleep(time.Millisecond * 250)
+	val, err = l.Take("first")
+	assert.Nil(t, err)
+	assert.NotEqual(t, OverQuota, val)
+}
+
+func TestSlidingWindowLimit_RedisUnavailable(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+
+	l := NewSlidingWindowLimit(time.Minute, 5, redis.New(s.Addr()), "slidingwindowlimit")
+	s.Close()
+	val, err := l.Take("first")
+	assert.NotNil(t, err)
+	assert.Equal(t, Unknown, val)
+}
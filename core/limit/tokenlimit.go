@@ -9,6 +9,7 @@ import (
 
 	"github.com/qkbyte/go-zero/core/logx"
 	"github.com/qkbyte/go-zero/core/stores/redis"
+	"github.com/qkbyte/go-zero/core/timex"
 	xrate "golang.org/x/time/rate"
 )
 
@@ -49,33 +50,72 @@ return allowed`
 	pingInterval    = time.Millisecond * 100
 )
 
-// A TokenLimiter controls how frequently events are allowed to happen with in one second.
-type TokenLimiter struct {
-	rate           int
-	burst          int
-	store          *redis.Redis
-	tokenKey       string
-	timestampKey   string
-	rescueLock     sync.Mutex
-	redisAlive     uint32
-	rescueLimiter  *xrate.Limiter
-	monitorStarted bool
-}
+type (
+	// TokenOption defines the method to customize a TokenLimiter.
+	TokenOption func(lim *TokenLimiter)
+
+	// A TokenLimiter controls how frequently events are allowed to happen with in one second.
+	TokenLimiter struct {
+		rate           int
+		burst          int
+		localRate      int
+		localBurst     int
+		warmupPeriod   time.Duration
+		startTime      time.Duration
+		store          *redis.Redis
+		tokenKey       string
+		timestampKey   string
+		rescueLock     sync.Mutex
+		redisAlive     uint32
+		rescueLimiter  *xrate.Limiter
+		monitorStarted bool
+	}
+)
 
 // NewTokenLimiter returns a new TokenLimiter that allows events up to rate and permits
 // bursts of at most burst tokens.
-func NewTokenLimiter(rate, burst int, store *redis.Redis, key string) *TokenLimiter {
+func NewTokenLimiter(rate, burst int, store *redis.Redis, key string,
+	opts ...TokenOption) *TokenLimiter {
 	tokenKey := fmt.Sprintf(tokenFormat, key)
 	timestampKey := fmt.Sprintf(timestampFormat, key)
 
-	return &TokenLimiter{
-		rate:          rate,
-		burst:         burst,
-		store:         store,
-		tokenKey:      tokenKey,
-		timestampKey:  timestampKey,
-		redisAlive:    1,
-		rescueLimiter: xrate.NewLimiter(xrate.Every(time.Second/time.Duration(rate)), burst),
+	lim := &TokenLimiter{
+		rate:         rate,
+		burst:        burst,
+		store:        store,
+		tokenKey:     tokenKey,
+		timestampKey: timestampKey,
+		redisAlive:   1,
+		startTime:    timex.Now(),
+	}
+	for _, opt := range opts {
+		opt(lim)
+	}
+	if lim.localRate <= 0 {
+		lim.localRate = rate
+		lim.localBurst = burst
+	}
+	lim.rescueLimiter = xrate.NewLimiter(
+		xrate.Every(time.Second/time.Duration(lim.localRate)), lim.localBurst)
+
+	return lim
+}
+
+// WithLocalRate returns a func to customize a TokenLimiter with the rate and burst
+// of the in-process limiter used while redis is unavailable. It defaults to the
+// distributed rate, which over-admits when many instances fall back together.
+func WithLocalRate(rate, burst int) TokenOption {
+	return func(lim *TokenLimiter) {
+		lim.localRate = rate
+		lim.localBurst = burst
+	}
+}
+
+// WithWarmup returns a func to customize a TokenLimiter with a warm-up period,
+// the permitted rate ramps up linearly to the configured rate over period.
+func WithWarmup(period time.Duration) TokenOption {
+	return func(lim *TokenLimiter) {
+		lim.warmupPeriod = period
 	}
 }
 
@@ -93,7 +133,7 @@ func (lim *TokenLimiter) AllowN(now time.Time, n int) bool {
 
 func (lim *TokenLimiter) reserveN(now time.Time, n int) bool {
 	if atomic.LoadUint32(&lim.redisAlive) == 0 {
-		return lim.rescueLimiter.AllowN(now, n)
+		return lim.rescueAllowN(now, n)
 	}
 
 	resp, err := lim.store.Eval(
@@ -103,7 +143,7 @@ func (lim *TokenLimiter) reserveN(now time.Time, n int) bool {
 			lim.timestampKey,
 		},
 		[]string{
-			strconv.Itoa(lim.rate),
+			strconv.Itoa(lim.warmedRate(lim.rate)),
 			strconv.Itoa(lim.burst),
 			strconv.FormatInt(now.Unix(), 10),
 			strconv.Itoa(n),
@@ -116,14 +156,14 @@ func (lim *TokenLimiter) reserveN(now time.Time, n int) bool {
 	if err != nil {
 		logx.Errorf("fail to use rate limiter: %s, use in-process limiter for rescue", err)
 		lim.startMonitor()
-		return lim.rescueLimiter.AllowN(now, n)
+		return lim.rescueAllowN(now, n)
 	}
 
 	code, ok := resp.(int64)
 	if !ok {
 		logx.Errorf("fail to eval redis script: %v, use in-process limiter for rescue", resp)
 		lim.startMonitor()
-		return lim.rescueLimiter.AllowN(now, n)
+		return lim.rescueAllowN(now, n)
 	}
 
 	// redis allowed == true
@@ -131,6 +171,34 @@ func (lim *TokenLimiter) reserveN(now time.Time, n int) bool {
 	return code == 1
 }
 
+func (lim *TokenLimiter) rescueAllowN(now time.Time, n int) bool {
+	if lim.warmupPeriod > 0 {
+		lim.rescueLimiter.SetLimit(xrate.Limit(lim.warmedRate(lim.localRate)))
+	}
+
+	return lim.rescueLimiter.AllowN(now, n)
+}
+
+// warmedRate scales rate by the elapsed share of the warm-up period,
+// at least one event per second is always permitted.
+func (lim *TokenLimiter) warmedRate(rate int) int {
+	if lim.warmupPeriod <= 0 {
+		return rate
+	}
+
+	elapsed := timex.Since(lim.startTime)
+	if elapsed >= lim.warmupPeriod {
+		return rate
+	}
+
+	warmed := int(int64(rate) * int64(elapsed) / int64(lim.warmupPeriod))
+	if warmed < 1 {
+		warmed = 1
+	}
+
+	return warmed
+}
+
 func (lim *TokenLimiter) startMonitor() {
 	lim.rescueLock.Lock()
 	defer lim.rescueLock.Unlock()
@@ -86,3 +86,47 @@ func TestTokenLimit_TakeBurst(t *testing.T) {
 
 	assert.True(t, allowed >= burst)
 }
+
+func TestTokenLimit_LocalRate(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+
+	l := NewTokenLimiter(100, 100, redis.New(s.Addr()), "tokenlimit",
+		WithLocalRate(1, 1))
+	s.Close()
+
+	var allowed int
+	for i := 0; i < 10; i++ {
+		if l.Allow() {
+			allowed++
+		}
+	}
+
+	// only the local burst is admitted with redis down.
+	assert.Equal(t, 1, allowed)
+}
+
+func TestTokenLimit_Warmup(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	l := NewTokenLimiter(100, 100, store, "tokenlimit", WithWarmup(time.Hour))
+	// right after startup the warmed rate is at its floor.
+	assert.Equal(t, 1, l.warmedRate(l.rate))
+
+	l.startTime = -time.Hour
+	assert.Equal(t, 100, l.warmedRate(l.rate))
+}
+
+func TestTokenLimit_WarmupHalfway(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	l := NewTokenLimiter(100, 100, store, "tokenlimit", WithWarmup(time.Hour))
+	l.startTime -= time.Hour / 2
+	rate := l.warmedRate(l.rate)
+	assert.True(t, rate >= 49 && rate <= 51, "got rate %d", rate)
+	assert.True(t, l.Allow())
+}
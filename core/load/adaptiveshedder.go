@@ -23,6 +23,25 @@ const (
 	// moving average hyperparameter beta for calculating requests on the fly
 	flyingBeta      = 0.9
 	coolOffDuration = time.Second
+	// default name to label the shedder metrics with.
+	defaultName = "adaptive"
+	// scale factors on the in-flight ceiling per priority,
+	// lower priorities hit the ceiling earlier and are shed first.
+	lowPriorityFactor  = 0.75
+	highPriorityFactor = 1.25
+)
+
+// Priority is the priority class of a request for load shedding,
+// lower priorities are shed first.
+type Priority int
+
+const (
+	// PriorityLow marks requests shed first under load.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityHigh marks requests shed last under load.
+	PriorityHigh
 )
 
 var (
@@ -55,16 +74,26 @@ type (
 		Allow() (Promise, error)
 	}
 
+	// PriorityShedder is a Shedder that sheds by priority class,
+	// lower priorities are shed first.
+	PriorityShedder interface {
+		Shedder
+		// AllowWithPriority returns the Promise if allowed, otherwise ErrServiceOverloaded.
+		AllowWithPriority(priority Priority) (Promise, error)
+	}
+
 	// ShedderOption lets caller customize the Shedder.
 	ShedderOption func(opts *shedderOptions)
 
 	shedderOptions struct {
+		name         string
 		window       time.Duration
 		buckets      int
 		cpuThreshold int64
 	}
 
 	adaptiveShedder struct {
+		name            string
 		cpuThreshold    int64
 		windows         int64
 		flying          int64
@@ -95,6 +124,7 @@ func NewAdaptiveShedder(opts ...ShedderOption) Shedder {
 	}
 
 	options := shedderOptions{
+		name:         defaultName,
 		window:       defaultWindow,
 		buckets:      defaultBuckets,
 		cpuThreshold: defaultCpuThreshold,
@@ -104,6 +134,7 @@ func NewAdaptiveShedder(opts ...ShedderOption) Shedder {
 	}
 	bucketDuration := options.window / time.Duration(options.buckets)
 	return &adaptiveShedder{
+		name:            options.name,
 		cpuThreshold:    options.cpuThreshold,
 		windows:         int64(time.Second / bucketDuration),
 		dropTime:        syncx.NewAtomicDuration(),
@@ -117,7 +148,12 @@ func NewAdaptiveShedder(opts ...ShedderOption) Shedder {
 
 // Allow implements Shedder.Allow.
 func (as *adaptiveShedder) Allow() (Promise, error) {
-	if as.shouldDrop() {
+	return as.AllowWithPriority(PriorityNormal)
+}
+
+// AllowWithPriority implements PriorityShedder.AllowWithPriority.
+func (as *adaptiveShedder) AllowWithPriority(priority Priority) (Promise, error) {
+	if as.shouldDropFor(priority) {
 		as.dropTime.Set(timex.Now())
 		as.droppedRecently.Set(true)
 
@@ -134,6 +170,7 @@ func (as *adaptiveShedder) Allow() (Promise, error) {
 
 func (as *adaptiveShedder) addFlying(delta int64) {
 	flying := atomic.AddInt64(&as.flying, delta)
+	metricInflight.Set(float64(flying), as.name)
 	// update avgFlying when the request is finished.
 	// this strategy makes avgFlying have a little bit lag against flying, and smoother.
 	// when the flying requests increase rapidly, avgFlying increase slower, accept more requests.
@@ -146,14 +183,31 @@ func (as *adaptiveShedder) addFlying(delta int64) {
 	}
 }
 
-func (as *adaptiveShedder) highThru() bool {
+func (as *adaptiveShedder) highThru(priority Priority) bool {
 	as.avgFlyingLock.Lock()
 	avgFlying := as.avgFlying
 	as.avgFlyingLock.Unlock()
-	maxFlight := as.maxFlight()
+	maxFlight := as.maxFlightFor(priority)
 	return int64(avgFlying) > maxFlight && atomic.LoadInt64(&as.flying) > maxFlight
 }
 
+// maxFlightFor scales the in-flight ceiling by priority,
+// lower priorities get a lower ceiling and are shed first.
+func (as *adaptiveShedder) maxFlightFor(priority Priority) int64 {
+	maxFlight := as.maxFlight()
+	switch priority {
+	case PriorityLow:
+		maxFlight = int64(float64(maxFlight) * lowPriorityFactor)
+	case PriorityHigh:
+		maxFlight = int64(float64(maxFlight) * highPriorityFactor)
+	}
+	if maxFlight < 1 {
+		maxFlight = 1
+	}
+
+	return maxFlight
+}
+
 func (as *adaptiveShedder) maxFlight() int64 {
 	// windows = buckets per second
 	// maxQPS = maxPASS * windows
@@ -192,8 +246,12 @@ func (as *adaptiveShedder) minRt() float64 {
 }
 
 func (as *adaptiveShedder) shouldDrop() bool {
+	return as.shouldDropFor(PriorityNormal)
+}
+
+func (as *adaptiveShedder) shouldDropFor(priority Priority) bool {
 	if as.systemOverloaded() || as.stillHot() {
-		if as.highThru() {
+		if as.highThru(priority) {
 			flying := atomic.LoadInt64(&as.flying)
 			as.avgFlyingLock.Lock()
 			avgFlying := as.avgFlying
@@ -203,13 +261,39 @@ func (as *adaptiveShedder) shouldDrop() bool {
 				stat.CpuUsage(), as.maxPass(), as.minRt(), as.stillHot(), flying, avgFlying)
 			logx.Error(msg)
 			stat.Report(msg)
+			as.reportDropProbability(true)
 			return true
 		}
+
+		as.reportDropProbability(false)
+		return false
 	}
 
+	metricDropProb.Set(0, as.name)
 	return false
 }
 
+// reportDropProbability updates the drop probability gauge with how likely
+// the next request is shed, derived from how far the in-flight average is
+// above the computed ceiling.
+func (as *adaptiveShedder) reportDropProbability(dropped bool) {
+	if dropped {
+		metricDropProb.Set(1, as.name)
+		return
+	}
+
+	as.avgFlyingLock.Lock()
+	avgFlying := as.avgFlying
+	as.avgFlyingLock.Unlock()
+	prob := avgFlying / float64(as.maxFlight())
+	if prob > 1 {
+		prob = 1
+	} else if prob < 0 {
+		prob = 0
+	}
+	metricDropProb.Set(prob, as.name)
+}
+
 func (as *adaptiveShedder) stillHot() bool {
 	if !as.droppedRecently.True() {
 		return false
@@ -232,6 +316,13 @@ func (as *adaptiveShedder) systemOverloaded() bool {
 	return systemOverloadChecker(as.cpuThreshold)
 }
 
+// WithName customizes the Shedder with given name to label its metrics with.
+func WithName(name string) ShedderOption {
+	return func(opts *shedderOptions) {
+		opts.name = name
+	}
+}
+
 // WithBuckets customizes the Shedder with given number of buckets.
 func WithBuckets(buckets int) ShedderOption {
 	return func(opts *shedderOptions) {
@@ -238,3 +238,51 @@ func BenchmarkAdaptiveShedder_Allow(b *testing.B) {
 func newRollingWindow() *collection.RollingWindow {
 	return collection.NewRollingWindow(buckets, bucketDuration, collection.IgnoreCurrentBucket())
 }
+
+func TestAdaptiveShedderPriority(t *testing.T) {
+	logx.Disable()
+	shedder := &adaptiveShedder{
+		passCounter:     newRollingWindow(),
+		rtCounter:       newRollingWindow(),
+		windows:         buckets,
+		dropTime:        syncx.NewAtomicDuration(),
+		droppedRecently: syncx.NewAtomicBool(),
+	}
+	systemOverloadChecker = func(int64) bool {
+		return true
+	}
+	defer func() {
+		systemOverloadChecker = func(cpuThreshold int64) bool {
+			return stat.CpuUsage() >= cpuThreshold
+		}
+	}()
+
+	// ceiling is 10, scaled to 7 for low and 12 for high priority.
+	assert.Equal(t, int64(10), shedder.maxFlightFor(PriorityNormal))
+	assert.Equal(t, int64(7), shedder.maxFlightFor(PriorityLow))
+	assert.Equal(t, int64(12), shedder.maxFlightFor(PriorityHigh))
+
+	shedder.avgFlying = 11
+	shedder.flying = 11
+	assert.True(t, shedder.shouldDropFor(PriorityLow))
+	assert.True(t, shedder.shouldDropFor(PriorityNormal))
+	assert.False(t, shedder.shouldDropFor(PriorityHigh))
+
+	shedder.avgFlying = 9
+	shedder.flying = 9
+	assert.True(t, shedder.shouldDropFor(PriorityLow))
+	assert.False(t, shedder.shouldDropFor(PriorityNormal))
+
+	_, err := shedder.AllowWithPriority(PriorityLow)
+	assert.Equal(t, ErrServiceOverloaded, err)
+	p, err := shedder.AllowWithPriority(PriorityHigh)
+	assert.Nil(t, err)
+	p.Pass()
+}
+
+func TestNopShedderPriority(t *testing.T) {
+	shedder := newNopShedder()
+	p, err := shedder.(PriorityShedder).AllowWithPriority(PriorityLow)
+	assert.Nil(t, err)
+	p.Pass()
+}
@@ -0,0 +1,20 @@
+package load
+
+import "github.com/qkbyte/go-zero/core/metric"
+
+var (
+	metricInflight = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "load_shedding",
+		Subsystem: "requests",
+		Name:      "inflight",
+		Help:      "current in-flight requests of the shedder.",
+		Labels:    []string{"name"},
+	})
+	metricDropProb = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "load_shedding",
+		Subsystem: "requests",
+		Name:      "drop_probability",
+		Help:      "estimated probability of the next request being shed.",
+		Labels:    []string{"name"},
+	})
+)
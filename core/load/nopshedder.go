@@ -10,6 +10,10 @@ func (s nopShedder) Allow() (Promise, error) {
 	return nopPromise{}, nil
 }
 
+func (s nopShedder) AllowWithPriority(_ Priority) (Promise, error) {
+	return nopPromise{}, nil
+}
+
 type nopPromise struct{}
 
 func (p nopPromise) Pass() {
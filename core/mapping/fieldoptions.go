@@ -9,6 +9,7 @@ type (
 	// nothing to do with context.Context
 	fieldOptionsWithContext struct {
 		FromString bool
+		Layout     string
 		Optional   bool
 		Options    []string
 		Default    string
@@ -40,6 +41,14 @@ func (o *fieldOptionsWithContext) getDefault() (string, bool) {
 	return o.Default, len(o.Default) > 0
 }
 
+func (o *fieldOptionsWithContext) layout() string {
+	if o == nil {
+		return ""
+	}
+
+	return o.Layout
+}
+
 func (o *fieldOptionsWithContext) optional() bool {
 	return o != nil && o.Optional
 }
@@ -98,6 +107,7 @@ func (o *fieldOptions) toOptionsWithContext(key string, m Valuer, fullName strin
 
 	return &fieldOptionsWithContext{
 		FromString: o.FromString,
+		Layout:     o.Layout,
 		Optional:   optional,
 		Options:    o.Options,
 		Default:    o.Default,
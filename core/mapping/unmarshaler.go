@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/qkbyte/go-zero/core/errorx"
 	"github.com/qkbyte/go-zero/core/jsonx"
 	"github.com/qkbyte/go-zero/core/lang"
 	"github.com/qkbyte/go-zero/core/stringx"
@@ -17,6 +20,8 @@ import (
 const (
 	defaultKeyName = "key"
 	delimiter      = '.'
+	// nowDefaultValue sets time.Time fields to the current time in default tags.
+	nowDefaultValue = "now"
 )
 
 var (
@@ -25,12 +30,16 @@ var (
 	errValueNotStruct   = errors.New("value type is not struct")
 	keyUnmarshaler      = NewUnmarshaler(defaultKeyName)
 	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
 	cacheKeys           map[string][]string
 	cacheKeysLock       sync.Mutex
 	defaultCache        map[string]interface{}
 	defaultCacheLock    sync.Mutex
 	emptyMap            = map[string]interface{}{}
 	emptyValue          = reflect.ValueOf(lang.Placeholder)
+
+	customUnmarshalers     = make(map[reflect.Type]func(value interface{}) (reflect.Value, error))
+	customUnmarshalersLock sync.RWMutex
 )
 
 type (
@@ -67,6 +76,25 @@ func NewUnmarshaler(key string, opts ...UnmarshalOption) *Unmarshaler {
 	return &unmarshaler
 }
 
+// RegisterUnmarshaler registers fn to decode values into T, like decimal or
+// netip.Addr types, and is honored by all unmarshalers, including httpx.Parse
+// and config loading. fn receives the raw value from the source, typically a
+// string or a json.Number. Registrations should be done in init functions,
+// a builtin type handling can be overridden by registering its type.
+func RegisterUnmarshaler[T any](fn func(value interface{}) (T, error)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	customUnmarshalersLock.Lock()
+	defer customUnmarshalersLock.Unlock()
+	customUnmarshalers[t] = func(value interface{}) (reflect.Value, error) {
+		v, err := fn(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(v), nil
+	}
+}
+
 // UnmarshalKey unmarshals m into v with tag key.
 func UnmarshalKey(m map[string]interface{}, v interface{}) error {
 	return keyUnmarshaler.Unmarshal(m, v)
@@ -95,14 +123,17 @@ func (u *Unmarshaler) unmarshalWithFullName(m Valuer, v interface{}, fullName st
 
 	rve := rv.Elem()
 	numFields := rte.NumField()
+	// collect the errors of all fields, so callers get all the missing and
+	// invalid fields reported at once, instead of fixing them one by one.
+	var batchErr errorx.BatchError
 	for i := 0; i < numFields; i++ {
 		field := rte.Field(i)
 		if err := u.processField(field, rve.Field(i), m, fullName); err != nil {
-			return err
+			batchErr.Add(err)
 		}
 	}
 
-	return nil
+	return batchErr.Err()
 }
 
 func (u *Unmarshaler) processAnonymousField(field reflect.StructField, value reflect.Value,
@@ -200,6 +231,10 @@ func (u *Unmarshaler) processFieldNotFromString(field reflect.StructField, value
 	typeKind := derefedFieldType.Kind()
 	valueKind := reflect.TypeOf(mapValue).Kind()
 
+	if fn, ok := getCustomUnmarshaler(derefedFieldType); ok {
+		return fillCustomValue(fieldType.Kind(), value, mapValue, fn)
+	}
+
 	switch {
 	case valueKind == reflect.Map && typeKind == reflect.Struct:
 		return u.processFieldStruct(field, value, mapValue, fullName)
@@ -211,6 +246,8 @@ func (u *Unmarshaler) processFieldNotFromString(field reflect.StructField, value
 		return u.fillSliceFromString(fieldType, value, mapValue)
 	case valueKind == reflect.String && derefedFieldType == durationType:
 		return fillDurationValue(fieldType.Kind(), value, mapValue.(string))
+	case valueKind == reflect.String && derefedFieldType == timeType:
+		return fillTimeValue(fieldType.Kind(), value, mapValue.(string), opts.layout())
 	default:
 		return u.processFieldPrimitive(field, value, mapValue, opts, fullName)
 	}
@@ -341,7 +378,7 @@ func (u *Unmarshaler) processNamedField(field reflect.StructField, value reflect
 		return u.processNamedFieldWithValue(field, value, mapValue, key, opts, fullName)
 	}
 
-	return u.processNamedFieldWithoutValue(field, value, opts, fullName)
+	return u.processNamedFieldWithoutValue(field, value, m, opts, fullName)
 }
 
 func (u *Unmarshaler) processNamedFieldWithValue(field reflect.StructField, value reflect.Value,
@@ -383,10 +420,15 @@ func (u *Unmarshaler) processNamedFieldWithValue(field reflect.StructField, valu
 }
 
 func (u *Unmarshaler) processNamedFieldWithoutValue(field reflect.StructField, value reflect.Value,
-	opts *fieldOptionsWithContext, fullName string) error {
+	m Valuer, opts *fieldOptionsWithContext, fullName string) error {
 	derefedType := Deref(field.Type)
 	fieldKind := derefedType.Kind()
 	if defaultValue, ok := opts.getDefault(); ok {
+		defaultValue, err := expandDefaultValue(defaultValue, m)
+		if err != nil {
+			return err
+		}
+
 		if field.Type.Kind() == reflect.Ptr {
 			maybeNewValue(field, value)
 			value = value.Elem()
@@ -394,6 +436,14 @@ func (u *Unmarshaler) processNamedFieldWithoutValue(field reflect.StructField, v
 		if derefedType == durationType {
 			return fillDurationValue(fieldKind, value, defaultValue)
 		}
+		if derefedType == timeType {
+			if defaultValue == nowDefaultValue {
+				value.Set(reflect.ValueOf(time.Now()))
+				return nil
+			}
+
+			return fillTimeValue(fieldKind, value, defaultValue, opts.layout())
+		}
 
 		switch fieldKind {
 		case reflect.Array, reflect.Slice:
@@ -728,6 +778,100 @@ func fillDurationValue(fieldKind reflect.Kind, value reflect.Value, dur string)
 	return nil
 }
 
+// expandDefaultValue resolves the dynamic forms of default tag values:
+// ${VAR} and ${VAR:fallback} look up environment variables,
+// $key references another key in the source, optionally followed by
+// a +n or -n offset, like default=$port+1000.
+// Any other value is returned as is.
+func expandDefaultValue(def string, m Valuer) (string, error) {
+	switch {
+	case strings.HasPrefix(def, "${") && strings.HasSuffix(def, "}"):
+		name, fallback, hasFallback := strings.Cut(def[2:len(def)-1], ":")
+		val := os.Getenv(name)
+		if len(val) == 0 && hasFallback {
+			return fallback, nil
+		}
+
+		return val, nil
+	case strings.HasPrefix(def, "$"):
+		expr := def[1:]
+		name := expr
+		var op byte
+		var operand string
+		if i := strings.IndexAny(expr, "+-"); i > 0 {
+			name, op, operand = expr[:i], expr[i], expr[i+1:]
+		}
+
+		mapValue, ok := getValue(m, name)
+		if !ok {
+			return "", fmt.Errorf("default value references missing key %q", name)
+		}
+		if op == 0 {
+			return Repr(mapValue), nil
+		}
+
+		base, err := strconv.ParseFloat(Repr(mapValue), 64)
+		if err != nil {
+			return "", err
+		}
+		offset, err := strconv.ParseFloat(operand, 64)
+		if err != nil {
+			return "", err
+		}
+		if op == '-' {
+			offset = -offset
+		}
+
+		return strconv.FormatFloat(base+offset, 'f', -1, 64), nil
+	default:
+		return def, nil
+	}
+}
+
+func fillCustomValue(fieldKind reflect.Kind, value reflect.Value, mapValue interface{},
+	fn func(value interface{}) (reflect.Value, error)) error {
+	v, err := fn(mapValue)
+	if err != nil {
+		return err
+	}
+
+	if fieldKind == reflect.Ptr {
+		value.Elem().Set(v)
+	} else {
+		value.Set(v)
+	}
+
+	return nil
+}
+
+func getCustomUnmarshaler(t reflect.Type) (func(value interface{}) (reflect.Value, error), bool) {
+	customUnmarshalersLock.RLock()
+	defer customUnmarshalersLock.RUnlock()
+	fn, ok := customUnmarshalers[t]
+	return fn, ok
+}
+
+// fillTimeValue parses val into a time.Time with the given layout,
+// RFC3339 is used when no layout tag option is set.
+func fillTimeValue(fieldKind reflect.Kind, value reflect.Value, val, layout string) error {
+	if len(layout) == 0 {
+		layout = time.RFC3339
+	}
+
+	t, err := time.Parse(layout, val)
+	if err != nil {
+		return err
+	}
+
+	if fieldKind == reflect.Ptr {
+		value.Elem().Set(reflect.ValueOf(t))
+	} else {
+		value.Set(reflect.ValueOf(t))
+	}
+
+	return nil
+}
+
 func fillPrimitive(fieldType reflect.Type, value reflect.Value, mapValue interface{},
 	opts *fieldOptionsWithContext, fullName string) error {
 	if !value.CanSet() {
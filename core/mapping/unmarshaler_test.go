@@ -3,6 +3,7 @@ package mapping
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"testing"
@@ -128,6 +129,53 @@ func TestUnmarshalDurationPtrDefault(t *testing.T) {
 	assert.Equal(t, time.Second*5, *in.Duration)
 }
 
+func TestUnmarshalTime(t *testing.T) {
+	type inner struct {
+		Time time.Time `key:"time"`
+	}
+	m := map[string]interface{}{
+		"time": "2022-10-01T08:00:00Z",
+	}
+	var in inner
+	assert.Nil(t, UnmarshalKey(m, &in))
+	assert.Equal(t, time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC), in.Time)
+}
+
+func TestUnmarshalTimeLayout(t *testing.T) {
+	type inner struct {
+		Time time.Time `key:"time,layout=2006-01-02"`
+	}
+	m := map[string]interface{}{
+		"time": "2022-10-01",
+	}
+	var in inner
+	assert.Nil(t, UnmarshalKey(m, &in))
+	assert.Equal(t, time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), in.Time)
+}
+
+func TestUnmarshalTimePtr(t *testing.T) {
+	type inner struct {
+		Time *time.Time `key:"time"`
+	}
+	m := map[string]interface{}{
+		"time": "2022-10-01T08:00:00Z",
+	}
+	var in inner
+	assert.Nil(t, UnmarshalKey(m, &in))
+	assert.Equal(t, time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC), *in.Time)
+}
+
+func TestUnmarshalTimeBadValue(t *testing.T) {
+	type inner struct {
+		Time time.Time `key:"time"`
+	}
+	m := map[string]interface{}{
+		"time": "not a time",
+	}
+	var in inner
+	assert.NotNil(t, UnmarshalKey(m, &in))
+}
+
 func TestUnmarshalInt(t *testing.T) {
 	type inner struct {
 		Int          int   `key:"int"`
@@ -3018,6 +3066,100 @@ func TestUnmarshalJsonReaderArrayString(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestUnmarshalDefaultFieldReference(t *testing.T) {
+	var val struct {
+		Port      int `key:"port"`
+		AdminPort int `key:"adminPort,default=$port+1000"`
+		PrevPort  int `key:"prevPort,default=$port-1"`
+		SamePort  int `key:"samePort,default=$port"`
+	}
+	m := map[string]interface{}{
+		"port": 8080,
+	}
+	assert.Nil(t, UnmarshalKey(m, &val))
+	assert.Equal(t, 8080, val.Port)
+	assert.Equal(t, 9080, val.AdminPort)
+	assert.Equal(t, 8079, val.PrevPort)
+	assert.Equal(t, 8080, val.SamePort)
+}
+
+func TestUnmarshalDefaultFieldReferenceMissing(t *testing.T) {
+	var val struct {
+		AdminPort int `key:"adminPort,default=$port+1000"`
+	}
+	assert.NotNil(t, UnmarshalKey(map[string]interface{}{}, &val))
+}
+
+func TestUnmarshalDefaultEnv(t *testing.T) {
+	os.Setenv("TEST_MAPPING_HOST", "remotehost")
+	defer os.Unsetenv("TEST_MAPPING_HOST")
+
+	var val struct {
+		Host string `key:"host,default=${TEST_MAPPING_HOST}"`
+		Addr string `key:"addr,default=${TEST_MAPPING_ADDR:localhost}"`
+	}
+	assert.Nil(t, UnmarshalKey(map[string]interface{}{}, &val))
+	assert.Equal(t, "remotehost", val.Host)
+	assert.Equal(t, "localhost", val.Addr)
+}
+
+func TestUnmarshalDefaultNow(t *testing.T) {
+	var val struct {
+		CreateAt time.Time `key:"createAt,default=now"`
+	}
+	assert.Nil(t, UnmarshalKey(map[string]interface{}{}, &val))
+	assert.WithinDuration(t, time.Now(), val.CreateAt, time.Minute)
+}
+
+type testAddr struct {
+	host string
+	port string
+}
+
+func TestRegisterUnmarshaler(t *testing.T) {
+	RegisterUnmarshaler(func(value interface{}) (testAddr, error) {
+		s, ok := value.(string)
+		if !ok {
+			return testAddr{}, errTypeMismatch
+		}
+
+		host, port, found := strings.Cut(s, ":")
+		if !found {
+			return testAddr{}, fmt.Errorf("bad addr: %s", s)
+		}
+
+		return testAddr{host: host, port: port}, nil
+	})
+
+	var val struct {
+		Addr    testAddr  `key:"addr"`
+		AddrPtr *testAddr `key:"addrPtr"`
+	}
+	m := map[string]interface{}{
+		"addr":    "localhost:8080",
+		"addrPtr": "localhost:8081",
+	}
+	assert.Nil(t, UnmarshalKey(m, &val))
+	assert.Equal(t, testAddr{host: "localhost", port: "8080"}, val.Addr)
+	assert.Equal(t, testAddr{host: "localhost", port: "8081"}, *val.AddrPtr)
+
+	var bad struct {
+		Addr testAddr `key:"addr"`
+	}
+	assert.NotNil(t, UnmarshalKey(map[string]interface{}{"addr": "garbage"}, &bad))
+}
+
+func TestUnmarshalAggregatedErrors(t *testing.T) {
+	var val struct {
+		Name string `key:"name"`
+		Age  int    `key:"age"`
+	}
+	err := UnmarshalKey(map[string]interface{}{}, &val)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "name")
+	assert.Contains(t, err.Error(), "age")
+}
+
 func BenchmarkDefaultValue(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		var a struct {
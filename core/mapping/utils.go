@@ -15,6 +15,7 @@ import (
 
 const (
 	defaultOption      = "default"
+	layoutOption       = "layout"
 	stringOption       = "string"
 	optionalOption     = "optional"
 	optionsOption      = "options"
@@ -364,6 +365,13 @@ func parseOption(fieldOpts *fieldOptions, fieldName, option string) error {
 		}
 
 		fieldOpts.Default = strings.TrimSpace(segs[1])
+	case strings.HasPrefix(option, layoutOption):
+		segs := strings.Split(option, equalToken)
+		if len(segs) != 2 {
+			return fmt.Errorf("field %s has wrong layout", fieldName)
+		}
+
+		fieldOpts.Layout = strings.TrimSpace(segs[1])
 	case strings.HasPrefix(option, rangeOption):
 		segs := strings.Split(option, equalToken)
 		if len(segs) != 2 {
@@ -1,9 +1,12 @@
 package metric
 
 import (
+	"context"
+
 	prom "github.com/prometheus/client_golang/prometheus"
 	"github.com/qkbyte/go-zero/core/proc"
 	"github.com/qkbyte/go-zero/core/prometheus"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
 )
 
 type (
@@ -20,7 +23,9 @@ type (
 	}
 
 	promCounterVec struct {
-		counter *prom.CounterVec
+		counter     *prom.CounterVec
+		otelCounter syncfloat64.Counter
+		labels      []string
 	}
 )
 
@@ -36,9 +41,11 @@ func NewCounterVec(cfg *CounterVecOpts) CounterVec {
 		Name:      cfg.Name,
 		Help:      cfg.Help,
 	}, cfg.Labels)
-	prom.MustRegister(vec)
+	prometheus.MustRegister(vec)
 	cv := &promCounterVec{
-		counter: vec,
+		counter:     vec,
+		otelCounter: newOtelCounter(prom.BuildFQName(cfg.Namespace, cfg.Subsystem, cfg.Name), cfg.Help),
+		labels:      cfg.Labels,
 	}
 	proc.AddShutdownListener(func() {
 		cv.close()
@@ -48,21 +55,18 @@ func NewCounterVec(cfg *CounterVecOpts) CounterVec {
 }
 
 func (cv *promCounterVec) Inc(labels ...string) {
-	if !prometheus.Enabled() {
-		return
-	}
-
-	cv.counter.WithLabelValues(labels...).Inc()
+	cv.Add(1, labels...)
 }
 
 func (cv *promCounterVec) Add(v float64, labels ...string) {
-	if !prometheus.Enabled() {
-		return
+	if prometheus.Enabled() {
+		cv.counter.WithLabelValues(labels...).Add(v)
+	}
+	if OtelEnabled() && cv.otelCounter != nil {
+		cv.otelCounter.Add(context.Background(), v, otelAttributes(cv.labels, labels)...)
 	}
-
-	cv.counter.WithLabelValues(labels...).Add(v)
 }
 
 func (cv *promCounterVec) close() bool {
-	return prom.Unregister(cv.counter)
+	return prometheus.Unregister(cv.counter)
 }
@@ -1,9 +1,15 @@
 package metric
 
 import (
+	"context"
+	"strings"
+	"sync"
+
 	prom "github.com/prometheus/client_golang/prometheus"
 	"github.com/qkbyte/go-zero/core/proc"
 	"github.com/qkbyte/go-zero/core/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument/asyncfloat64"
 )
 
 type (
@@ -21,8 +27,17 @@ type (
 		close() bool
 	}
 
+	gaugeValue struct {
+		attrs []attribute.KeyValue
+		value float64
+	}
+
 	promGaugeVec struct {
-		gauge *prom.GaugeVec
+		gauge     *prom.GaugeVec
+		otelGauge asyncfloat64.Gauge
+		labels    []string
+		lock      sync.Mutex
+		values    map[string]*gaugeValue
 	}
 )
 
@@ -39,10 +54,14 @@ func NewGaugeVec(cfg *GaugeVecOpts) GaugeVec {
 			Name:      cfg.Name,
 			Help:      cfg.Help,
 		}, cfg.Labels)
-	prom.MustRegister(vec)
+	prometheus.MustRegister(vec)
 	gv := &promGaugeVec{
-		gauge: vec,
+		gauge:  vec,
+		labels: cfg.Labels,
+		values: make(map[string]*gaugeValue),
 	}
+	gv.otelGauge = newOtelGauge(prom.BuildFQName(cfg.Namespace, cfg.Subsystem, cfg.Name),
+		cfg.Help, gv.observeValues)
 	proc.AddShutdownListener(func() {
 		gv.close()
 	})
@@ -51,29 +70,59 @@ func NewGaugeVec(cfg *GaugeVecOpts) GaugeVec {
 }
 
 func (gv *promGaugeVec) Inc(labels ...string) {
-	if !prometheus.Enabled() {
-		return
-	}
-
-	gv.gauge.WithLabelValues(labels...).Inc()
+	gv.Add(1, labels...)
 }
 
 func (gv *promGaugeVec) Add(v float64, labels ...string) {
-	if !prometheus.Enabled() {
-		return
+	if prometheus.Enabled() {
+		gv.gauge.WithLabelValues(labels...).Add(v)
+	}
+	if OtelEnabled() {
+		gv.updateValue(labels, func(val *gaugeValue) {
+			val.value += v
+		})
 	}
-
-	gv.gauge.WithLabelValues(labels...).Add(v)
 }
 
 func (gv *promGaugeVec) Set(v float64, labels ...string) {
-	if !prometheus.Enabled() {
-		return
+	if prometheus.Enabled() {
+		gv.gauge.WithLabelValues(labels...).Set(v)
+	}
+	if OtelEnabled() {
+		gv.updateValue(labels, func(val *gaugeValue) {
+			val.value = v
+		})
 	}
-
-	gv.gauge.WithLabelValues(labels...).Set(v)
 }
 
 func (gv *promGaugeVec) close() bool {
-	return prom.Unregister(gv.gauge)
+	return prometheus.Unregister(gv.gauge)
+}
+
+// observeValues reports the last values to the opentelemetry gauge,
+// called on every collection of the periodic reader.
+func (gv *promGaugeVec) observeValues(ctx context.Context, gauge asyncfloat64.Gauge) {
+	gv.lock.Lock()
+	defer gv.lock.Unlock()
+
+	for _, val := range gv.values {
+		gauge.Observe(ctx, val.value, val.attrs...)
+	}
+}
+
+func (gv *promGaugeVec) updateValue(labels []string, update func(val *gaugeValue)) {
+	key := strings.Join(labels, "|")
+
+	gv.lock.Lock()
+	defer gv.lock.Unlock()
+
+	val, ok := gv.values[key]
+	if !ok {
+		val = &gaugeValue{
+			attrs: otelAttributes(gv.labels, labels),
+		}
+		gv.values[key] = val
+	}
+
+	update(val)
 }
@@ -1,9 +1,13 @@
 package metric
 
 import (
+	"context"
+
 	prom "github.com/prometheus/client_golang/prometheus"
 	"github.com/qkbyte/go-zero/core/proc"
 	"github.com/qkbyte/go-zero/core/prometheus"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type (
@@ -15,17 +19,31 @@ type (
 		Help      string
 		Labels    []string
 		Buckets   []float64
+		// NativeHistogramBucketFactor enables prometheus native histograms
+		// when greater than 1, Buckets are still exported alongside.
+		NativeHistogramBucketFactor float64
+		// NativeHistogramMaxBuckets bounds the number of native buckets,
+		// only used when native histograms are enabled.
+		NativeHistogramMaxBuckets uint32
+		// NativeHistogramZeroThreshold is the width of the zero bucket,
+		// only used when native histograms are enabled.
+		NativeHistogramZeroThreshold float64
 	}
 
 	// A HistogramVec interface represents a histogram vector.
 	HistogramVec interface {
 		// Observe adds observation v to labels.
 		Observe(v int64, labels ...string)
+		// ObserveCtx adds observation v to labels, and attaches an exemplar
+		// with the trace id in ctx when the span is sampled.
+		ObserveCtx(ctx context.Context, v int64, labels ...string)
 		close() bool
 	}
 
 	promHistogramVec struct {
-		histogram *prom.HistogramVec
+		histogram     *prom.HistogramVec
+		otelHistogram syncfloat64.Histogram
+		labels        []string
 	}
 )
 
@@ -36,15 +54,21 @@ func NewHistogramVec(cfg *HistogramVecOpts) HistogramVec {
 	}
 
 	vec := prom.NewHistogramVec(prom.HistogramOpts{
-		Namespace: cfg.Namespace,
-		Subsystem: cfg.Subsystem,
-		Name:      cfg.Name,
-		Help:      cfg.Help,
-		Buckets:   cfg.Buckets,
+		Namespace:                      cfg.Namespace,
+		Subsystem:                      cfg.Subsystem,
+		Name:                           cfg.Name,
+		Help:                           cfg.Help,
+		Buckets:                        cfg.Buckets,
+		NativeHistogramBucketFactor:    cfg.NativeHistogramBucketFactor,
+		NativeHistogramMaxBucketNumber: cfg.NativeHistogramMaxBuckets,
+		NativeHistogramZeroThreshold:   cfg.NativeHistogramZeroThreshold,
 	}, cfg.Labels)
-	prom.MustRegister(vec)
+	prometheus.MustRegister(vec)
 	hv := &promHistogramVec{
 		histogram: vec,
+		otelHistogram: newOtelHistogram(prom.BuildFQName(cfg.Namespace, cfg.Subsystem, cfg.Name),
+			cfg.Help, cfg.Buckets),
+		labels: cfg.Labels,
 	}
 	proc.AddShutdownListener(func() {
 		hv.close()
@@ -54,13 +78,42 @@ func NewHistogramVec(cfg *HistogramVecOpts) HistogramVec {
 }
 
 func (hv *promHistogramVec) Observe(v int64, labels ...string) {
-	if !prometheus.Enabled() {
-		return
+	hv.ObserveCtx(context.Background(), v, labels...)
+}
+
+func (hv *promHistogramVec) ObserveCtx(ctx context.Context, v int64, labels ...string) {
+	if prometheus.Enabled() {
+		hv.promObserve(ctx, v, labels)
+	}
+	if OtelEnabled() && hv.otelHistogram != nil {
+		hv.otelHistogram.Record(ctx, float64(v), otelAttributes(hv.labels, labels)...)
+	}
+}
+
+func (hv *promHistogramVec) promObserve(ctx context.Context, v int64, labels []string) {
+	observer := hv.histogram.WithLabelValues(labels...)
+	if exemplar := exemplarFromContext(ctx); exemplar != nil {
+		if eo, ok := observer.(prom.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(float64(v), exemplar)
+			return
+		}
 	}
 
-	hv.histogram.WithLabelValues(labels...).Observe(float64(v))
+	observer.Observe(float64(v))
+}
+
+func exemplarFromContext(ctx context.Context) prom.Labels {
+	spanCtx := oteltrace.SpanContextFromContext(ctx)
+	if !spanCtx.IsSampled() {
+		return nil
+	}
+
+	return prom.Labels{
+		"trace_id": spanCtx.TraceID().String(),
+		"span_id":  spanCtx.SpanID().String(),
+	}
 }
 
 func (hv *promHistogramVec) close() bool {
-	return prom.Unregister(hv.histogram)
+	return prometheus.Unregister(hv.histogram)
 }
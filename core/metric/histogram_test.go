@@ -1,11 +1,13 @@
 package metric
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 func TestNewHistogramVec(t *testing.T) {
@@ -48,3 +50,64 @@ func TestHistogramObserve(t *testing.T) {
 	err := testutil.CollectAndCompare(hv.histogram, strings.NewReader(metadata+val))
 	assert.Nil(t, err)
 }
+
+func TestHistogramObserveCtx(t *testing.T) {
+	startAgent()
+	histogramVec := NewHistogramVec(&HistogramVecOpts{
+		Name:    "counts_ctx",
+		Help:    "rpc server requests duration(ms).",
+		Buckets: []float64{1, 2, 3},
+		Labels:  []string{"method"},
+	})
+	defer histogramVec.close()
+	hv, _ := histogramVec.(*promHistogramVec)
+	// no sampled span in ctx, observed without exemplar.
+	hv.ObserveCtx(context.Background(), 2, "/Users")
+
+	metadata := `
+		# HELP counts_ctx rpc server requests duration(ms).
+        # TYPE counts_ctx histogram
+`
+	val := `
+		counts_ctx_bucket{method="/Users",le="1"} 0
+		counts_ctx_bucket{method="/Users",le="2"} 1
+		counts_ctx_bucket{method="/Users",le="3"} 1
+		counts_ctx_bucket{method="/Users",le="+Inf"} 1
+		counts_ctx_sum{method="/Users"} 2
+        counts_ctx_count{method="/Users"} 1
+`
+
+	err := testutil.CollectAndCompare(hv.histogram, strings.NewReader(metadata+val))
+	assert.Nil(t, err)
+}
+
+func TestExemplarFromContext(t *testing.T) {
+	assert.Nil(t, exemplarFromContext(context.Background()))
+
+	spanCtx := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{2},
+		TraceFlags: oteltrace.FlagsSampled,
+	})
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), spanCtx)
+	exemplar := exemplarFromContext(ctx)
+	assert.Equal(t, spanCtx.TraceID().String(), exemplar["trace_id"])
+	assert.Equal(t, spanCtx.SpanID().String(), exemplar["span_id"])
+}
+
+func TestHistogramWithNativeHistogram(t *testing.T) {
+	startAgent()
+	histogramVec := NewHistogramVec(&HistogramVecOpts{
+		Name:                        "native_counts",
+		Help:                        "rpc server requests duration(ms).",
+		Buckets:                     []float64{1, 2, 3},
+		Labels:                      []string{"method"},
+		NativeHistogramBucketFactor: 1.1,
+		NativeHistogramMaxBuckets:   100,
+	})
+	defer histogramVec.close()
+	hv, _ := histogramVec.(*promHistogramVec)
+	hv.Observe(2, "/Users")
+
+	assert.Equal(t, 1, testutil.CollectAndCount(hv.histogram))
+}
@@ -0,0 +1,167 @@
+package metric
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/proc"
+	"github.com/qkbyte/go-zero/core/syncx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// instrumentationName is the instrumentation name of the builtin metrics.
+const instrumentationName = "go-zero"
+
+// A Config is an opentelemetry metrics config, the metrics are pushed
+// to the given otlp grpc endpoint periodically.
+type Config struct {
+	Name     string            `json:",optional"`
+	Endpoint string            `json:",optional"`
+	Secure   bool              `json:",optional"`
+	Headers  map[string]string `json:",optional"`
+	// Interval is the push interval in seconds.
+	Interval int `json:",default=15"`
+}
+
+var (
+	otelOnce    sync.Once
+	otelEnabled syncx.AtomicBool
+	bucketLock  sync.Mutex
+	// buckets of the histograms created before StartAgent,
+	// turned into explicit bucket views when the provider is built.
+	buckets = make(map[string][]float64)
+)
+
+// OtelEnabled returns if opentelemetry metrics is enabled.
+func OtelEnabled() bool {
+	return otelEnabled.True()
+}
+
+// StartAgent starts an opentelemetry metrics agent, the builtin metrics
+// are pushed to c.Endpoint besides, or instead of, the prometheus handler.
+func StartAgent(c Config) {
+	if len(c.Endpoint) == 0 {
+		return
+	}
+
+	otelOnce.Do(func() {
+		if err := startOtelAgent(c); err != nil {
+			logx.Error(err)
+			return
+		}
+
+		otelEnabled.Set(true)
+	})
+}
+
+func startOtelAgent(c Config) error {
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(c.Endpoint),
+	}
+	if !c.Secure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	if len(c.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(c.Headers))
+	}
+
+	exporter, err := otlpmetricgrpc.New(context.Background(), opts...)
+	if err != nil {
+		return err
+	}
+
+	var views []view.View
+	bucketLock.Lock()
+	for name, boundaries := range buckets {
+		v, err := view.New(view.MatchInstrumentName(name),
+			view.WithSetAggregation(aggregation.ExplicitBucketHistogram{
+				Boundaries: boundaries,
+			}))
+		if err != nil {
+			bucketLock.Unlock()
+			return err
+		}
+		views = append(views, v)
+	}
+	bucketLock.Unlock()
+
+	interval := time.Duration(c.Interval) * time.Second
+	reader := metric.NewPeriodicReader(exporter, metric.WithInterval(interval))
+	provider := metric.NewMeterProvider(
+		metric.WithReader(reader, views...),
+		metric.WithResource(resource.NewSchemaless(semconv.ServiceNameKey.String(c.Name))),
+	)
+	global.SetMeterProvider(provider)
+	proc.AddShutdownListener(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return nil
+}
+
+func newOtelCounter(name, help string) syncfloat64.Counter {
+	counter, err := global.MeterProvider().Meter(instrumentationName).SyncFloat64().
+		Counter(name, instrument.WithDescription(help))
+	if err != nil {
+		logx.Error(err)
+		return nil
+	}
+
+	return counter
+}
+
+func newOtelGauge(name, help string,
+	callback func(ctx context.Context, gauge asyncfloat64.Gauge)) asyncfloat64.Gauge {
+	meter := global.MeterProvider().Meter(instrumentationName)
+	gauge, err := meter.AsyncFloat64().Gauge(name, instrument.WithDescription(help))
+	if err != nil {
+		logx.Error(err)
+		return nil
+	}
+
+	if err := meter.RegisterCallback([]instrument.Asynchronous{gauge},
+		func(ctx context.Context) {
+			callback(ctx, gauge)
+		}); err != nil {
+		logx.Error(err)
+		return nil
+	}
+
+	return gauge
+}
+
+func newOtelHistogram(name, help string, boundaries []float64) syncfloat64.Histogram {
+	bucketLock.Lock()
+	buckets[name] = boundaries
+	bucketLock.Unlock()
+
+	histogram, err := global.MeterProvider().Meter(instrumentationName).SyncFloat64().
+		Histogram(name, instrument.WithDescription(help))
+	if err != nil {
+		logx.Error(err)
+		return nil
+	}
+
+	return histogram
+}
+
+func otelAttributes(names, values []string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(names))
+	for i := 0; i < len(names) && i < len(values); i++ {
+		attrs = append(attrs, attribute.String(names[i], values[i]))
+	}
+
+	return attrs
+}
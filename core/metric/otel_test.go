@@ -0,0 +1,28 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestStartAgentNoEndpoint(t *testing.T) {
+	StartAgent(Config{})
+	assert.False(t, OtelEnabled())
+}
+
+func TestOtelAttributes(t *testing.T) {
+	attrs := otelAttributes([]string{"method", "code"}, []string{"/users", "200"})
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("method", "/users"),
+		attribute.String("code", "200"),
+	}, attrs)
+	assert.Empty(t, otelAttributes(nil, []string{"/users"}))
+}
+
+func TestNewOtelInstruments(t *testing.T) {
+	assert.NotNil(t, newOtelCounter("test_counter_total", "test counter."))
+	assert.NotNil(t, newOtelHistogram("test_duration_ms", "test histogram.", []float64{1, 2}))
+	assert.NotNil(t, newOtelGauge("test_gauge", "test gauge.", nil))
+}
@@ -0,0 +1,80 @@
+package metric
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/qkbyte/go-zero/core/proc"
+	"github.com/qkbyte/go-zero/core/prometheus"
+)
+
+// defaultObjectives is the default quantile objectives of the summaries.
+var defaultObjectives = map[float64]float64{
+	0.5:  0.05,
+	0.9:  0.01,
+	0.99: 0.001,
+}
+
+type (
+	// A SummaryVecOpts is a summary vector options.
+	SummaryVecOpts struct {
+		Namespace string
+		Subsystem string
+		Name      string
+		Help      string
+		Labels    []string
+		// Objectives maps quantiles to their allowed absolute errors,
+		// defaultObjectives is used when empty.
+		Objectives map[float64]float64
+	}
+
+	// A SummaryVec interface represents a summary vector.
+	SummaryVec interface {
+		// Observe adds observation v to labels.
+		Observe(v float64, labels ...string)
+		close() bool
+	}
+
+	promSummaryVec struct {
+		summary *prom.SummaryVec
+	}
+)
+
+// NewSummaryVec returns a SummaryVec.
+func NewSummaryVec(cfg *SummaryVecOpts) SummaryVec {
+	if cfg == nil {
+		return nil
+	}
+
+	objectives := cfg.Objectives
+	if len(objectives) == 0 {
+		objectives = defaultObjectives
+	}
+
+	vec := prom.NewSummaryVec(prom.SummaryOpts{
+		Namespace:  cfg.Namespace,
+		Subsystem:  cfg.Subsystem,
+		Name:       cfg.Name,
+		Help:       cfg.Help,
+		Objectives: objectives,
+	}, cfg.Labels)
+	prometheus.MustRegister(vec)
+	sv := &promSummaryVec{
+		summary: vec,
+	}
+	proc.AddShutdownListener(func() {
+		sv.close()
+	})
+
+	return sv
+}
+
+func (sv *promSummaryVec) Observe(v float64, labels ...string) {
+	if !prometheus.Enabled() {
+		return
+	}
+
+	sv.summary.WithLabelValues(labels...).Observe(v)
+}
+
+func (sv *promSummaryVec) close() bool {
+	return prometheus.Unregister(sv.summary)
+}
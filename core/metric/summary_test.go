@@ -0,0 +1,40 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSummaryVec(t *testing.T) {
+	summaryVec := NewSummaryVec(&SummaryVecOpts{
+		Name: "duration_seconds",
+		Help: "rpc server requests duration(seconds).",
+		Objectives: map[float64]float64{
+			0.9: 0.01,
+		},
+	})
+	defer summaryVec.close()
+	summaryVecNil := NewSummaryVec(nil)
+	assert.NotNil(t, summaryVec)
+	assert.Nil(t, summaryVecNil)
+}
+
+func TestSummaryObserve(t *testing.T) {
+	startAgent()
+	summaryVec := NewSummaryVec(&SummaryVecOpts{
+		Name:   "counts",
+		Help:   "rpc server requests duration(ms).",
+		Labels: []string{"method"},
+	})
+	defer summaryVec.close()
+	sv, _ := summaryVec.(*promSummaryVec)
+	sv.Observe(2, "/Users")
+	sv.Observe(4, "/Users")
+
+	assert.Equal(t, 1, testutil.CollectAndCount(sv.summary))
+	problems, err := testutil.CollectAndLint(sv.summary)
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+}
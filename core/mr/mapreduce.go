@@ -5,6 +5,7 @@ import (
 	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/qkbyte/go-zero/core/errorx"
 	"github.com/qkbyte/go-zero/core/lang"
@@ -13,11 +14,15 @@ import (
 const (
 	defaultWorkers = 16
 	minWorkers     = 1
+	// useWorkersBuffer sizes the collector buffer by the number of workers.
+	useWorkersBuffer = -1
 )
 
 var (
 	// ErrCancelWithNil is an error that mapreduce was cancelled with nil.
 	ErrCancelWithNil = errors.New("mapreduce cancelled with nil")
+	// ErrMapperTimeout is an error that a mapper did not finish within the timeout.
+	ErrMapperTimeout = errors.New("mapper execution timeout")
 	// ErrReduceNoOutput is an error that reduce did not output a value.
 	ErrReduceNoOutput = errors.New("reduce not writing value")
 )
@@ -38,6 +43,9 @@ type (
 	// VoidReducerFunc is used to reduce all the mapping output, but no output.
 	// Use cancel func to cancel the processing.
 	VoidReducerFunc func(pipe <-chan interface{}, cancel func(error))
+	// StreamReducerFunc is used to reduce the mapping output one element at a time,
+	// use cancel func to cancel the processing.
+	StreamReducerFunc func(item interface{}, cancel func(error))
 	// Option defines the method to customize the mapreduce.
 	Option func(opts *mapReduceOptions)
 
@@ -52,8 +60,11 @@ type (
 	}
 
 	mapReduceOptions struct {
-		ctx     context.Context
-		workers int
+		ctx           context.Context
+		workers       int
+		buffer        int
+		mapperTimeout time.Duration
+		bestEffort    bool
 	}
 
 	// Writer interface wraps Write method.
@@ -105,11 +116,17 @@ func ForEach(generate GenerateFunc, mapper ForEachFunc, opts ...Option) {
 	collector := make(chan interface{})
 	done := make(chan lang.PlaceholderType)
 
+	mpr := MapFunc(func(item interface{}, _ Writer) {
+		mapper(item)
+	})
+	if options.mapperTimeout > 0 {
+		// no error reporting in ForEach, timed out elements are just skipped.
+		mpr = wrapMapperTimeout(mpr, options.mapperTimeout, func() {})
+	}
+
 	go executeMappers(mapperContext{
-		ctx: options.ctx,
-		mapper: func(item interface{}, _ Writer) {
-			mapper(item)
-		},
+		ctx:       options.ctx,
+		mapper:    mpr,
 		source:    source,
 		panicChan: panicChan,
 		collector: collector,
@@ -158,8 +175,10 @@ func mapReduceWithPanicChan(source <-chan interface{}, panicChan *onceChan, mapp
 		}
 	}()
 
-	// collector is used to collect data from mapper, and consume in reducer
-	collector := make(chan interface{}, options.workers)
+	// collector is used to collect data from mapper, and consume in reducer.
+	// the bounded buffer makes mappers block once the reducer falls behind,
+	// so large fan-ins don't pile up in memory before reduce.
+	collector := make(chan interface{}, options.collectorBuffer())
 	// if done is closed, all mappers and reducer should stop processing
 	done := make(chan lang.PlaceholderType)
 	writer := newGuardedWriter(options.ctx, output, done)
@@ -182,6 +201,26 @@ func mapReduceWithPanicChan(source <-chan interface{}, panicChan *onceChan, mapp
 		drain(source)
 		finish()
 	})
+	// in best effort mode, cancel only records the error and keeps processing,
+	// the recorded errors are aggregated and returned along with the reduced result.
+	var mapperErrsLock sync.Mutex
+	var mapperErrs errorx.BatchError
+	if options.bestEffort {
+		cancel = func(err error) {
+			if err == nil {
+				err = ErrCancelWithNil
+			}
+
+			mapperErrsLock.Lock()
+			mapperErrs.Add(err)
+			mapperErrsLock.Unlock()
+		}
+	}
+	partialErr := func() error {
+		mapperErrsLock.Lock()
+		defer mapperErrsLock.Unlock()
+		return mapperErrs.Err()
+	}
 
 	go func() {
 		defer func() {
@@ -195,11 +234,18 @@ func mapReduceWithPanicChan(source <-chan interface{}, panicChan *onceChan, mapp
 		reducer(collector, writer, cancel)
 	}()
 
+	mpr := MapFunc(func(item interface{}, w Writer) {
+		mapper(item, w, cancel)
+	})
+	if options.mapperTimeout > 0 {
+		mpr = wrapMapperTimeout(mpr, options.mapperTimeout, func() {
+			cancel(ErrMapperTimeout)
+		})
+	}
+
 	go executeMappers(mapperContext{
-		ctx: options.ctx,
-		mapper: func(item interface{}, w Writer) {
-			mapper(item, w, cancel)
-		},
+		ctx:       options.ctx,
+		mapper:    mpr,
 		source:    source,
 		panicChan: panicChan,
 		collector: collector,
@@ -219,7 +265,9 @@ func mapReduceWithPanicChan(source <-chan interface{}, panicChan *onceChan, mapp
 		if err := retErr.Load(); err != nil {
 			return nil, err
 		} else if ok {
-			return v, nil
+			return v, partialErr()
+		} else if err := partialErr(); err != nil {
+			return nil, err
 		} else {
 			return nil, ErrReduceNoOutput
 		}
@@ -239,6 +287,49 @@ func MapReduceVoid(generate GenerateFunc, mapper MapperFunc, reducer VoidReducer
 	return err
 }
 
+// MapReduceStream maps all elements generated from given generate,
+// and reduces the output elements one at a time with given reducer.
+// The reducer consumes the mapping output as a stream,
+// backpressured by the collector buffer, see WithBuffer.
+func MapReduceStream(generate GenerateFunc, mapper MapperFunc, reducer StreamReducerFunc,
+	opts ...Option) error {
+	return MapReduceVoid(generate, mapper, func(pipe <-chan interface{}, cancel func(error)) {
+		for item := range pipe {
+			reducer(item, cancel)
+		}
+	}, opts...)
+}
+
+// WithBestEffort customizes a mapreduce processing to keep going on mapper errors.
+// The errors passed to cancel don't stop the processing,
+// they are aggregated and returned along with the reduced result.
+func WithBestEffort() Option {
+	return func(opts *mapReduceOptions) {
+		opts.bestEffort = true
+	}
+}
+
+// WithBuffer customizes a mapreduce processing with given buffer size
+// of the channel between mappers and reducer, defaults to the number of workers.
+func WithBuffer(size int) Option {
+	return func(opts *mapReduceOptions) {
+		if size < 0 {
+			opts.buffer = 0
+		} else {
+			opts.buffer = size
+		}
+	}
+}
+
+// WithMapperTimeout customizes a mapreduce processing with given timeout per mapper call.
+// When a mapper times out, its writes are discarded and the processing is cancelled
+// with ErrMapperTimeout, or the timeout is recorded in best effort mode.
+func WithMapperTimeout(timeout time.Duration) Option {
+	return func(opts *mapReduceOptions) {
+		opts.mapperTimeout = timeout
+	}
+}
+
 // WithContext customizes a mapreduce processing accepts a given ctx.
 func WithContext(ctx context.Context) Option {
 	return func(opts *mapReduceOptions) {
@@ -330,13 +421,58 @@ func executeMappers(mCtx mapperContext) {
 	}
 }
 
+// wrapMapperTimeout bounds each mapper call with timeout,
+// onTimeout is called when the mapper did not finish in time,
+// and the late writes of a timed out mapper are discarded.
+func wrapMapperTimeout(mapper MapFunc, timeout time.Duration, onTimeout func()) MapFunc {
+	return func(item interface{}, writer Writer) {
+		tw := &timeoutWriter{writer: writer}
+		// buffered, the late panic of a timed out mapper is dropped on the floor
+		panicChan := make(chan interface{}, 1)
+		done := make(chan lang.PlaceholderType)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panicChan <- r
+				}
+				close(done)
+			}()
+
+			mapper(item, tw)
+		}()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-done:
+			select {
+			case r := <-panicChan:
+				panic(r)
+			default:
+			}
+		case <-timer.C:
+			tw.expire()
+			onTimeout()
+		}
+	}
+}
+
 func newOptions() *mapReduceOptions {
 	return &mapReduceOptions{
 		ctx:     context.Background(),
 		workers: defaultWorkers,
+		buffer:  useWorkersBuffer,
 	}
 }
 
+func (opts *mapReduceOptions) collectorBuffer() int {
+	if opts.buffer == useWorkersBuffer {
+		return opts.workers
+	}
+
+	return opts.buffer
+}
+
 func once(fn func(error)) func(error) {
 	once := new(sync.Once)
 	return func(err error) {
@@ -372,6 +508,21 @@ func (gw guardedWriter) Write(v interface{}) {
 	}
 }
 
+type timeoutWriter struct {
+	writer  Writer
+	expired int32
+}
+
+func (tw *timeoutWriter) Write(v interface{}) {
+	if atomic.LoadInt32(&tw.expired) == 0 {
+		tw.writer.Write(v)
+	}
+}
+
+func (tw *timeoutWriter) expire() {
+	atomic.StoreInt32(&tw.expired, 1)
+}
+
 type onceChan struct {
 	channel chan interface{}
 	wrote   int32
@@ -0,0 +1,155 @@
+package mr
+
+import (
+	"github.com/qkbyte/go-zero/core/lang"
+)
+
+type (
+	// ForEachFuncT is the typed version of ForEachFunc.
+	ForEachFuncT[T any] func(item T)
+	// GenerateFuncT is the typed version of GenerateFunc.
+	GenerateFuncT[T any] func(source chan<- T)
+	// MapperFuncT is the typed version of MapperFunc.
+	MapperFuncT[T, U any] func(item T, writer WriterT[U], cancel func(error))
+	// ReducerFuncT is the typed version of ReducerFunc.
+	ReducerFuncT[U, V any] func(pipe <-chan U, writer WriterT[V], cancel func(error))
+	// VoidReducerFuncT is the typed version of VoidReducerFunc.
+	VoidReducerFuncT[U any] func(pipe <-chan U, cancel func(error))
+	// StreamReducerFuncT is the typed version of StreamReducerFunc.
+	StreamReducerFuncT[U any] func(item U, cancel func(error))
+
+	// WriterT is the typed version of Writer.
+	WriterT[T any] interface {
+		Write(v T)
+	}
+
+	typedWriter[T any] struct {
+		writer Writer
+	}
+)
+
+// ForEachT is the typed version of ForEach,
+// so callers don't need interface{} assertions in mapper.
+func ForEachT[T any](generate GenerateFuncT[T], mapper ForEachFuncT[T], opts ...Option) {
+	ForEach(adaptGenerate(generate), func(item interface{}) {
+		mapper(item.(T))
+	}, opts...)
+}
+
+// MapReduceT is the typed version of MapReduce,
+// so callers don't need interface{} assertions in mapper and reducer.
+func MapReduceT[T, U, V any](generate GenerateFuncT[T], mapper MapperFuncT[T, U],
+	reducer ReducerFuncT[U, V], opts ...Option) (V, error) {
+	var result V
+	val, err := MapReduce(adaptGenerate(generate), adaptMapper(mapper),
+		adaptReducer(reducer), opts...)
+	if err != nil {
+		return result, err
+	}
+
+	result, _ = val.(V)
+	return result, nil
+}
+
+// MapReduceChanT is the typed version of MapReduceChan,
+// so callers don't need interface{} assertions in mapper and reducer.
+func MapReduceChanT[T, U, V any](source <-chan T, mapper MapperFuncT[T, U],
+	reducer ReducerFuncT[U, V], opts ...Option) (V, error) {
+	proxy := make(chan interface{})
+	go func() {
+		for v := range source {
+			proxy <- v
+		}
+		close(proxy)
+	}()
+
+	var result V
+	val, err := MapReduceChan(proxy, adaptMapper(mapper), adaptReducer(reducer), opts...)
+	if err != nil {
+		return result, err
+	}
+
+	result, _ = val.(V)
+	return result, nil
+}
+
+// MapReduceStreamT is the typed version of MapReduceStream,
+// so callers don't need interface{} assertions in mapper and reducer.
+func MapReduceStreamT[T, U any](generate GenerateFuncT[T], mapper MapperFuncT[T, U],
+	reducer StreamReducerFuncT[U], opts ...Option) error {
+	return MapReduceStream(adaptGenerate(generate), adaptMapper(mapper),
+		func(item interface{}, cancel func(error)) {
+			reducer(item.(U), cancel)
+		}, opts...)
+}
+
+// MapReduceVoidT is the typed version of MapReduceVoid,
+// so callers don't need interface{} assertions in mapper and reducer.
+func MapReduceVoidT[T, U any](generate GenerateFuncT[T], mapper MapperFuncT[T, U],
+	reducer VoidReducerFuncT[U], opts ...Option) error {
+	return MapReduceVoid(adaptGenerate(generate), adaptMapper(mapper),
+		func(pipe <-chan interface{}, cancel func(error)) {
+			typed, done := pumpTyped[U](pipe)
+			defer close(done)
+			reducer(typed, cancel)
+		}, opts...)
+}
+
+// adaptGenerate runs generate in the calling goroutine,
+// so panics keep flowing through the panic handling of the untyped API.
+func adaptGenerate[T any](generate GenerateFuncT[T]) GenerateFunc {
+	return func(source chan<- interface{}) {
+		typed := make(chan T)
+		done := make(chan lang.PlaceholderType)
+		go func() {
+			for v := range typed {
+				source <- v
+			}
+			close(done)
+		}()
+		defer func() {
+			close(typed)
+			<-done
+		}()
+
+		generate(typed)
+	}
+}
+
+func adaptMapper[T, U any](mapper MapperFuncT[T, U]) MapperFunc {
+	return func(item interface{}, writer Writer, cancel func(error)) {
+		mapper(item.(T), typedWriter[U]{writer: writer}, cancel)
+	}
+}
+
+func adaptReducer[U, V any](reducer ReducerFuncT[U, V]) ReducerFunc {
+	return func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		typed, done := pumpTyped[U](pipe)
+		defer close(done)
+		reducer(typed, typedWriter[V]{writer: writer}, cancel)
+	}
+}
+
+// pumpTyped copies pipe into a typed channel, closing done makes the pump
+// drain the rest of pipe without blocking, so early returning reducers
+// don't leak the mapper goroutines.
+func pumpTyped[U any](pipe <-chan interface{}) (chan U, chan lang.PlaceholderType) {
+	typed := make(chan U)
+	done := make(chan lang.PlaceholderType)
+	go func() {
+		defer close(typed)
+		for v := range pipe {
+			select {
+			case typed <- v.(U):
+			case <-done:
+				// reducer returned early, keep draining pipe
+			}
+		}
+	}()
+
+	return typed, done
+}
+
+func (w typedWriter[T]) Write(v T) {
+	w.writer.Write(v)
+}
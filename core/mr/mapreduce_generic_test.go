@@ -0,0 +1,165 @@
+package mr
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachT(t *testing.T) {
+	var count uint32
+	ForEachT(func(source chan<- int) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item int) {
+		if item%2 == 0 {
+			atomic.AddUint32(&count, 1)
+		}
+	})
+
+	assert.Equal(t, uint32(5), count)
+}
+
+func TestMapReduceT(t *testing.T) {
+	sum, err := MapReduceT(func(source chan<- int) {
+		for i := 1; i <= 5; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterT[int], cancel func(error)) {
+		writer.Write(item * item)
+	}, func(pipe <-chan int, writer WriterT[int], cancel func(error)) {
+		var total int
+		for v := range pipe {
+			total += v
+		}
+		writer.Write(total)
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 55, sum)
+}
+
+func TestMapReduceT_Cancel(t *testing.T) {
+	val, err := MapReduceT(func(source chan<- int) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterT[int], cancel func(error)) {
+		if item == 5 {
+			cancel(errDummy)
+			return
+		}
+		writer.Write(item)
+	}, func(pipe <-chan int, writer WriterT[int], cancel func(error)) {
+		var total int
+		for v := range pipe {
+			total += v
+		}
+		writer.Write(total)
+	})
+
+	assert.Equal(t, errDummy, err)
+	assert.Equal(t, 0, val)
+}
+
+func TestMapReduceT_NoOutput(t *testing.T) {
+	val, err := MapReduceT(func(source chan<- int) {
+		source <- 1
+	}, func(item int, writer WriterT[int], cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan int, writer WriterT[string], cancel func(error)) {
+		for range pipe {
+		}
+	})
+
+	assert.Equal(t, ErrReduceNoOutput, err)
+	assert.Equal(t, "", val)
+}
+
+func TestMapReduceT_EarlyReturnReducer(t *testing.T) {
+	val, err := MapReduceT(func(source chan<- int) {
+		for i := 0; i < 100; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterT[int], cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan int, writer WriterT[int], cancel func(error)) {
+		// return after the first item, the rest is drained for us.
+		writer.Write(<-pipe)
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, val >= 0 && val < 100)
+}
+
+func TestMapReduceT_Panic(t *testing.T) {
+	assert.Panics(t, func() {
+		_, _ = MapReduceT(func(source chan<- int) {
+			source <- 1
+		}, func(item int, writer WriterT[int], cancel func(error)) {
+			panic("fail")
+		}, func(pipe <-chan int, writer WriterT[int], cancel func(error)) {
+			for range pipe {
+			}
+		})
+	})
+}
+
+func TestMapReduceChanT(t *testing.T) {
+	source := make(chan int)
+	go func() {
+		for i := 1; i <= 5; i++ {
+			source <- i
+		}
+		close(source)
+	}()
+
+	sum, err := MapReduceChanT(source, func(item int, writer WriterT[int], cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan int, writer WriterT[int], cancel func(error)) {
+		var total int
+		for v := range pipe {
+			total += v
+		}
+		writer.Write(total)
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 15, sum)
+}
+
+func TestMapReduceStreamT(t *testing.T) {
+	var total uint32
+	err := MapReduceStreamT(func(source chan<- int) {
+		for i := 1; i <= 5; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterT[int], cancel func(error)) {
+		writer.Write(item)
+	}, func(item int, cancel func(error)) {
+		atomic.AddUint32(&total, uint32(item))
+	}, WithBuffer(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(15), total)
+}
+
+func TestMapReduceVoidT(t *testing.T) {
+	var total uint32
+	err := MapReduceVoidT(func(source chan<- int) {
+		for i := 1; i <= 5; i++ {
+			source <- i
+		}
+	}, func(item int, writer WriterT[int], cancel func(error)) {
+		writer.Write(item)
+	}, func(pipe <-chan int, cancel func(error)) {
+		for v := range pipe {
+			atomic.AddUint32(&total, uint32(v))
+		}
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(15), total)
+}
@@ -601,6 +601,139 @@ func TestMapReduceWithContext(t *testing.T) {
 	assert.Equal(t, context.DeadlineExceeded, err)
 }
 
+func TestMapReduceBestEffort(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	val, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 10; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		i := item.(int)
+		if i%2 == 1 {
+			cancel(errDummy)
+			return
+		}
+		writer.Write(i)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v.(int)
+		}
+		writer.Write(result)
+	}, WithBestEffort())
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 20, val)
+}
+
+func TestMapReduceVoidBestEffort(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	err := MapReduceVoid(func(source chan<- interface{}) {
+		for i := 0; i < 5; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if item.(int) == 1 {
+			cancel(errDummy)
+			return
+		}
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, cancel func(error)) {
+		drain(pipe)
+	}, WithBestEffort())
+
+	assert.Equal(t, errDummy, err)
+}
+
+func TestMapReduceMapperTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	block := make(chan struct{})
+	defer close(block)
+
+	val, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 5; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if item.(int) == 0 {
+			<-block
+		}
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v.(int)
+		}
+		writer.Write(result)
+	}, WithMapperTimeout(time.Millisecond*50))
+
+	assert.Equal(t, ErrMapperTimeout, err)
+	assert.Nil(t, val)
+}
+
+func TestMapReduceMapperTimeoutBestEffort(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	block := make(chan struct{})
+	defer close(block)
+
+	val, err := MapReduce(func(source chan<- interface{}) {
+		for i := 0; i < 5; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		if item.(int) == 0 {
+			<-block
+		}
+		writer.Write(item)
+	}, func(pipe <-chan interface{}, writer Writer, cancel func(error)) {
+		var result int
+		for v := range pipe {
+			result += v.(int)
+		}
+		writer.Write(result)
+	}, WithBestEffort(), WithMapperTimeout(time.Millisecond*50))
+
+	assert.Equal(t, ErrMapperTimeout, err)
+	assert.Equal(t, 10, val)
+}
+
+func TestMapReduceStream(t *testing.T) {
+	var sum uint64
+	err := MapReduceStream(func(source chan<- interface{}) {
+		for i := 1; i < 5; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		i := item.(int)
+		writer.Write(i * i)
+	}, func(item interface{}, cancel func(error)) {
+		atomic.AddUint64(&sum, uint64(item.(int)))
+	}, WithBuffer(0))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(30), sum)
+}
+
+func TestMapReduceStreamCancel(t *testing.T) {
+	err := MapReduceStream(func(source chan<- interface{}) {
+		for i := 0; i < defaultWorkers*2; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer Writer, cancel func(error)) {
+		writer.Write(item)
+	}, func(item interface{}, cancel func(error)) {
+		if item.(int) == 0 {
+			cancel(errDummy)
+		}
+	})
+
+	assert.Equal(t, errDummy, err)
+}
+
 func BenchmarkMapReduce(b *testing.B) {
 	b.ReportAllocs()
 
@@ -0,0 +1,16 @@
+//go:build windows
+// +build windows
+
+package proc
+
+// DumpProfiles does nothing on windows.
+func DumpProfiles() {
+}
+
+// SetDumpDir does nothing on windows.
+func SetDumpDir(dir string) {
+}
+
+// SetMaxDumpFiles does nothing on windows.
+func SetMaxDumpFiles(count int) {
+}
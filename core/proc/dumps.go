@@ -0,0 +1,129 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/logx"
+)
+
+const (
+	heapProfile  = "heap"
+	mutexProfile = "mutex"
+	blockProfile = "block"
+
+	dumpFileSuffix      = ".dump"
+	defaultMaxDumpFiles = 10
+)
+
+var (
+	dumpLock     sync.Mutex
+	dumpDir      = os.TempDir()
+	maxDumpFiles = defaultMaxDumpFiles
+)
+
+// SetDumpDir sets the directory the profile dumps are written to,
+// os.TempDir() by default.
+func SetDumpDir(dir string) {
+	dumpLock.Lock()
+	defer dumpLock.Unlock()
+	dumpDir = dir
+}
+
+// SetMaxDumpFiles sets how many dump files are kept in the dump directory,
+// the oldest ones beyond the limit are removed after each dump.
+// A non-positive count disables the cleanup.
+func SetMaxDumpFiles(count int) {
+	dumpLock.Lock()
+	defer dumpLock.Unlock()
+	maxDumpFiles = count
+}
+
+// DumpProfiles writes the goroutine, heap, mutex and block profiles to the
+// dump directory, use it to capture the process state when watchdogs fire.
+func DumpProfiles() {
+	dumpProfile(goroutineProfile, debugLevel)
+	dumpProfile(heapProfile, 0)
+	dumpProfile(mutexProfile, 0)
+	dumpProfile(blockProfile, 0)
+}
+
+func dumpProfile(profile string, debug int) {
+	dumpLock.Lock()
+	dir := dumpDir
+	keep := maxDumpFiles
+	dumpLock.Unlock()
+
+	command := path.Base(os.Args[0])
+	pid := syscall.Getpid()
+	dumpFile := path.Join(dir, fmt.Sprintf("%s-%d-%s-%s%s",
+		command, pid, profile, time.Now().Format(timeFormat), dumpFileSuffix))
+
+	logx.Infof("Dumping %s profile to %s", profile, dumpFile)
+
+	f, err := os.Create(dumpFile)
+	if err != nil {
+		logx.Errorf("Failed to dump %s profile, error: %v", profile, err)
+		return
+	}
+	defer f.Close()
+
+	if p := pprof.Lookup(profile); p != nil {
+		p.WriteTo(f, debug)
+	}
+
+	removeStaleDumps(dir, command, keep)
+}
+
+// removeStaleDumps removes the oldest dump files of this command in dir,
+// keeping at most the given number.
+func removeStaleDumps(dir, command string, keep int) {
+	if keep <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logx.Errorf("Failed to clean up dump files, error: %v", err)
+		return
+	}
+
+	var dumps []os.DirEntry
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), command+"-") &&
+			strings.HasSuffix(entry.Name(), dumpFileSuffix) {
+			dumps = append(dumps, entry)
+		}
+	}
+	if len(dumps) <= keep {
+		return
+	}
+
+	sort.Slice(dumps, func(i, j int) bool {
+		fi, ierr := dumps[i].Info()
+		fj, jerr := dumps[j].Info()
+		if ierr != nil || jerr != nil {
+			return dumps[i].Name() < dumps[j].Name()
+		}
+		return fi.ModTime().Before(fj.ModTime())
+	})
+
+	for _, entry := range dumps[:len(dumps)-keep] {
+		if err := os.Remove(path.Join(dir, entry.Name())); err != nil {
+			logx.Errorf("Failed to remove stale dump file %s, error: %v", entry.Name(), err)
+		}
+	}
+}
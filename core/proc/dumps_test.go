@@ -0,0 +1,67 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpProfiles(t *testing.T) {
+	dir := t.TempDir()
+	SetDumpDir(dir)
+	defer SetDumpDir(os.TempDir())
+
+	DumpProfiles()
+
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(entries))
+	for _, profile := range []string{goroutineProfile, heapProfile, mutexProfile, blockProfile} {
+		assert.True(t, containsDump(entries, profile), profile)
+	}
+}
+
+func TestDumpRetention(t *testing.T) {
+	dir := t.TempDir()
+	SetDumpDir(dir)
+	SetMaxDumpFiles(2)
+	defer func() {
+		SetDumpDir(os.TempDir())
+		SetMaxDumpFiles(defaultMaxDumpFiles)
+	}()
+
+	command := path.Base(os.Args[0])
+	stale := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		name := path.Join(dir, fmt.Sprintf("%s-%d-fake-%d%s",
+			command, os.Getpid(), i, dumpFileSuffix))
+		assert.Nil(t, os.WriteFile(name, []byte("fake"), 0o644))
+		assert.Nil(t, os.Chtimes(name, stale, stale.Add(time.Duration(i)*time.Second)))
+	}
+
+	dumpProfile(heapProfile, 0)
+
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(entries))
+	// the fresh dump survives the cleanup
+	assert.True(t, containsDump(entries, heapProfile))
+}
+
+func containsDump(entries []os.DirEntry, profile string) bool {
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), "-"+profile+"-") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -5,11 +5,28 @@ package proc
 
 import "time"
 
+// ShutdownPriority defines the phase a shutdown listener runs in.
+type ShutdownPriority int
+
+const (
+	// PriorityDrain is for listeners that stop accepting new traffic, they run first.
+	PriorityDrain ShutdownPriority = iota
+	// PriorityFlush is for listeners that flush buffered data, like traces and metrics.
+	PriorityFlush
+	// PriorityClose is for listeners that close underlying stores and connections, they run last.
+	PriorityClose
+)
+
 // AddShutdownListener returns fn itself on windows, lets callers call fn on their own.
 func AddShutdownListener(fn func()) func() {
 	return fn
 }
 
+// AddShutdownListenerWithPriority returns fn itself on windows, lets callers call fn on their own.
+func AddShutdownListenerWithPriority(fn func(), _ ShutdownPriority) func() {
+	return fn
+}
+
 // AddWrapUpListener returns fn itself on windows, lets callers call fn on their own.
 func AddWrapUpListener(fn func()) func() {
 	return fn
@@ -17,3 +34,6 @@ func AddWrapUpListener(fn func()) func() {
 
 func SetTimeToForceQuit(duration time.Duration) {
 }
+
+func SetWrapUpTime(duration time.Duration) {
+}
@@ -15,21 +15,43 @@ import (
 )
 
 const (
-	wrapUpTime = time.Second
+	defaultWrapUpTime = time.Second
 	// why we use 5500 milliseconds is because most of our queue are blocking mode with 5 seconds
-	waitTime = 5500 * time.Millisecond
+	defaultWaitTime = 5500 * time.Millisecond
+)
+
+// ShutdownPriority defines the phase a shutdown listener runs in.
+// Listeners in the same phase are notified concurrently,
+// and the next phase starts only after the previous one is done.
+type ShutdownPriority int
+
+const (
+	// PriorityDrain is for listeners that stop accepting new traffic, they run first.
+	PriorityDrain ShutdownPriority = iota
+	// PriorityFlush is for listeners that flush buffered data, like traces and metrics.
+	PriorityFlush
+	// PriorityClose is for listeners that close underlying stores and connections, they run last.
+	PriorityClose
+	priorityCount
 )
 
 var (
 	wrapUpListeners          = new(listenerManager)
-	shutdownListeners        = new(listenerManager)
-	delayTimeBeforeForceQuit = waitTime
+	shutdownListeners        = new(priorityListenerManager)
+	wrapUpTime               = defaultWrapUpTime
+	delayTimeBeforeForceQuit = defaultWaitTime
 )
 
-// AddShutdownListener adds fn as a shutdown listener.
+// AddShutdownListener adds fn as a shutdown listener with PriorityFlush.
 // The returned func can be used to wait for fn getting called.
 func AddShutdownListener(fn func()) (waitForCalled func()) {
-	return shutdownListeners.addListener(fn)
+	return shutdownListeners.addListener(fn, PriorityFlush)
+}
+
+// AddShutdownListenerWithPriority adds fn as a shutdown listener in the given phase.
+// The returned func can be used to wait for fn getting called.
+func AddShutdownListenerWithPriority(fn func(), priority ShutdownPriority) (waitForCalled func()) {
+	return shutdownListeners.addListener(fn, priority)
 }
 
 // AddWrapUpListener adds fn as a wrap up listener.
@@ -43,6 +65,11 @@ func SetTimeToForceQuit(duration time.Duration) {
 	delayTimeBeforeForceQuit = duration
 }
 
+// SetWrapUpTime sets the waiting time between the wrap up and shutdown phases.
+func SetWrapUpTime(duration time.Duration) {
+	wrapUpTime = duration
+}
+
 func gracefulStop(signals chan os.Signal) {
 	signal.Stop(signals)
 
@@ -88,3 +115,21 @@ func (lm *listenerManager) notifyListeners() {
 	}
 	group.Wait()
 }
+
+type priorityListenerManager struct {
+	managers [priorityCount]listenerManager
+}
+
+func (pm *priorityListenerManager) addListener(fn func(), priority ShutdownPriority) (waitForCalled func()) {
+	if priority < PriorityDrain || priority >= priorityCount {
+		priority = PriorityFlush
+	}
+
+	return pm.managers[priority].addListener(fn)
+}
+
+func (pm *priorityListenerManager) notifyListeners() {
+	for i := range pm.managers {
+		pm.managers[i].notifyListeners()
+	}
+}
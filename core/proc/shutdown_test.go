@@ -4,6 +4,7 @@
 package proc
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -29,3 +30,34 @@ func TestShutdown(t *testing.T) {
 	called()
 	assert.Equal(t, 3, val)
 }
+
+func TestShutdownWithPriority(t *testing.T) {
+	var lock sync.Mutex
+	var order []ShutdownPriority
+	record := func(priority ShutdownPriority) func() {
+		return func() {
+			lock.Lock()
+			defer lock.Unlock()
+			order = append(order, priority)
+		}
+	}
+
+	var listeners priorityListenerManager
+	listeners.addListener(record(PriorityClose), PriorityClose)
+	listeners.addListener(record(PriorityDrain), PriorityDrain)
+	listeners.addListener(record(PriorityFlush), PriorityFlush)
+	// out of range priorities fall back to PriorityFlush
+	called := listeners.addListener(record(PriorityFlush), priorityCount+1)
+	listeners.notifyListeners()
+	called()
+	assert.Equal(t, []ShutdownPriority{
+		PriorityDrain, PriorityFlush, PriorityFlush, PriorityClose,
+	}, order)
+}
+
+func TestSetWrapUpTime(t *testing.T) {
+	defer SetWrapUpTime(defaultWrapUpTime)
+
+	SetWrapUpTime(time.Minute)
+	assert.Equal(t, time.Minute, wrapUpTime)
+}
@@ -27,7 +27,7 @@ func init() {
 			v := <-signals
 			switch v {
 			case syscall.SIGUSR1:
-				dumpGoroutines()
+				DumpProfiles()
 			case syscall.SIGUSR2:
 				if profiler == nil {
 					profiler = StartProfile()
@@ -4,9 +4,14 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
+	prom "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/proc"
 	"github.com/qkbyte/go-zero/core/syncx"
 	"github.com/qkbyte/go-zero/core/threading"
 )
@@ -14,6 +19,10 @@ import (
 var (
 	once    sync.Once
 	enabled syncx.AtomicBool
+
+	registryLock sync.RWMutex
+	registerer   prom.Registerer = prom.DefaultRegisterer
+	gatherer     prom.Gatherer   = prom.DefaultGatherer
 )
 
 // Enabled returns if prometheus is enabled.
@@ -21,21 +30,115 @@ func Enabled() bool {
 	return enabled.True()
 }
 
+// MustRegister registers the given collectors with the current registerer.
+func MustRegister(cs ...prom.Collector) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	registerer.MustRegister(cs...)
+}
+
+// Unregister unregisters the given collector from the current registerer.
+func Unregister(c prom.Collector) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return registerer.Unregister(c)
+}
+
+// SetRegistry replaces the implicit default registry, the metrics created
+// afterwards are registered into reg, and the agent exposes and pushes reg.
+// Call it before setting up the service.
+func SetRegistry(reg *prom.Registry) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registerer = reg
+	gatherer = reg
+}
+
 // StartAgent starts a prometheus agent.
 func StartAgent(c Config) {
-	if len(c.Host) == 0 {
+	if len(c.Host) == 0 && len(c.PushGateway) == 0 {
 		return
 	}
 
 	once.Do(func() {
 		enabled.Set(true)
-		threading.GoSafe(func() {
-			http.Handle(c.Path, promhttp.Handler())
-			addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
-			logx.Infof("Starting prometheus agent at %s", addr)
-			if err := http.ListenAndServe(addr, nil); err != nil {
-				logx.Error(err)
+
+		registryLock.RLock()
+		g := newLabeledGatherer(gatherer, c.Labels)
+		registryLock.RUnlock()
+
+		if len(c.Host) > 0 {
+			threading.GoSafe(func() {
+				http.Handle(c.Path, promhttp.HandlerFor(g, promhttp.HandlerOpts{}))
+				addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
+				logx.Infof("Starting prometheus agent at %s", addr)
+				if err := http.ListenAndServe(addr, nil); err != nil {
+					logx.Error(err)
+				}
+			})
+		}
+
+		if len(c.PushGateway) > 0 {
+			startPusher(c, g)
+		}
+	})
+}
+
+func startPusher(c Config, g prom.Gatherer) {
+	jobName := c.PushJobName
+	if len(jobName) == 0 {
+		jobName = proc.ProcessName()
+	}
+
+	pusher := push.New(c.PushGateway, jobName).Gatherer(g)
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(time.Duration(c.PushInterval) * time.Second)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := pusher.Push(); err != nil {
+				logx.Errorf("push metrics to %s failed: %v", c.PushGateway, err)
 			}
-		})
+		}
 	})
 }
+
+// A labeledGatherer attaches the constant labels to every gathered metric.
+type labeledGatherer struct {
+	gatherer prom.Gatherer
+	labels   []*dto.LabelPair
+}
+
+func newLabeledGatherer(g prom.Gatherer, labels map[string]string) prom.Gatherer {
+	pairs := make([]*dto.LabelPair, 0, len(labels))
+	for name, value := range labels {
+		name, value := name, value
+		pairs = append(pairs, &dto.LabelPair{
+			Name:  &name,
+			Value: &value,
+		})
+	}
+
+	return labeledGatherer{
+		gatherer: g,
+		labels:   pairs,
+	}
+}
+
+func (g labeledGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	if err != nil || len(g.labels) == 0 {
+		return families, err
+	}
+
+	for _, family := range families {
+		for _, m := range family.Metric {
+			m.Label = append(m.Label, g.labels...)
+		}
+	}
+
+	return families, nil
+}
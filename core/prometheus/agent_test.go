@@ -0,0 +1,50 @@
+package prometheus
+
+import (
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabeledGatherer(t *testing.T) {
+	registry := prom.NewRegistry()
+	counter := prom.NewCounter(prom.CounterOpts{
+		Name: "test_total",
+		Help: "test counter.",
+	})
+	registry.MustRegister(counter)
+	counter.Inc()
+
+	g := newLabeledGatherer(registry, map[string]string{
+		"env": "test",
+	})
+	families, err := g.Gather()
+	assert.Nil(t, err)
+	assert.Len(t, families, 1)
+	labels := families[0].Metric[0].Label
+	assert.Len(t, labels, 1)
+	assert.Equal(t, "env", labels[0].GetName())
+	assert.Equal(t, "test", labels[0].GetValue())
+}
+
+func TestSetRegistry(t *testing.T) {
+	defer func() {
+		registryLock.Lock()
+		registerer = prom.DefaultRegisterer
+		gatherer = prom.DefaultGatherer
+		registryLock.Unlock()
+	}()
+
+	registry := prom.NewRegistry()
+	SetRegistry(registry)
+	counter := prom.NewCounter(prom.CounterOpts{
+		Name: "test_registry_total",
+		Help: "test counter.",
+	})
+	MustRegister(counter)
+	families, err := registry.Gather()
+	assert.Nil(t, err)
+	assert.Len(t, families, 1)
+	assert.True(t, Unregister(counter))
+}
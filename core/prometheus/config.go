@@ -5,4 +5,16 @@ type Config struct {
 	Host string `json:",optional"`
 	Port int    `json:",default=9101"`
 	Path string `json:",default=/metrics"`
+	// Labels are constant labels attached to every exposed metric,
+	// like service, env and region.
+	Labels map[string]string `json:",optional"`
+	// PushGateway is the push gateway url, when set the metrics are
+	// pushed periodically besides being exposed for pulling,
+	// useful for short-lived jobs.
+	PushGateway string `json:",optional"`
+	// PushJobName is the job name used when pushing,
+	// default to the process name.
+	PushJobName string `json:",optional"`
+	// PushInterval is the push interval in seconds.
+	PushInterval int `json:",default=15"`
 }
@@ -0,0 +1,76 @@
+package queue
+
+import "github.com/qkbyte/go-zero/core/logx"
+
+type (
+	// A Message is a message delivered by a Broker.
+	Message struct {
+		Id   string
+		Body string
+		// Attempts is the number of times the message has been delivered.
+		Attempts int
+	}
+
+	// A Handler handles a delivered message, returning an error
+	// leads to a redelivery per the retry policy of the broker.
+	Handler func(msg Message) error
+
+	// A RetryPolicy controls the redelivery of failed messages.
+	RetryPolicy struct {
+		// MaxAttempts is the max delivery attempts per message.
+		MaxAttempts int
+		// DeadLetterTopic receives the messages that exhausted the attempts,
+		// they are dropped when empty.
+		DeadLetterTopic string
+	}
+
+	// A Broker is a broker-agnostic producer/consumer abstraction,
+	// the subscribers with the same group share the messages of a topic,
+	// the subscribers with different groups each receive all the messages.
+	Broker interface {
+		// Publish publishes body to topic.
+		Publish(topic, body string) error
+		// Subscribe subscribes handler to topic within group.
+		Subscribe(topic, group string, handler Handler) (Subscription, error)
+	}
+
+	// A Subscription represents a running subscriber, Close stops it.
+	Subscription interface {
+		Close() error
+	}
+)
+
+// A ConsumeService wraps a Broker subscription as a service.Service,
+// so event-driven services can be managed by a ServiceGroup.
+type ConsumeService struct {
+	broker  Broker
+	topic   string
+	group   string
+	handler Handler
+	sub     Subscription
+}
+
+// NewConsumeService returns a ConsumeService that subscribes handler
+// to topic within group on Start, and closes the subscription on Stop.
+func NewConsumeService(broker Broker, topic, group string, handler Handler) *ConsumeService {
+	return &ConsumeService{
+		broker:  broker,
+		topic:   topic,
+		group:   group,
+		handler: handler,
+	}
+}
+
+// Start starts consuming, implements service.Service.
+func (s *ConsumeService) Start() {
+	sub, err := s.broker.Subscribe(s.topic, s.group, s.handler)
+	logx.Must(err)
+	s.sub = sub
+}
+
+// Stop stops consuming, implements service.Service.
+func (s *ConsumeService) Stop() {
+	if s.sub != nil {
+		_ = s.sub.Close()
+	}
+}
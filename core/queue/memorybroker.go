@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/qkbyte/go-zero/core/lang"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/stringx"
+)
+
+const defaultGroupBuffer = 1024
+
+// ErrFullQueue is an error that indicates the group queue is full.
+var ErrFullQueue = errors.New("the group queue is full")
+
+type (
+	// A BrokerOption customizes a MemoryBroker.
+	BrokerOption func(b *MemoryBroker)
+
+	// A MemoryBroker is an in-process Broker implementation,
+	// mainly for tests and single node setups.
+	// The messages published before a group subscribes are not delivered to it.
+	MemoryBroker struct {
+		lock   sync.RWMutex
+		topics map[string]map[string]chan Message
+		policy RetryPolicy
+		buffer int
+	}
+
+	memorySubscription struct {
+		done chan lang.PlaceholderType
+		wg   sync.WaitGroup
+	}
+)
+
+// NewMemoryBroker returns a MemoryBroker.
+func NewMemoryBroker(opts ...BrokerOption) *MemoryBroker {
+	broker := &MemoryBroker{
+		topics: make(map[string]map[string]chan Message),
+		buffer: defaultGroupBuffer,
+	}
+	for _, opt := range opts {
+		opt(broker)
+	}
+
+	return broker
+}
+
+// WithGroupBuffer customizes the buffered messages per group.
+func WithGroupBuffer(buffer int) BrokerOption {
+	return func(b *MemoryBroker) {
+		b.buffer = buffer
+	}
+}
+
+// WithRetryPolicy customizes the retry policy of the broker.
+func WithRetryPolicy(policy RetryPolicy) BrokerOption {
+	return func(b *MemoryBroker) {
+		b.policy = policy
+	}
+}
+
+// Publish publishes body to the subscribed groups of topic.
+func (b *MemoryBroker) Publish(topic, body string) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for _, ch := range b.topics[topic] {
+		select {
+		case ch <- Message{
+			Id:       stringx.RandId(),
+			Body:     body,
+			Attempts: 1,
+		}:
+		default:
+			return ErrFullQueue
+		}
+	}
+
+	return nil
+}
+
+// Subscribe subscribes handler to topic within group.
+func (b *MemoryBroker) Subscribe(topic, group string, handler Handler) (Subscription, error) {
+	ch := b.groupChan(topic, group)
+	sub := &memorySubscription{
+		done: make(chan lang.PlaceholderType),
+	}
+	sub.wg.Add(1)
+	go func() {
+		defer sub.wg.Done()
+
+		for {
+			select {
+			case <-sub.done:
+				return
+			case msg := <-ch:
+				b.handleMessage(ch, msg, handler)
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+func (b *MemoryBroker) groupChan(topic, group string) chan Message {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	groups, ok := b.topics[topic]
+	if !ok {
+		groups = make(map[string]chan Message)
+		b.topics[topic] = groups
+	}
+	ch, ok := groups[group]
+	if !ok {
+		ch = make(chan Message, b.buffer)
+		groups[group] = ch
+	}
+
+	return ch
+}
+
+func (b *MemoryBroker) handleMessage(ch chan Message, msg Message, handler Handler) {
+	if err := handler(msg); err == nil {
+		return
+	}
+
+	if b.policy.MaxAttempts > 0 && msg.Attempts >= b.policy.MaxAttempts {
+		if len(b.policy.DeadLetterTopic) > 0 {
+			if err := b.Publish(b.policy.DeadLetterTopic, msg.Body); err != nil {
+				logx.Errorf("failed to dead letter message %s, error: %v", msg.Id, err)
+			}
+		} else {
+			logx.Errorf("dropped message %s after %d attempts", msg.Id, msg.Attempts)
+		}
+		return
+	}
+
+	msg.Attempts++
+	select {
+	case ch <- msg:
+	default:
+		logx.Errorf("failed to redeliver message %s, %v", msg.Id, ErrFullQueue)
+	}
+}
+
+func (s *memorySubscription) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
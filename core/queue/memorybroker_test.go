@@ -0,0 +1,109 @@
+package queue
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBroker(t *testing.T) {
+	broker := NewMemoryBroker()
+	var first, second int32
+	sub1, err := broker.Subscribe("topic", "group1", func(msg Message) error {
+		atomic.AddInt32(&first, 1)
+		return nil
+	})
+	assert.Nil(t, err)
+	defer sub1.Close()
+	sub2, err := broker.Subscribe("topic", "group2", func(msg Message) error {
+		atomic.AddInt32(&second, 1)
+		return nil
+	})
+	assert.Nil(t, err)
+	defer sub2.Close()
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, broker.Publish("topic", "hello"))
+	}
+
+	waitForValue(t, &first, 10)
+	waitForValue(t, &second, 10)
+}
+
+func TestMemoryBrokerSharedGroup(t *testing.T) {
+	broker := NewMemoryBroker()
+	var count int32
+	handler := func(msg Message) error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	}
+	sub1, err := broker.Subscribe("topic", "group", handler)
+	assert.Nil(t, err)
+	defer sub1.Close()
+	sub2, err := broker.Subscribe("topic", "group", handler)
+	assert.Nil(t, err)
+	defer sub2.Close()
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, broker.Publish("topic", "hello"))
+	}
+
+	// the messages are shared, not duplicated, within a group
+	waitForValue(t, &count, 10)
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(10), atomic.LoadInt32(&count))
+}
+
+func TestMemoryBrokerDeadLetter(t *testing.T) {
+	broker := NewMemoryBroker(WithRetryPolicy(RetryPolicy{
+		MaxAttempts:     2,
+		DeadLetterTopic: "topic.dead",
+	}))
+	var attempts, dead int32
+	sub, err := broker.Subscribe("topic", "group", func(msg Message) error {
+		atomic.AddInt32(&attempts, 1)
+		return errors.New("mock error")
+	})
+	assert.Nil(t, err)
+	defer sub.Close()
+	deadSub, err := broker.Subscribe("topic.dead", "group", func(msg Message) error {
+		atomic.AddInt32(&dead, 1)
+		return nil
+	})
+	assert.Nil(t, err)
+	defer deadSub.Close()
+
+	assert.Nil(t, broker.Publish("topic", "hello"))
+	waitForValue(t, &attempts, 2)
+	waitForValue(t, &dead, 1)
+}
+
+func TestConsumeService(t *testing.T) {
+	broker := NewMemoryBroker()
+	var count int32
+	service := NewConsumeService(broker, "topic", "group", func(msg Message) error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+	service.Start()
+	defer service.Stop()
+
+	assert.Nil(t, broker.Publish("topic", "hello"))
+	waitForValue(t, &count, 1)
+}
+
+func waitForValue(t *testing.T, val *int32, expect int32) {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		if atomic.LoadInt32(val) >= expect {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	assert.Equal(t, expect, atomic.LoadInt32(val))
+}
@@ -5,10 +5,12 @@ import (
 
 	"github.com/qkbyte/go-zero/core/load"
 	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/metric"
 	"github.com/qkbyte/go-zero/core/proc"
 	"github.com/qkbyte/go-zero/core/prometheus"
 	"github.com/qkbyte/go-zero/core/stat"
 	"github.com/qkbyte/go-zero/core/trace"
+	"github.com/qkbyte/go-zero/internal/devserver"
 )
 
 const (
@@ -31,7 +33,9 @@ type ServiceConf struct {
 	Mode       string            `json:",default=pro,options=dev|test|rt|pre|pro"`
 	MetricsUrl string            `json:",optional"`
 	Prometheus prometheus.Config `json:",optional"`
+	Metrics    metric.Config     `json:",optional"`
 	Telemetry  trace.Config      `json:",optional"`
+	DevServer  devserver.Config  `json:",optional"`
 }
 
 // MustSetUp sets up the service, exits on error.
@@ -53,6 +57,11 @@ func (sc ServiceConf) SetUp() error {
 	sc.initMode()
 	prometheus.StartAgent(sc.Prometheus)
 
+	if len(sc.Metrics.Name) == 0 {
+		sc.Metrics.Name = sc.Name
+	}
+	metric.StartAgent(sc.Metrics)
+
 	if len(sc.Telemetry.Name) == 0 {
 		sc.Telemetry.Name = sc.Name
 	}
@@ -65,6 +74,8 @@ func (sc ServiceConf) SetUp() error {
 		stat.SetReportWriter(stat.NewRemoteWriter(sc.MetricsUrl))
 	}
 
+	devserver.StartAgent(sc.DevServer)
+
 	return nil
 }
 
@@ -26,10 +26,15 @@ type (
 	}
 
 	// A ServiceGroup is a group of services.
-	// Attention: the starting order of the added services is not guaranteed.
+	// The services are started in the order they were added,
+	// and stopped in the reverse order, so resources like DB pools
+	// and consumers start and stop deterministically.
 	ServiceGroup struct {
-		services []Service
-		stopOnce func()
+		services     []Service
+		beforeStarts []func()
+		afterStarts  []func()
+		beforeStops  []func()
+		stopOnce     func()
 	}
 )
 
@@ -42,8 +47,22 @@ func NewServiceGroup() *ServiceGroup {
 
 // Add adds service into sg.
 func (sg *ServiceGroup) Add(service Service) {
-	// push front, stop with reverse order.
-	sg.services = append([]Service{service}, sg.services...)
+	sg.services = append(sg.services, service)
+}
+
+// AfterStart registers fn to run after all the services have been started.
+func (sg *ServiceGroup) AfterStart(fn func()) {
+	sg.afterStarts = append(sg.afterStarts, fn)
+}
+
+// BeforeStart registers fn to run before the services are started.
+func (sg *ServiceGroup) BeforeStart(fn func()) {
+	sg.beforeStarts = append(sg.beforeStarts, fn)
+}
+
+// BeforeStop registers fn to run before the services are stopped.
+func (sg *ServiceGroup) BeforeStop(fn func()) {
+	sg.beforeStops = append(sg.beforeStops, fn)
 }
 
 // Start starts the ServiceGroup.
@@ -64,8 +83,13 @@ func (sg *ServiceGroup) Stop() {
 }
 
 func (sg *ServiceGroup) doStart() {
+	for _, fn := range sg.beforeStarts {
+		threading.RunSafe(fn)
+	}
+
 	routineGroup := threading.NewRoutineGroup()
 
+	// start in added order.
 	for i := range sg.services {
 		service := sg.services[i]
 		routineGroup.RunSafe(func() {
@@ -73,12 +97,21 @@ func (sg *ServiceGroup) doStart() {
 		})
 	}
 
+	for _, fn := range sg.afterStarts {
+		threading.RunSafe(fn)
+	}
+
 	routineGroup.Wait()
 }
 
 func (sg *ServiceGroup) doStop() {
-	for _, service := range sg.services {
-		service.Stop()
+	for _, fn := range sg.beforeStops {
+		threading.RunSafe(fn)
+	}
+
+	// stop in reverse order of adding.
+	for i := len(sg.services) - 1; i >= 0; i-- {
+		sg.services[i].Stop()
 	}
 }
 
@@ -124,3 +124,79 @@ type mockedStarter struct {
 func (s mockedStarter) Start() {
 	s.fn()
 }
+
+func TestServiceGroup_StopInReverseOrder(t *testing.T) {
+	var lock sync.Mutex
+	var stopped []int
+
+	group := NewServiceGroup()
+	for i := 0; i < 4; i++ {
+		i := i
+		group.Add(withStop(func() {
+			lock.Lock()
+			stopped = append(stopped, i)
+			lock.Unlock()
+		}))
+	}
+
+	group.Stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []int{3, 2, 1, 0}, stopped)
+}
+
+func TestServiceGroup_Hooks(t *testing.T) {
+	var lock sync.Mutex
+	var events []string
+	record := func(event string) {
+		lock.Lock()
+		events = append(events, event)
+		lock.Unlock()
+	}
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+	group := NewServiceGroup()
+	group.BeforeStart(func() {
+		record("beforeStart")
+	})
+	group.AfterStart(func() {
+		record("afterStart")
+	})
+	group.BeforeStop(func() {
+		record("beforeStop")
+	})
+	group.Add(WithStart(func() {
+		record("start")
+		wait.Done()
+	}))
+
+	go group.Start()
+	wait.Wait()
+	group.Stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, "beforeStart", events[0])
+	assert.Contains(t, events, "start")
+	assert.Contains(t, events, "afterStart")
+	assert.Equal(t, "beforeStop", events[len(events)-1])
+}
+
+type stopService struct {
+	fn func()
+}
+
+func withStop(fn func()) Service {
+	return stopService{
+		fn: fn,
+	}
+}
+
+func (s stopService) Start() {
+}
+
+func (s stopService) Stop() {
+	s.fn()
+}
@@ -17,8 +17,12 @@ import (
 
 const (
 	cgroupDir   = "/sys/fs/cgroup"
+	cpuMaxFile  = cgroupDir + "/cpu.max"
 	cpuStatFile = cgroupDir + "/cpu.stat"
 	cpusetFile  = cgroupDir + "/cpuset.cpus.effective"
+
+	// defaultCpuPeriodUs is the default cpu period in the unified hierarchy.
+	defaultCpuPeriodUs = 100000
 )
 
 var (
@@ -88,21 +92,23 @@ type cgroupV2 struct {
 }
 
 func (c *cgroupV2) cpuQuotaUs() (int64, error) {
-	data, err := iox.ReadText(path.Join(cgroupDir, "cpu.cfs_quota_us"))
+	data, err := iox.ReadText(cpuMaxFile)
 	if err != nil {
 		return 0, err
 	}
 
-	return strconv.ParseInt(data, 10, 64)
+	quota, _, err := parseCpuMax(data)
+	return quota, err
 }
 
 func (c *cgroupV2) cpuPeriodUs() (uint64, error) {
-	data, err := iox.ReadText(path.Join(cgroupDir, "cpu.cfs_period_us"))
+	data, err := iox.ReadText(cpuMaxFile)
 	if err != nil {
 		return 0, err
 	}
 
-	return parseUint(data)
+	_, period, err := parseCpuMax(data)
+	return period, err
 }
 
 func (c *cgroupV2) cpus() ([]uint64, error) {
@@ -196,6 +202,31 @@ func isCgroup2UnifiedMode() bool {
 	return isUnified
 }
 
+// parseCpuMax parses the content of cpu.max in the unified hierarchy,
+// which holds "$QUOTA $PERIOD", quota is "max" when unlimited,
+// period falls back to the default when absent.
+func parseCpuMax(data string) (quota int64, period uint64, err error) {
+	fields := strings.Fields(data)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, 0, fmt.Errorf("invalid cpu.max content: %q", data)
+	}
+
+	if fields[0] == "max" {
+		quota = -1
+	} else if quota, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+		return 0, 0, err
+	}
+
+	period = uint64(defaultCpuPeriodUs)
+	if len(fields) == 2 {
+		if period, err = parseUint(fields[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return quota, period, nil
+}
+
 func parseUint(s string) (uint64, error) {
 	v, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
@@ -17,3 +17,26 @@ func BenchmarkRefreshCpu(b *testing.B) {
 		RefreshCpu()
 	}
 }
+
+func TestParseCpuMax(t *testing.T) {
+	quota, period, err := parseCpuMax("max 100000")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), quota)
+	assert.Equal(t, uint64(100000), period)
+
+	quota, period, err = parseCpuMax("200000 50000")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(200000), quota)
+	assert.Equal(t, uint64(50000), period)
+
+	quota, period, err = parseCpuMax("150000")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(150000), quota)
+	assert.Equal(t, uint64(defaultCpuPeriodUs), period)
+
+	_, _, err = parseCpuMax("")
+	assert.NotNil(t, err)
+
+	_, _, err = parseCpuMax("a b")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,19 @@
+package stat
+
+import "github.com/qkbyte/go-zero/core/logx"
+
+// A LogWriter writes stat reports with logx.
+type LogWriter struct{}
+
+// NewLogWriter returns a LogWriter.
+func NewLogWriter() Writer {
+	return LogWriter{}
+}
+
+func (w LogWriter) Write(report *StatReport) error {
+	logx.Statf("(%s) - qps: %.1f/s, drops: %d, avg time: %.1fms, med: %.1fms, "+
+		"90th: %.1fms, 99th: %.1fms, 99.9th: %.1fms",
+		report.Name, report.ReqsPerSecond, report.Drops, report.Average, report.Median,
+		report.Top90th, report.Top99th, report.Top99p9th)
+	return nil
+}
@@ -0,0 +1,52 @@
+package stat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogWriter(t *testing.T) {
+	assert.Nil(t, NewLogWriter().Write(&StatReport{
+		Name: "foo",
+	}))
+}
+
+func TestPrometheusWriter(t *testing.T) {
+	writer := NewPrometheusWriter()
+	// register once only
+	assert.Equal(t, writer, NewPrometheusWriter())
+	assert.Nil(t, writer.Write(&StatReport{
+		Name:          "foo",
+		ReqsPerSecond: 10,
+	}))
+}
+
+func TestAddReportWriter(t *testing.T) {
+	var count int
+	AddReportWriter(writerFunc(func(report *StatReport) error {
+		count++
+		return nil
+	}))
+	defer func() {
+		writerLock.Lock()
+		writers = nil
+		writerLock.Unlock()
+	}()
+
+	writeReport(&StatReport{Name: "foo"})
+	assert.Equal(t, 1, count)
+}
+
+type writerFunc func(report *StatReport) error
+
+func (f writerFunc) Write(report *StatReport) error {
+	return f(report)
+}
+
+func TestMemUsage(t *testing.T) {
+	SetMemThreshold(1)
+	defer SetMemThreshold(0)
+	refreshMem()
+	assert.True(t, MemUsage() > 0)
+}
@@ -14,7 +14,8 @@ var (
 	logInterval  = time.Minute
 	writerLock   sync.Mutex
 	reportWriter Writer = nil
-	logEnabled          = syncx.ForAtomicBool(true)
+	writers      []Writer
+	logEnabled   = syncx.ForAtomicBool(true)
 )
 
 type (
@@ -56,6 +57,14 @@ func SetReportWriter(writer Writer) {
 	writerLock.Unlock()
 }
 
+// AddReportWriter adds a report writer, each report is written
+// to all the added writers besides the one set by SetReportWriter.
+func AddReportWriter(writer Writer) {
+	writerLock.Lock()
+	writers = append(writers, writer)
+	writerLock.Unlock()
+}
+
 // NewMetrics returns a Metrics.
 func NewMetrics(name string) *Metrics {
 	container := &metricsContainer{
@@ -207,10 +216,7 @@ func getTopDuration(tasks []Task) float32 {
 func log(report *StatReport) {
 	writeReport(report)
 	if logEnabled.True() {
-		logx.Statf("(%s) - qps: %.1f/s, drops: %d, avg time: %.1fms, med: %.1fms, "+
-			"90th: %.1fms, 99th: %.1fms, 99.9th: %.1fms",
-			report.Name, report.ReqsPerSecond, report.Drops, report.Average, report.Median,
-			report.Top90th, report.Top99th, report.Top99p9th)
+		_ = LogWriter{}.Write(report)
 	}
 }
 
@@ -223,4 +229,9 @@ func writeReport(report *StatReport) {
 			logx.Error(err)
 		}
 	}
+	for _, writer := range writers {
+		if err := writer.Write(report); err != nil {
+			logx.Error(err)
+		}
+	}
 }
@@ -0,0 +1,59 @@
+package stat
+
+import (
+	"sync"
+
+	"github.com/qkbyte/go-zero/core/metric"
+)
+
+const statNamespace = "stat"
+
+var (
+	promOnce      sync.Once
+	promQpsGauge  metric.GaugeVec
+	promDropGauge metric.GaugeVec
+	promDurGauge  metric.GaugeVec
+)
+
+// A PrometheusWriter writes stat reports as prometheus gauges.
+type PrometheusWriter struct{}
+
+// NewPrometheusWriter returns a PrometheusWriter.
+func NewPrometheusWriter() Writer {
+	promOnce.Do(func() {
+		promQpsGauge = metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: statNamespace,
+			Subsystem: "report",
+			Name:      "qps",
+			Help:      "stat report qps.",
+			Labels:    []string{"name"},
+		})
+		promDropGauge = metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: statNamespace,
+			Subsystem: "report",
+			Name:      "drops",
+			Help:      "stat report drops.",
+			Labels:    []string{"name"},
+		})
+		promDurGauge = metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: statNamespace,
+			Subsystem: "report",
+			Name:      "duration_ms",
+			Help:      "stat report durations(ms).",
+			Labels:    []string{"name", "quantile"},
+		})
+	})
+
+	return PrometheusWriter{}
+}
+
+func (w PrometheusWriter) Write(report *StatReport) error {
+	promQpsGauge.Set(float64(report.ReqsPerSecond), report.Name)
+	promDropGauge.Set(float64(report.Drops), report.Name)
+	promDurGauge.Set(float64(report.Average), report.Name, "avg")
+	promDurGauge.Set(float64(report.Median), report.Name, "med")
+	promDurGauge.Set(float64(report.Top90th), report.Name, "p90")
+	promDurGauge.Set(float64(report.Top99th), report.Name, "p99")
+	promDurGauge.Set(float64(report.Top99p9th), report.Name, "p999")
+	return nil
+}
@@ -1,6 +1,7 @@
 package stat
 
 import (
+	"fmt"
 	"runtime"
 	"sync/atomic"
 	"time"
@@ -13,22 +14,33 @@ import (
 const (
 	// 250ms and 0.95 as beta will count the average cpu load for past 5 seconds
 	cpuRefreshInterval = time.Millisecond * 250
+	memRefreshInterval = time.Second * 30
 	allRefreshInterval = time.Minute
 	// moving average beta hyperparameter
 	beta = 0.95
 )
 
-var cpuUsage int64
+var (
+	cpuUsage int64
+	memUsage int64
+	// memThreshold is the in-use heap bytes that triggers an alert,
+	// zero means alerts are disabled.
+	memThreshold uint64
+)
 
 func init() {
 	go func() {
 		cpuTicker := time.NewTicker(cpuRefreshInterval)
 		defer cpuTicker.Stop()
+		memTicker := time.NewTicker(memRefreshInterval)
+		defer memTicker.Stop()
 		allTicker := time.NewTicker(allRefreshInterval)
 		defer allTicker.Stop()
 
 		for {
 			select {
+			case <-memTicker.C:
+				threading.RunSafe(refreshMem)
 			case <-cpuTicker.C:
 				threading.RunSafe(func() {
 					curUsage := internal.RefreshCpu()
@@ -51,6 +63,29 @@ func CpuUsage() int64 {
 	return atomic.LoadInt64(&cpuUsage)
 }
 
+// MemUsage returns the in-use heap bytes of last refresh.
+func MemUsage() int64 {
+	return atomic.LoadInt64(&memUsage)
+}
+
+// SetMemThreshold sets the in-use heap bytes that triggers an alert report,
+// zero disables the alerts.
+func SetMemThreshold(bytes uint64) {
+	atomic.StoreUint64(&memThreshold, bytes)
+}
+
+func refreshMem() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	atomic.StoreInt64(&memUsage, int64(m.Alloc))
+
+	threshold := atomic.LoadUint64(&memThreshold)
+	if threshold > 0 && m.Alloc >= threshold {
+		Report(fmt.Sprintf("memory usage %.1fMi exceeds threshold %.1fMi",
+			bToMb(m.Alloc), bToMb(threshold)))
+	}
+}
+
 func bToMb(b uint64) float32 {
 	return float32(b) / 1024 / 1024
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/qkbyte/go-zero/core/errorx"
@@ -23,6 +24,12 @@ type (
 		Get(key string, val interface{}) error
 		// GetCtx gets the cache with key and fills into v.
 		GetCtx(ctx context.Context, key string, val interface{}) error
+		// GetMany gets the caches with keys in one round trip, fills the hit
+		// values into vals, and returns the indexes of the missed keys.
+		GetMany(keys []string, vals []interface{}) ([]int, error)
+		// GetManyCtx gets the caches with keys in one round trip, fills the hit
+		// values into vals, and returns the indexes of the missed keys.
+		GetManyCtx(ctx context.Context, keys []string, vals []interface{}) ([]int, error)
 		// IsNotFound checks if the given error is the defined errNotFound.
 		IsNotFound(err error) bool
 		// Set sets the cache with key and v, using c.expiry.
@@ -35,17 +42,19 @@ type (
 		SetWithExpireCtx(ctx context.Context, key string, val interface{}, expire time.Duration) error
 		// Take takes the result from cache first, if not found,
 		// query from DB and set cache using c.expiry, then return the result.
-		Take(val interface{}, key string, query func(val interface{}) error) error
+		Take(val interface{}, key string, query func(val interface{}) error, opts ...Option) error
 		// TakeCtx takes the result from cache first, if not found,
 		// query from DB and set cache using c.expiry, then return the result.
-		TakeCtx(ctx context.Context, val interface{}, key string, query func(val interface{}) error) error
+		TakeCtx(ctx context.Context, val interface{}, key string, query func(val interface{}) error,
+			opts ...Option) error
 		// TakeWithExpire takes the result from cache first, if not found,
 		// query from DB and set cache using given expire, then return the result.
-		TakeWithExpire(val interface{}, key string, query func(val interface{}, expire time.Duration) error) error
+		TakeWithExpire(val interface{}, key string, query func(val interface{},
+			expire time.Duration) error, opts ...Option) error
 		// TakeWithExpireCtx takes the result from cache first, if not found,
 		// query from DB and set cache using given expire, then return the result.
 		TakeWithExpireCtx(ctx context.Context, val interface{}, key string,
-			query func(val interface{}, expire time.Duration) error) error
+			query func(val interface{}, expire time.Duration) error, opts ...Option) error
 	}
 
 	cacheCluster struct {
@@ -132,6 +141,54 @@ func (cc cacheCluster) GetCtx(ctx context.Context, key string, val interface{})
 	return c.(Cache).GetCtx(ctx, key, val)
 }
 
+// GetMany gets the caches with keys in one round trip per node, fills the hit
+// values into vals, and returns the indexes of the missed keys.
+func (cc cacheCluster) GetMany(keys []string, vals []interface{}) ([]int, error) {
+	return cc.GetManyCtx(context.Background(), keys, vals)
+}
+
+// GetManyCtx gets the caches with keys in one round trip per node, fills the hit
+// values into vals, and returns the indexes of the missed keys.
+func (cc cacheCluster) GetManyCtx(ctx context.Context, keys []string, vals []interface{}) (
+	[]int, error) {
+	if len(keys) != len(vals) {
+		return nil, errKeyValLenMismatch
+	}
+
+	var missed []int
+	nodes := make(map[interface{}][]int)
+	for i, key := range keys {
+		c, ok := cc.dispatcher.Get(key)
+		if !ok {
+			missed = append(missed, i)
+			continue
+		}
+
+		nodes[c] = append(nodes[c], i)
+	}
+
+	for c, indexes := range nodes {
+		subKeys := make([]string, 0, len(indexes))
+		subVals := make([]interface{}, 0, len(indexes))
+		for _, index := range indexes {
+			subKeys = append(subKeys, keys[index])
+			subVals = append(subVals, vals[index])
+		}
+
+		subMissed, err := c.(Cache).GetManyCtx(ctx, subKeys, subVals)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, index := range subMissed {
+			missed = append(missed, indexes[index])
+		}
+	}
+
+	sort.Ints(missed)
+	return missed, nil
+}
+
 // IsNotFound checks if the given error is the defined errNotFound.
 func (cc cacheCluster) IsNotFound(err error) bool {
 	return errors.Is(err, cc.errNotFound)
@@ -169,34 +226,38 @@ func (cc cacheCluster) SetWithExpireCtx(ctx context.Context, key string, val int
 
 // Take takes the result from cache first, if not found,
 // query from DB and set cache using c.expiry, then return the result.
-func (cc cacheCluster) Take(val interface{}, key string, query func(val interface{}) error) error {
-	return cc.TakeCtx(context.Background(), val, key, query)
+func (cc cacheCluster) Take(val interface{}, key string, query func(val interface{}) error,
+	opts ...Option) error {
+	return cc.TakeCtx(context.Background(), val, key, query, opts...)
 }
 
 // TakeCtx takes the result from cache first, if not found,
 // query from DB and set cache using c.expiry, then return the result.
-func (cc cacheCluster) TakeCtx(ctx context.Context, val interface{}, key string, query func(val interface{}) error) error {
+func (cc cacheCluster) TakeCtx(ctx context.Context, val interface{}, key string,
+	query func(val interface{}) error, opts ...Option) error {
 	c, ok := cc.dispatcher.Get(key)
 	if !ok {
 		return cc.errNotFound
 	}
 
-	return c.(Cache).TakeCtx(ctx, val, key, query)
+	return c.(Cache).TakeCtx(ctx, val, key, query, opts...)
 }
 
 // TakeWithExpire takes the result from cache first, if not found,
 // query from DB and set cache using given expire, then return the result.
-func (cc cacheCluster) TakeWithExpire(val interface{}, key string, query func(val interface{}, expire time.Duration) error) error {
-	return cc.TakeWithExpireCtx(context.Background(), val, key, query)
+func (cc cacheCluster) TakeWithExpire(val interface{}, key string, query func(val interface{},
+	expire time.Duration) error, opts ...Option) error {
+	return cc.TakeWithExpireCtx(context.Background(), val, key, query, opts...)
 }
 
 // TakeWithExpireCtx takes the result from cache first, if not found,
 // query from DB and set cache using given expire, then return the result.
-func (cc cacheCluster) TakeWithExpireCtx(ctx context.Context, val interface{}, key string, query func(val interface{}, expire time.Duration) error) error {
+func (cc cacheCluster) TakeWithExpireCtx(ctx context.Context, val interface{}, key string,
+	query func(val interface{}, expire time.Duration) error, opts ...Option) error {
 	c, ok := cc.dispatcher.Get(key)
 	if !ok {
 		return cc.errNotFound
 	}
 
-	return c.(Cache).TakeWithExpireCtx(ctx, val, key, query)
+	return c.(Cache).TakeWithExpireCtx(ctx, val, key, query, opts...)
 }
@@ -56,6 +56,22 @@ func (mc *mockedNode) GetCtx(ctx context.Context, key string, val interface{}) e
 	return mc.errNotFound
 }
 
+func (mc *mockedNode) GetMany(keys []string, vals []interface{}) ([]int, error) {
+	return mc.GetManyCtx(context.Background(), keys, vals)
+}
+
+func (mc *mockedNode) GetManyCtx(ctx context.Context, keys []string, vals []interface{}) (
+	[]int, error) {
+	var missed []int
+	for i, key := range keys {
+		if err := mc.GetCtx(ctx, key, vals[i]); err != nil {
+			missed = append(missed, i)
+		}
+	}
+
+	return missed, nil
+}
+
 func (mc *mockedNode) IsNotFound(err error) bool {
 	return errors.Is(err, mc.errNotFound)
 }
@@ -82,11 +98,13 @@ func (mc *mockedNode) SetWithExpireCtx(ctx context.Context, key string, val inte
 	return mc.Set(key, val)
 }
 
-func (mc *mockedNode) Take(val interface{}, key string, query func(val interface{}) error) error {
-	return mc.TakeCtx(context.Background(), val, key, query)
+func (mc *mockedNode) Take(val interface{}, key string, query func(val interface{}) error,
+	opts ...Option) error {
+	return mc.TakeCtx(context.Background(), val, key, query, opts...)
 }
 
-func (mc *mockedNode) TakeCtx(ctx context.Context, val interface{}, key string, query func(val interface{}) error) error {
+func (mc *mockedNode) TakeCtx(ctx context.Context, val interface{}, key string,
+	query func(val interface{}) error, opts ...Option) error {
 	if _, ok := mc.vals[key]; ok {
 		return mc.GetCtx(ctx, key, val)
 	}
@@ -98,11 +116,13 @@ func (mc *mockedNode) TakeCtx(ctx context.Context, val interface{}, key string,
 	return mc.SetCtx(ctx, key, val)
 }
 
-func (mc *mockedNode) TakeWithExpire(val interface{}, key string, query func(val interface{}, expire time.Duration) error) error {
-	return mc.TakeWithExpireCtx(context.Background(), val, key, query)
+func (mc *mockedNode) TakeWithExpire(val interface{}, key string, query func(val interface{},
+	expire time.Duration) error, opts ...Option) error {
+	return mc.TakeWithExpireCtx(context.Background(), val, key, query, opts...)
 }
 
-func (mc *mockedNode) TakeWithExpireCtx(ctx context.Context, val interface{}, key string, query func(val interface{}, expire time.Duration) error) error {
+func (mc *mockedNode) TakeWithExpireCtx(ctx context.Context, val interface{}, key string,
+	query func(val interface{}, expire time.Duration) error, opts ...Option) error {
 	return mc.Take(val, key, func(val interface{}) error {
 		return query(val, 0)
 	})
@@ -2,10 +2,12 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"reflect"
 	"sync"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/qkbyte/go-zero/core/stat"
 	"github.com/qkbyte/go-zero/core/stores/redis"
 	"github.com/qkbyte/go-zero/core/syncx"
+	"github.com/qkbyte/go-zero/core/threading"
 )
 
 const (
@@ -22,21 +25,37 @@ const (
 	// make the expiry unstable to avoid lots of cached items expire at the same time
 	// make the unstable expiry to be [0.95, 1.05] * seconds
 	expiryDeviation = 0.05
+	// logically expired entries are kept in redis this many times longer,
+	// so stale values are still around to be served while refreshing.
+	staleFactor = 2
+	// prefix of the singleflight key for background refreshes.
+	refreshPrefix = "refresh#"
 )
 
-// indicates there is no such value associate with the key
-var errPlaceholder = errors.New("placeholder")
+var (
+	// indicates there is no such value associate with the key
+	errPlaceholder = errors.New("placeholder")
+	// indicates that the keys and vals passed to GetMany have different lengths
+	errKeyValLenMismatch = errors.New("keys and vals have different lengths")
+)
+
+// a logicalEntry wraps a cached value with its logical expiry.
+type logicalEntry struct {
+	Value    json.RawMessage `json:"value"`
+	ExpireAt int64           `json:"expireAt"`
+}
 
 type cacheNode struct {
-	rds            *redis.Redis
-	expiry         time.Duration
-	notFoundExpiry time.Duration
-	barrier        syncx.SingleFlight
-	r              *rand.Rand
-	lock           *sync.Mutex
-	unstableExpiry mathx.Unstable
-	stat           *Stat
-	errNotFound    error
+	rds                 *redis.Redis
+	expiry              time.Duration
+	notFoundExpiry      time.Duration
+	notFoundPlaceholder string
+	barrier             syncx.SingleFlight
+	r                   *rand.Rand
+	lock                *sync.Mutex
+	unstableExpiry      mathx.Unstable
+	stat                *Stat
+	errNotFound         error
 }
 
 // NewNode returns a cacheNode.
@@ -49,15 +68,16 @@ func NewNode(rds *redis.Redis, barrier syncx.SingleFlight, st *Stat,
 	errNotFound error, opts ...Option) Cache {
 	o := newOptions(opts...)
 	return cacheNode{
-		rds:            rds,
-		expiry:         o.Expiry,
-		notFoundExpiry: o.NotFoundExpiry,
-		barrier:        barrier,
-		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
-		lock:           new(sync.Mutex),
-		unstableExpiry: mathx.NewUnstable(expiryDeviation),
-		stat:           st,
-		errNotFound:    errNotFound,
+		rds:                 rds,
+		expiry:              o.Expiry,
+		notFoundExpiry:      o.NotFoundExpiry,
+		notFoundPlaceholder: o.NotFoundPlaceholder,
+		barrier:             barrier,
+		r:                   rand.New(rand.NewSource(time.Now().UnixNano())),
+		lock:                new(sync.Mutex),
+		unstableExpiry:      mathx.NewUnstable(expiryDeviation),
+		stat:                st,
+		errNotFound:         errNotFound,
 	}
 }
 
@@ -72,6 +92,7 @@ func (c cacheNode) DelCtx(ctx context.Context, keys ...string) error {
 		return nil
 	}
 
+	c.stat.IncrementDel()
 	logger := logx.WithContext(ctx)
 	if len(keys) > 1 && c.rds.Type == redis.ClusterType {
 		for _, key := range keys {
@@ -103,6 +124,51 @@ func (c cacheNode) GetCtx(ctx context.Context, key string, val interface{}) erro
 	return err
 }
 
+// GetMany gets the caches with keys in one round trip, fills the hit values
+// into vals, and returns the indexes of the missed keys.
+func (c cacheNode) GetMany(keys []string, vals []interface{}) ([]int, error) {
+	return c.GetManyCtx(context.Background(), keys, vals)
+}
+
+// GetManyCtx gets the caches with keys in one round trip, fills the hit values
+// into vals, and returns the indexes of the missed keys.
+// Keys cached with the not-found placeholder are reported as missed as well.
+func (c cacheNode) GetManyCtx(ctx context.Context, keys []string, vals []interface{}) (
+	[]int, error) {
+	if len(keys) != len(vals) {
+		return nil, errKeyValLenMismatch
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	data, err := c.rds.MgetCtx(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	var missed []int
+	for i, datum := range data {
+		c.stat.IncrementTotal()
+		c.stat.SampleKey(keys[i])
+		if len(datum) == 0 || datum == c.placeholder() {
+			c.stat.IncrementMiss()
+			missed = append(missed, i)
+			continue
+		}
+
+		if err := c.processCache(ctx, keys[i], datum, vals[i]); err != nil {
+			c.stat.IncrementMiss()
+			missed = append(missed, i)
+			continue
+		}
+
+		c.stat.IncrementHit()
+	}
+
+	return missed, nil
+}
+
 // IsNotFound checks if the given error is the defined errNotFound.
 func (c cacheNode) IsNotFound(err error) bool {
 	return errors.Is(err, c.errNotFound)
@@ -141,42 +207,78 @@ func (c cacheNode) String() string {
 
 // Take takes the result from cache first, if not found,
 // query from DB and set cache using c.expiry, then return the result.
-func (c cacheNode) Take(val interface{}, key string, query func(val interface{}) error) error {
-	return c.TakeCtx(context.Background(), val, key, query)
+func (c cacheNode) Take(val interface{}, key string, query func(val interface{}) error,
+	opts ...Option) error {
+	return c.TakeCtx(context.Background(), val, key, query, opts...)
 }
 
 // TakeCtx takes the result from cache first, if not found,
 // query from DB and set cache using c.expiry, then return the result.
 func (c cacheNode) TakeCtx(ctx context.Context, val interface{}, key string,
-	query func(val interface{}) error) error {
+	query func(val interface{}) error, opts ...Option) error {
+	o := parseOptions(opts)
+	expire := c.aroundDuration(c.takeExpiry(o))
+	if o.LogicalExpiry {
+		return c.doTakeLogical(ctx, val, key, query, expire, o.SkipNotFound)
+	}
+
 	return c.doTake(ctx, val, key, query, func(v interface{}) error {
-		return c.SetCtx(ctx, key, v)
-	})
+		return c.SetWithExpireCtx(ctx, key, v, expire)
+	}, o.SkipNotFound)
 }
 
 // TakeWithExpire takes the result from cache first, if not found,
 // query from DB and set cache using given expire, then return the result.
 func (c cacheNode) TakeWithExpire(val interface{}, key string, query func(val interface{},
-	expire time.Duration) error) error {
-	return c.TakeWithExpireCtx(context.Background(), val, key, query)
+	expire time.Duration) error, opts ...Option) error {
+	return c.TakeWithExpireCtx(context.Background(), val, key, query, opts...)
 }
 
 // TakeWithExpireCtx takes the result from cache first, if not found,
 // query from DB and set cache using given expire, then return the result.
 func (c cacheNode) TakeWithExpireCtx(ctx context.Context, val interface{}, key string,
-	query func(val interface{}, expire time.Duration) error) error {
-	expire := c.aroundDuration(c.expiry)
+	query func(val interface{}, expire time.Duration) error, opts ...Option) error {
+	o := parseOptions(opts)
+	expire := c.aroundDuration(c.takeExpiry(o))
 	return c.doTake(ctx, val, key, func(v interface{}) error {
 		return query(v, expire)
 	}, func(v interface{}) error {
 		return c.SetWithExpireCtx(ctx, key, v, expire)
-	})
+	}, o.SkipNotFound)
 }
 
 func (c cacheNode) aroundDuration(duration time.Duration) time.Duration {
 	return c.unstableExpiry.AroundDuration(duration)
 }
 
+// takeExpiry returns the expiry for a single take, the one set in opts takes
+// precedence over the expiry that the node was created with.
+func (c cacheNode) takeExpiry(o Options) time.Duration {
+	if o.Expiry > 0 {
+		return o.Expiry
+	}
+
+	return c.expiry
+}
+
+// placeholder returns the sentinel value that marks cached "not found" results.
+func (c cacheNode) placeholder() string {
+	if len(c.notFoundPlaceholder) > 0 {
+		return c.notFoundPlaceholder
+	}
+
+	return notFoundPlaceholder
+}
+
+func parseOptions(opts []Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
 func (c cacheNode) asyncRetryDelCache(keys ...string) {
 	AddCleanTask(func() error {
 		_, err := c.rds.Del(keys...)
@@ -186,6 +288,7 @@ func (c cacheNode) asyncRetryDelCache(keys ...string) {
 
 func (c cacheNode) doGetCache(ctx context.Context, key string, v interface{}) error {
 	c.stat.IncrementTotal()
+	c.stat.SampleKey(key)
 	data, err := c.rds.GetCtx(ctx, key)
 	if err != nil {
 		c.stat.IncrementMiss()
@@ -198,7 +301,7 @@ func (c cacheNode) doGetCache(ctx context.Context, key string, v interface{}) er
 	}
 
 	c.stat.IncrementHit()
-	if data == notFoundPlaceholder {
+	if data == c.placeholder() {
 		return errPlaceholder
 	}
 
@@ -206,7 +309,8 @@ func (c cacheNode) doGetCache(ctx context.Context, key string, v interface{}) er
 }
 
 func (c cacheNode) doTake(ctx context.Context, v interface{}, key string,
-	query func(v interface{}) error, cacheVal func(v interface{}) error) error {
+	query func(v interface{}) error, cacheVal func(v interface{}) error,
+	skipNotFound bool) error {
 	logger := logx.WithContext(ctx)
 	val, fresh, err := c.barrier.DoEx(key, func() (interface{}, error) {
 		if err := c.doGetCache(ctx, key, v); err != nil {
@@ -220,8 +324,10 @@ func (c cacheNode) doTake(ctx context.Context, v interface{}, key string,
 			}
 
 			if err = query(v); err == c.errNotFound {
-				if err = c.setCacheWithNotFound(ctx, key); err != nil {
-					logger.Error(err)
+				if !skipNotFound {
+					if err = c.setCacheWithNotFound(ctx, key); err != nil {
+						logger.Error(err)
+					}
 				}
 
 				return nil, c.errNotFound
@@ -255,6 +361,137 @@ func (c cacheNode) doTake(ctx context.Context, v interface{}, key string,
 	return jsonx.Unmarshal(val.([]byte), v)
 }
 
+// doTakeLogical is the logical expiration counterpart of doTake.
+// Misses query the db behind the singleflight barrier as usual, but hits that
+// passed their logical expiry are served stale, while a single goroutine
+// refreshes the value in background.
+func (c cacheNode) doTakeLogical(ctx context.Context, v interface{}, key string,
+	query func(v interface{}) error, expire time.Duration, skipNotFound bool) error {
+	logger := logx.WithContext(ctx)
+	val, fresh, err := c.barrier.DoEx(key, func() (interface{}, error) {
+		stale, err := c.getLogical(ctx, key, v)
+		switch {
+		case err == errPlaceholder:
+			return nil, c.errNotFound
+		case err == c.errNotFound:
+			if err = query(v); err == c.errNotFound {
+				if !skipNotFound {
+					if err = c.setCacheWithNotFound(ctx, key); err != nil {
+						logger.Error(err)
+					}
+				}
+
+				return nil, c.errNotFound
+			} else if err != nil {
+				c.stat.IncrementDbFails()
+				return nil, err
+			}
+
+			if err = c.setLogical(ctx, key, v, expire); err != nil {
+				logger.Error(err)
+			}
+		case err != nil:
+			return nil, err
+		default:
+			if stale {
+				c.refreshAsync(key, v, query, expire)
+			}
+		}
+
+		return jsonx.Marshal(v)
+	})
+	if err != nil {
+		return err
+	}
+	if fresh {
+		return nil
+	}
+
+	// got the result from previous ongoing query.
+	c.stat.IncrementTotal()
+	c.stat.IncrementHit()
+
+	return jsonx.Unmarshal(val.([]byte), v)
+}
+
+// getLogical reads a logical entry into v, and reports whether it's stale.
+func (c cacheNode) getLogical(ctx context.Context, key string, v interface{}) (bool, error) {
+	c.stat.IncrementTotal()
+	c.stat.SampleKey(key)
+	data, err := c.rds.GetCtx(ctx, key)
+	if err != nil {
+		c.stat.IncrementMiss()
+		return false, err
+	}
+
+	if len(data) == 0 {
+		c.stat.IncrementMiss()
+		return false, c.errNotFound
+	}
+
+	c.stat.IncrementHit()
+	if data == c.placeholder() {
+		return false, errPlaceholder
+	}
+
+	var entry logicalEntry
+	if jsonx.Unmarshal([]byte(data), &entry) != nil ||
+		jsonx.Unmarshal(entry.Value, v) != nil {
+		return false, c.processCache(ctx, key, data, v)
+	}
+
+	stale := time.Now().UnixNano() >= entry.ExpireAt
+	if stale {
+		c.stat.IncrementExpire()
+	}
+
+	return stale, nil
+}
+
+// refreshAsync reloads the value of key in background,
+// sharing one in-flight refresh between concurrent stale hits.
+func (c cacheNode) refreshAsync(key string, v interface{},
+	query func(v interface{}) error, expire time.Duration) {
+	threading.GoSafe(func() {
+		fresh := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+		if _, err := c.barrier.Do(refreshPrefix+key, func() (interface{}, error) {
+			if err := query(fresh); err != nil {
+				return nil, err
+			}
+
+			return nil, c.setLogical(context.Background(), key, fresh, expire)
+		}); err == c.errNotFound {
+			if err := c.setCacheWithNotFound(context.Background(), key); err != nil {
+				logx.Error(err)
+			}
+		} else if err != nil {
+			c.stat.IncrementDbFails()
+			logx.Errorf("failed to refresh cache with key: %q, error: %v", key, err)
+		}
+	})
+}
+
+// setLogical caches v with the logical expiry in the payload,
+// the redis expiration is stretched to keep the stale value around.
+func (c cacheNode) setLogical(ctx context.Context, key string, v interface{},
+	expire time.Duration) error {
+	data, err := jsonx.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	payload, err := jsonx.Marshal(logicalEntry{
+		Value:    data,
+		ExpireAt: time.Now().Add(expire).UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.rds.SetexCtx(ctx, key, string(payload),
+		int(math.Ceil((expire * staleFactor).Seconds())))
+}
+
 func (c cacheNode) processCache(ctx context.Context, key, data string, v interface{}) error {
 	err := jsonx.Unmarshal([]byte(data), v)
 	if err == nil {
@@ -277,5 +514,5 @@ func (c cacheNode) processCache(ctx context.Context, key, data string, v interfa
 
 func (c cacheNode) setCacheWithNotFound(ctx context.Context, key string) error {
 	seconds := int(math.Ceil(c.aroundDuration(c.notFoundExpiry).Seconds()))
-	return c.rds.SetexCtx(ctx, key, notFoundPlaceholder, seconds)
+	return c.rds.SetexCtx(ctx, key, c.placeholder(), seconds)
 }
@@ -125,6 +125,25 @@ func TestCacheNode_Take(t *testing.T) {
 	assert.Equal(t, `"value"`, val)
 }
 
+func TestCacheNode_TakeWithExpiryOption(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	cn := NewNode(store, syncx.NewSingleFlight(), NewStat("any"), errTestNotFound,
+		WithExpiry(time.Hour), WithNotFoundExpiry(time.Second))
+	var str string
+	err = cn.Take(&str, "any", func(v interface{}) error {
+		*v.(*string) = "value"
+		return nil
+	}, WithExpiry(time.Second*10))
+	assert.Nil(t, err)
+	assert.Equal(t, "value", str)
+	ttl, err := store.Ttl("any")
+	assert.Nil(t, err)
+	assert.True(t, ttl > 0 && ttl <= 11)
+}
+
 func TestCacheNode_TakeNotFound(t *testing.T) {
 	store, clean, err := redistest.CreateRedis()
 	assert.Nil(t, err)
@@ -233,3 +252,136 @@ func TestCacheValueWithBigInt(t *testing.T) {
 	assert.Nil(t, cn.Get(key, &val))
 	assert.Equal(t, strconv.FormatInt(value, 10), fmt.Sprintf("%v", val))
 }
+
+func TestCacheNode_TakeWithLogicalExpiry(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	cn := cacheNode{
+		rds:            store,
+		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
+		barrier:        syncx.NewSingleFlight(),
+		lock:           new(sync.Mutex),
+		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		stat:           NewStat("any"),
+		errNotFound:    errTestNotFound,
+	}
+
+	var str string
+	err = cn.Take(&str, "any", func(v interface{}) error {
+		*v.(*string) = "first"
+		return nil
+	}, WithExpiry(time.Millisecond*50), WithLogicalExpiry())
+	assert.Nil(t, err)
+	assert.Equal(t, "first", str)
+
+	// wait past the logical expiry, the stale value is still served.
+	time.Sleep(time.Millisecond * 100)
+	refreshed := make(chan struct{})
+	str = ""
+	err = cn.Take(&str, "any", func(v interface{}) error {
+		defer close(refreshed)
+		*v.(*string) = "second"
+		return nil
+	}, WithExpiry(time.Millisecond*50), WithLogicalExpiry())
+	assert.Nil(t, err)
+	assert.Equal(t, "first", str)
+
+	// the background refresh stored the new value.
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("refresh didn't happen")
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		str = ""
+		err = cn.Take(&str, "any", func(v interface{}) error {
+			*v.(*string) = "second"
+			return nil
+		}, WithExpiry(time.Millisecond*50), WithLogicalExpiry())
+		assert.Nil(t, err)
+		if str == "second" {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Equal(t, "second", str)
+}
+
+func TestCacheNode_TakeWithLogicalExpiryNotFound(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	cn := cacheNode{
+		rds:            store,
+		r:              rand.New(rand.NewSource(time.Now().UnixNano())),
+		barrier:        syncx.NewSingleFlight(),
+		lock:           new(sync.Mutex),
+		unstableExpiry: mathx.NewUnstable(expiryDeviation),
+		stat:           NewStat("any"),
+		errNotFound:    errTestNotFound,
+		notFoundExpiry: defaultNotFoundExpiry,
+	}
+
+	var str string
+	err = cn.Take(&str, "missing", func(v interface{}) error {
+		return errTestNotFound
+	}, WithLogicalExpiry())
+	assert.Equal(t, errTestNotFound, err)
+
+	// the placeholder protects the db from repeated queries.
+	err = cn.Take(&str, "missing", func(v interface{}) error {
+		return errors.New("should not be queried")
+	}, WithLogicalExpiry())
+	assert.Equal(t, errTestNotFound, err)
+}
+
+func TestCacheNode_CustomPlaceholder(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	c := NewNode(store, syncx.NewSingleFlight(), NewStat("any"), errTestNotFound,
+		WithNotFoundPlaceholder("-"), WithNotFoundExpiry(time.Minute))
+	var str string
+	err = c.Take(&str, "missing", func(v interface{}) error {
+		return errTestNotFound
+	})
+	assert.Equal(t, errTestNotFound, err)
+
+	val, err := store.Get("missing")
+	assert.Nil(t, err)
+	assert.Equal(t, "-", val)
+
+	// placeholder hit, the db is not queried.
+	err = c.Take(&str, "missing", func(v interface{}) error {
+		return errors.New("should not be queried")
+	})
+	assert.Equal(t, errTestNotFound, err)
+}
+
+func TestCacheNode_WithoutNegativeCache(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	c := NewNode(store, syncx.NewSingleFlight(), NewStat("any"), errTestNotFound)
+	var queried int
+	var str string
+	for i := 0; i < 2; i++ {
+		err = c.Take(&str, "missing", func(v interface{}) error {
+			queried++
+			return errTestNotFound
+		}, WithoutNegativeCache())
+		assert.Equal(t, errTestNotFound, err)
+	}
+
+	// no placeholder cached, the db is queried each time.
+	assert.Equal(t, 2, queried)
+	ok, err := store.Exists("missing")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
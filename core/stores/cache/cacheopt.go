@@ -10,8 +10,11 @@ const (
 type (
 	// An Options is used to store the cache options.
 	Options struct {
-		Expiry         time.Duration
-		NotFoundExpiry time.Duration
+		Expiry              time.Duration
+		NotFoundExpiry      time.Duration
+		NotFoundPlaceholder string
+		LogicalExpiry       bool
+		SkipNotFound        bool
 	}
 
 	// Option defines the method to customize an Options.
@@ -41,9 +44,36 @@ func WithExpiry(expiry time.Duration) Option {
 	}
 }
 
+// WithLogicalExpiry returns a func to customize a Options to expire entries logically.
+// Entries carry the expiry in the payload and stay in redis longer, expired hits are
+// served stale while a single background goroutine refreshes the value.
+// Only honored by Take and TakeCtx.
+func WithLogicalExpiry() Option {
+	return func(o *Options) {
+		o.LogicalExpiry = true
+	}
+}
+
 // WithNotFoundExpiry returns a func to customize a Options with given not found expiry.
 func WithNotFoundExpiry(expiry time.Duration) Option {
 	return func(o *Options) {
 		o.NotFoundExpiry = expiry
 	}
 }
+
+// WithNotFoundPlaceholder returns a func to customize a Options with given sentinel
+// value that marks cached "not found" results.
+func WithNotFoundPlaceholder(placeholder string) Option {
+	return func(o *Options) {
+		o.NotFoundPlaceholder = placeholder
+	}
+}
+
+// WithoutNegativeCache returns a func to customize a Options to skip caching
+// "not found" results, db misses are queried again on the next take.
+// Only honored by the Take methods.
+func WithoutNegativeCache() Option {
+	return func(o *Options) {
+		o.SkipNotFound = true
+	}
+}
@@ -1,13 +1,19 @@
 package cache
 
 import (
+	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/qkbyte/go-zero/core/logx"
 )
 
-const statInterval = time.Minute
+const (
+	statInterval = time.Minute
+	// number of hottest keys reported per interval.
+	hotKeyCount = 10
+)
 
 // A Stat is used to stat the cache.
 type Stat struct {
@@ -18,12 +24,17 @@ type Stat struct {
 	Hit     uint64
 	Miss    uint64
 	DbFails uint64
+
+	// key-level sampling to spot the hot keys.
+	lock sync.Mutex
+	keys map[string]uint64
 }
 
 // NewStat returns a Stat.
 func NewStat(name string) *Stat {
 	ret := &Stat{
 		name: name,
+		keys: make(map[string]uint64),
 	}
 	go ret.statLoop()
 
@@ -38,16 +49,36 @@ func (s *Stat) IncrementTotal() {
 // IncrementHit increments the hit count.
 func (s *Stat) IncrementHit() {
 	atomic.AddUint64(&s.Hit, 1)
+	metricHit.Inc(s.name)
 }
 
 // IncrementMiss increments the miss count.
 func (s *Stat) IncrementMiss() {
 	atomic.AddUint64(&s.Miss, 1)
+	metricMiss.Inc(s.name)
 }
 
 // IncrementDbFails increments the db fail count.
 func (s *Stat) IncrementDbFails() {
 	atomic.AddUint64(&s.DbFails, 1)
+	metricDbFails.Inc(s.name)
+}
+
+// IncrementDel increments the delete count.
+func (s *Stat) IncrementDel() {
+	metricDel.Inc(s.name)
+}
+
+// IncrementExpire increments the logically expired count.
+func (s *Stat) IncrementExpire() {
+	metricExpire.Inc(s.name)
+}
+
+// SampleKey counts an access of key, the hottest keys are reported per interval.
+func (s *Stat) SampleKey(key string) {
+	s.lock.Lock()
+	s.keys[key]++
+	s.lock.Unlock()
 }
 
 func (s *Stat) statLoop() {
@@ -66,5 +97,37 @@ func (s *Stat) statLoop() {
 		dbf := atomic.SwapUint64(&s.DbFails, 0)
 		logx.Statf("dbcache(%s) - qpm: %d, hit_ratio: %.1f%%, hit: %d, miss: %d, db_fails: %d",
 			s.name, total, percent, hit, miss, dbf)
+		s.reportHotKeys()
+	}
+}
+
+func (s *Stat) reportHotKeys() {
+	s.lock.Lock()
+	keys := s.keys
+	s.keys = make(map[string]uint64)
+	s.lock.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+
+	type keyHits struct {
+		key  string
+		hits uint64
+	}
+	hot := make([]keyHits, 0, len(keys))
+	for key, hits := range keys {
+		hot = append(hot, keyHits{key: key, hits: hits})
+	}
+	sort.Slice(hot, func(i, j int) bool {
+		return hot[i].hits > hot[j].hits
+	})
+	if len(hot) > hotKeyCount {
+		hot = hot[:hotKeyCount]
+	}
+
+	for _, item := range hot {
+		metricHotKey.Set(float64(item.hits), s.name, item.key)
+		logx.Statf("dbcache(%s) - hot key: %s, hits: %d", s.name, item.key, item.hits)
 	}
 }
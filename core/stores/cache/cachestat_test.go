@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatSampleKey(t *testing.T) {
+	st := NewStat("any")
+	for i := 0; i < hotKeyCount*2; i++ {
+		key := "key:" + strconv.Itoa(i)
+		for j := 0; j <= i; j++ {
+			st.SampleKey(key)
+		}
+	}
+
+	st.lock.Lock()
+	assert.Equal(t, hotKeyCount*2, len(st.keys))
+	assert.Equal(t, uint64(hotKeyCount*2), st.keys["key:"+strconv.Itoa(hotKeyCount*2-1)])
+	st.lock.Unlock()
+
+	// reporting drains the samples.
+	st.reportHotKeys()
+	st.lock.Lock()
+	assert.Equal(t, 0, len(st.keys))
+	st.lock.Unlock()
+}
@@ -0,0 +1,249 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/collection"
+	"github.com/qkbyte/go-zero/core/jsonx"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/stores/redis"
+	"github.com/qkbyte/go-zero/core/stringx"
+)
+
+const (
+	invalidationChannel = "cache#invalidations"
+	instanceIdLen       = 8
+)
+
+// A localCache wraps a Cache with an in-process LRU front layer,
+// so hot-key reads are served without a redis round trip.
+type localCache struct {
+	Cache
+	rds   *redis.Redis
+	inner *collection.Cache
+	id    string
+}
+
+// NewLocalCache wraps remote with an in-process LRU layer of up to limit entries,
+// local copies expire after expire. Invalidations are broadcast over redis pub/sub,
+// so writes on one process evict the local copies on the others.
+func NewLocalCache(remote Cache, rds *redis.Redis, expire time.Duration, limit int) (
+	Cache, error) {
+	inner, err := collection.NewCache(expire, collection.WithLimit(limit),
+		collection.WithName("local-cache"))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &localCache{
+		Cache: remote,
+		rds:   rds,
+		inner: inner,
+		id:    stringx.Randn(instanceIdLen),
+	}
+	if _, err := rds.Subscribe(c.onInvalidation, invalidationChannel); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Del deletes cached values with keys.
+func (c *localCache) Del(keys ...string) error {
+	return c.DelCtx(context.Background(), keys...)
+}
+
+// DelCtx deletes cached values with keys.
+func (c *localCache) DelCtx(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		c.inner.Del(key)
+	}
+	err := c.Cache.DelCtx(ctx, keys...)
+	c.broadcast(ctx, keys...)
+
+	return err
+}
+
+// Get gets the cache with key and fills into val.
+func (c *localCache) Get(key string, val interface{}) error {
+	return c.GetCtx(context.Background(), key, val)
+}
+
+// GetCtx gets the cache with key and fills into val.
+func (c *localCache) GetCtx(ctx context.Context, key string, val interface{}) error {
+	if c.loadLocal(key, val) {
+		return nil
+	}
+
+	if err := c.Cache.GetCtx(ctx, key, val); err != nil {
+		return err
+	}
+
+	c.storeLocal(key, val)
+	return nil
+}
+
+// GetMany gets the caches with keys, fills the hit values into vals,
+// and returns the indexes of the missed keys.
+func (c *localCache) GetMany(keys []string, vals []interface{}) ([]int, error) {
+	return c.GetManyCtx(context.Background(), keys, vals)
+}
+
+// GetManyCtx gets the caches with keys, fills the hit values into vals,
+// and returns the indexes of the missed keys.
+func (c *localCache) GetManyCtx(ctx context.Context, keys []string, vals []interface{}) (
+	[]int, error) {
+	var remoteKeys []string
+	var remoteVals []interface{}
+	var remoteIndexes []int
+	for i, key := range keys {
+		if c.loadLocal(key, vals[i]) {
+			continue
+		}
+
+		remoteKeys = append(remoteKeys, key)
+		remoteVals = append(remoteVals, vals[i])
+		remoteIndexes = append(remoteIndexes, i)
+	}
+	if len(remoteKeys) == 0 {
+		return nil, nil
+	}
+
+	missed, err := c.Cache.GetManyCtx(ctx, remoteKeys, remoteVals)
+	if err != nil {
+		return nil, err
+	}
+
+	missedSet := make(map[int]struct{}, len(missed))
+	for _, i := range missed {
+		missedSet[i] = struct{}{}
+	}
+
+	var missedIndexes []int
+	for j, i := range remoteIndexes {
+		if _, ok := missedSet[j]; ok {
+			missedIndexes = append(missedIndexes, i)
+		} else {
+			c.storeLocal(keys[i], vals[i])
+		}
+	}
+
+	return missedIndexes, nil
+}
+
+// Set sets the cache with key and val, using c.expiry.
+func (c *localCache) Set(key string, val interface{}) error {
+	return c.SetCtx(context.Background(), key, val)
+}
+
+// SetCtx sets the cache with key and val, using c.expiry.
+func (c *localCache) SetCtx(ctx context.Context, key string, val interface{}) error {
+	if err := c.Cache.SetCtx(ctx, key, val); err != nil {
+		return err
+	}
+
+	c.storeLocal(key, val)
+	c.broadcast(ctx, key)
+	return nil
+}
+
+// SetWithExpire sets the cache with key and val, using given expire.
+func (c *localCache) SetWithExpire(key string, val interface{}, expire time.Duration) error {
+	return c.SetWithExpireCtx(context.Background(), key, val, expire)
+}
+
+// SetWithExpireCtx sets the cache with key and val, using given expire.
+func (c *localCache) SetWithExpireCtx(ctx context.Context, key string, val interface{},
+	expire time.Duration) error {
+	if err := c.Cache.SetWithExpireCtx(ctx, key, val, expire); err != nil {
+		return err
+	}
+
+	c.storeLocal(key, val)
+	c.broadcast(ctx, key)
+	return nil
+}
+
+// Take takes the result from cache first, if not found,
+// query from DB and set cache using c.expiry, then return the result.
+func (c *localCache) Take(val interface{}, key string, query func(val interface{}) error,
+	opts ...Option) error {
+	return c.TakeCtx(context.Background(), val, key, query, opts...)
+}
+
+// TakeCtx takes the result from cache first, if not found,
+// query from DB and set cache using c.expiry, then return the result.
+func (c *localCache) TakeCtx(ctx context.Context, val interface{}, key string,
+	query func(val interface{}) error, opts ...Option) error {
+	if c.loadLocal(key, val) {
+		return nil
+	}
+
+	if err := c.Cache.TakeCtx(ctx, val, key, query, opts...); err != nil {
+		return err
+	}
+
+	c.storeLocal(key, val)
+	return nil
+}
+
+// TakeWithExpire takes the result from cache first, if not found,
+// query from DB and set cache using given expire, then return the result.
+func (c *localCache) TakeWithExpire(val interface{}, key string,
+	query func(val interface{}, expire time.Duration) error, opts ...Option) error {
+	return c.TakeWithExpireCtx(context.Background(), val, key, query, opts...)
+}
+
+// TakeWithExpireCtx takes the result from cache first, if not found,
+// query from DB and set cache using given expire, then return the result.
+func (c *localCache) TakeWithExpireCtx(ctx context.Context, val interface{}, key string,
+	query func(val interface{}, expire time.Duration) error, opts ...Option) error {
+	if c.loadLocal(key, val) {
+		return nil
+	}
+
+	if err := c.Cache.TakeWithExpireCtx(ctx, val, key, query, opts...); err != nil {
+		return err
+	}
+
+	c.storeLocal(key, val)
+	return nil
+}
+
+func (c *localCache) broadcast(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if _, err := c.rds.PublishCtx(ctx, invalidationChannel, c.id+"|"+key); err != nil {
+			logx.Errorf("failed to broadcast invalidation of key %s: %s", key, err)
+		}
+	}
+}
+
+func (c *localCache) loadLocal(key string, val interface{}) bool {
+	data, ok := c.inner.Get(key)
+	if !ok {
+		return false
+	}
+
+	return jsonx.Unmarshal(data.([]byte), val) == nil
+}
+
+func (c *localCache) onInvalidation(msg *redis.Message) {
+	sender, key, ok := strings.Cut(msg.Payload, "|")
+	if !ok || sender == c.id {
+		// malformed or our own broadcast, the local copy is already fresh.
+		return
+	}
+
+	c.inner.Del(key)
+}
+
+func (c *localCache) storeLocal(key string, val interface{}) {
+	data, err := jsonx.Marshal(val)
+	if err != nil {
+		return
+	}
+
+	c.inner.Set(key, data)
+}
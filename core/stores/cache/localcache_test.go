@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/stores/redis/redistest"
+	"github.com/qkbyte/go-zero/core/syncx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalCache(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	remote := NewNode(store, syncx.NewSingleFlight(), NewStat("local"), errTestNotFound)
+	c, err := NewLocalCache(remote, store, time.Minute, 100)
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.Set("key", "value"))
+	var val string
+	assert.Nil(t, c.Get("key", &val))
+	assert.Equal(t, "value", val)
+
+	// remove the remote copy, the local layer still serves the read.
+	assert.Nil(t, remote.Del("key"))
+	val = ""
+	assert.Nil(t, c.Get("key", &val))
+	assert.Equal(t, "value", val)
+
+	// deleting through the wrapper evicts both layers.
+	assert.Nil(t, c.Set("key", "value"))
+	assert.Nil(t, c.Del("key"))
+	assert.True(t, c.IsNotFound(c.Get("key", &val)))
+
+	var queried int
+	assert.Nil(t, c.Take(&val, "take", func(v interface{}) error {
+		queried++
+		*v.(*string) = "taken"
+		return nil
+	}))
+	assert.Equal(t, "taken", val)
+	// second take is a local hit, the query doesn't run again.
+	val = ""
+	assert.Nil(t, c.Take(&val, "take", func(v interface{}) error {
+		queried++
+		return nil
+	}))
+	assert.Equal(t, "taken", val)
+	assert.Equal(t, 1, queried)
+}
+
+func TestLocalCacheGetMany(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	remote := NewNode(store, syncx.NewSingleFlight(), NewStat("local"), errTestNotFound)
+	c, err := NewLocalCache(remote, store, time.Minute, 100)
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.Set("many1", "1"))
+	assert.Nil(t, remote.Set("many2", "2"))
+
+	var v1, v2, v3 string
+	missed, err := c.GetMany([]string{"many1", "many2", "many3"},
+		[]interface{}{&v1, &v2, &v3})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2}, missed)
+	assert.Equal(t, "1", v1)
+	assert.Equal(t, "2", v2)
+
+	// all local now.
+	v1, v2 = "", ""
+	missed, err = c.GetMany([]string{"many1", "many2"}, []interface{}{&v1, &v2})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(missed))
+	assert.Equal(t, "1", v1)
+	assert.Equal(t, "2", v2)
+}
@@ -0,0 +1,50 @@
+package cache
+
+import "github.com/qkbyte/go-zero/core/metric"
+
+const namespace = "cache"
+
+var (
+	metricHit = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "hit_total",
+		Help:      "cache hit count.",
+		Labels:    []string{"name"},
+	})
+	metricMiss = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "miss_total",
+		Help:      "cache miss count.",
+		Labels:    []string{"name"},
+	})
+	metricExpire = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "expire_total",
+		Help:      "logically expired hits served stale.",
+		Labels:    []string{"name"},
+	})
+	metricDel = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "del_total",
+		Help:      "cache delete count.",
+		Labels:    []string{"name"},
+	})
+	metricDbFails = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "db_fail_total",
+		Help:      "cache-backing db failure count.",
+		Labels:    []string{"name"},
+	})
+	metricHotKey = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "hot_key_hits",
+		Help:      "accesses of the hottest keys in the last stat interval.",
+		Labels:    []string{"name", "key"},
+	})
+)
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Get gets the cache with key into a value of type T,
+// which saves the pointer-through-interface{} out-params at call sites.
+func Get[T any](ctx context.Context, c Cache, key string) (T, error) {
+	var v T
+	err := c.GetCtx(ctx, key, &v)
+	return v, err
+}
+
+// Set sets the cache with key to the given value of type T, using the cache expiry.
+func Set[T any](ctx context.Context, c Cache, key string, val T) error {
+	return c.SetCtx(ctx, key, val)
+}
+
+// SetWithExpire sets the cache with key to the given value of type T, using given expire.
+func SetWithExpire[T any](ctx context.Context, c Cache, key string, val T,
+	expire time.Duration) error {
+	return c.SetWithExpireCtx(ctx, key, val, expire)
+}
+
+// Take takes the result of type T from cache first, if not found,
+// fetch is called and the result is cached, mismatched types between
+// set and take sites are caught at compile time.
+func Take[T any](ctx context.Context, c Cache, key string,
+	fetch func() (T, error), opts ...Option) (T, error) {
+	var v T
+	err := c.TakeCtx(ctx, &v, key, func(val interface{}) error {
+		ret, err := fetch()
+		if err != nil {
+			return err
+		}
+
+		*val.(*T) = ret
+		return nil
+	}, opts...)
+
+	return v, err
+}
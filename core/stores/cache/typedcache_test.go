@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/qkbyte/go-zero/core/stores/redis/redistest"
+	"github.com/qkbyte/go-zero/core/syncx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedCache(t *testing.T) {
+	store, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	type user struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	c := NewNode(store, syncx.NewSingleFlight(), NewStat("typed"), errTestNotFound)
+	ctx := context.Background()
+
+	var queried int
+	val, err := Take(ctx, c, "user:1", func() (user, error) {
+		queried++
+		return user{Id: 1, Name: "alice"}, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, user{Id: 1, Name: "alice"}, val)
+
+	// second take is a cache hit.
+	val, err = Take(ctx, c, "user:1", func() (user, error) {
+		queried++
+		return user{}, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, user{Id: 1, Name: "alice"}, val)
+	assert.Equal(t, 1, queried)
+
+	assert.Nil(t, Set(ctx, c, "user:2", user{Id: 2, Name: "bob"}))
+	got, err := Get[user](ctx, c, "user:2")
+	assert.Nil(t, err)
+	assert.Equal(t, user{Id: 2, Name: "bob"}, got)
+
+	_, err = Get[user](ctx, c, "user:404")
+	assert.True(t, c.IsNotFound(err))
+
+	_, err = Take(ctx, c, "user:404", func() (user, error) {
+		return user{}, errTestNotFound
+	})
+	assert.Equal(t, errTestNotFound, err)
+}
@@ -0,0 +1,166 @@
+package clickhouse
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	ch "github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/qkbyte/go-zero/core/executors"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/timex"
+)
+
+const (
+	flushInterval = time.Second
+	// clickhouse favors large batches, way beyond what mysql takes.
+	maxBatchRows = 10000
+)
+
+type (
+	// Conn is an alias of the native clickhouse connection,
+	// required for columnar batch inserts.
+	Conn = driver.Conn
+
+	// BatchResultHandler defines the method of batch result handlers,
+	// it's called once per flushed batch with the number of rows sent.
+	BatchResultHandler func(rows int, err error)
+
+	// BatchOption defines the method to customize a BatchInserter.
+	BatchOption func(in *chInserter)
+
+	// A BatchInserter buffers rows and flushes them asynchronously as single
+	// columnar inserts, either when maxBatchRows rows are pending or on the
+	// flush interval, whichever comes first. Pending rows are flushed on
+	// graceful shutdown through the proc shutdown listener registered by the
+	// underlying PeriodicalExecutor.
+	BatchInserter struct {
+		executor *executors.PeriodicalExecutor
+		inserter *chInserter
+	}
+
+	batchRow []interface{}
+)
+
+// NewConn connects to clickhouse with the native protocol,
+// for use with NewBatchInserter.
+func NewConn(opts *ch.Options) (Conn, error) {
+	return ch.Open(opts)
+}
+
+// NewBatchInserter returns a BatchInserter, stmt is a plain insert statement
+// without values, like "INSERT INTO events (ts, name, value)".
+func NewBatchInserter(conn Conn, stmt string, opts ...BatchOption) *BatchInserter {
+	inserter := &chInserter{
+		conn:    conn,
+		stmt:    stmt,
+		table:   tableFromStmt(stmt),
+		maxRows: maxBatchRows,
+	}
+	for _, opt := range opts {
+		opt(inserter)
+	}
+
+	return &BatchInserter{
+		executor: executors.NewPeriodicalExecutor(flushInterval, inserter),
+		inserter: inserter,
+	}
+}
+
+// WithMaxBatchRows customizes a BatchInserter with given max rows per batch.
+func WithMaxBatchRows(rows int) BatchOption {
+	return func(in *chInserter) {
+		in.maxRows = rows
+	}
+}
+
+// Flush flushes all the pending rows.
+func (bi *BatchInserter) Flush() {
+	bi.executor.Flush()
+}
+
+// Insert appends a row with given args, flushed asynchronously.
+func (bi *BatchInserter) Insert(args ...interface{}) {
+	bi.executor.Add(batchRow(args))
+}
+
+// SetResultHandler sets the given handler.
+func (bi *BatchInserter) SetResultHandler(handler BatchResultHandler) {
+	bi.executor.Sync(func() {
+		bi.inserter.resultHandler = handler
+	})
+}
+
+type chInserter struct {
+	conn          Conn
+	stmt          string
+	table         string
+	rows          []batchRow
+	maxRows       int
+	resultHandler BatchResultHandler
+}
+
+func (in *chInserter) AddTask(task interface{}) bool {
+	in.rows = append(in.rows, task.(batchRow))
+	return len(in.rows) >= in.maxRows
+}
+
+func (in *chInserter) Execute(bulk interface{}) {
+	rows := bulk.([]batchRow)
+	if len(rows) == 0 {
+		return
+	}
+
+	starTime := timex.Now()
+	err := in.send(rows)
+	metricFlushDur.Observe(int64(timex.Since(starTime)/time.Millisecond), in.table)
+	if err != nil {
+		metricFlushFails.Inc(in.table)
+	} else {
+		metricInsertRows.Add(float64(len(rows)), in.table)
+	}
+
+	if in.resultHandler != nil {
+		in.resultHandler(len(rows), err)
+	} else if err != nil {
+		logx.Errorf("clickhouse batch insert failed, stmt: %s, rows: %d, error: %s",
+			in.stmt, len(rows), err)
+	}
+}
+
+func (in *chInserter) RemoveAll() interface{} {
+	rows := in.rows
+	in.rows = nil
+	return rows
+}
+
+func (in *chInserter) send(rows []batchRow) error {
+	batch, err := in.conn.PrepareBatch(context.Background(), in.stmt)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := batch.Append(row...); err != nil {
+			if abortErr := batch.Abort(); abortErr != nil {
+				logx.Errorf("clickhouse batch abort failed, error: %s", abortErr)
+			}
+			return err
+		}
+	}
+
+	return batch.Send()
+}
+
+// tableFromStmt extracts the table name to label the metrics with.
+func tableFromStmt(stmt string) string {
+	fields := strings.Fields(stmt)
+	for i := 0; i < len(fields)-1; i++ {
+		if strings.EqualFold(fields[i], "into") {
+			return fields[i+1]
+		}
+	}
+
+	return "unknown"
+}
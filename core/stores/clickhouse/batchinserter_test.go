@@ -0,0 +1,96 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBatch struct {
+	driver.Batch
+	rows    [][]interface{}
+	sent    bool
+	sendErr error
+}
+
+func (b *mockBatch) Append(v ...interface{}) error {
+	b.rows = append(b.rows, v)
+	return nil
+}
+
+func (b *mockBatch) Send() error {
+	b.sent = true
+	return b.sendErr
+}
+
+type mockConn struct {
+	driver.Conn
+	lock    sync.Mutex
+	batches []*mockBatch
+	sendErr error
+}
+
+func (c *mockConn) PrepareBatch(_ context.Context, _ string) (driver.Batch, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	batch := &mockBatch{sendErr: c.sendErr}
+	c.batches = append(c.batches, batch)
+	return batch, nil
+}
+
+func TestBatchInserter(t *testing.T) {
+	conn := new(mockConn)
+	inserter := NewBatchInserter(conn, "INSERT INTO events (ts, name)")
+	for i := 0; i < 10; i++ {
+		inserter.Insert(i, "event")
+	}
+	inserter.Flush()
+
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+	assert.Equal(t, 1, len(conn.batches))
+	assert.Equal(t, 10, len(conn.batches[0].rows))
+	assert.True(t, conn.batches[0].sent)
+	assert.Equal(t, []interface{}{0, "event"}, conn.batches[0].rows[0])
+}
+
+func TestBatchInserterMaxRows(t *testing.T) {
+	conn := new(mockConn)
+	inserter := NewBatchInserter(conn, "INSERT INTO events (ts, name)", WithMaxBatchRows(2))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var rowCount int
+	inserter.SetResultHandler(func(rows int, err error) {
+		rowCount = rows
+		assert.Nil(t, err)
+		wg.Done()
+	})
+	inserter.Insert(1, "a")
+	inserter.Insert(2, "b")
+	wg.Wait()
+	assert.Equal(t, 2, rowCount)
+}
+
+func TestBatchInserterSendError(t *testing.T) {
+	conn := &mockConn{sendErr: errors.New("dummy")}
+	inserter := NewBatchInserter(conn, "INSERT INTO events (ts, name)")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	inserter.SetResultHandler(func(rows int, err error) {
+		assert.NotNil(t, err)
+		wg.Done()
+	})
+	inserter.Insert(1, "a")
+	inserter.Flush()
+	wg.Wait()
+}
+
+func TestTableFromStmt(t *testing.T) {
+	assert.Equal(t, "events", tableFromStmt("INSERT INTO events (ts, name)"))
+	assert.Equal(t, "db.events", tableFromStmt("insert into db.events"))
+	assert.Equal(t, "unknown", tableFromStmt("bad stmt"))
+}
@@ -0,0 +1,30 @@
+package clickhouse
+
+import "github.com/qkbyte/go-zero/core/metric"
+
+const namespace = "clickhouse_client"
+
+var (
+	metricInsertRows = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "batch",
+		Name:      "rows_total",
+		Help:      "rows inserted through batch inserts.",
+		Labels:    []string{"table"},
+	})
+	metricFlushFails = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "batch",
+		Name:      "flush_fail_total",
+		Help:      "failed batch insert flushes.",
+		Labels:    []string{"table"},
+	})
+	metricFlushDur = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: namespace,
+		Subsystem: "batch",
+		Name:      "flush_duration_ms",
+		Help:      "batch insert flush duration(ms).",
+		Labels:    []string{"table"},
+		Buckets:   []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500},
+	})
+)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"sync"
 
 	"github.com/qkbyte/go-zero/core/errorx"
 	"github.com/qkbyte/go-zero/core/hash"
@@ -14,7 +15,42 @@ import (
 // ErrNoRedisNode is an error that indicates no redis node.
 var ErrNoRedisNode = errors.New("no redis node")
 
+// number of keys fetched per scan on rebalancing.
+const rebalanceScanCount = 100
+
 type (
+	// Option defines the method to customize a Store.
+	Option func(o *options)
+
+	options struct {
+		replicas int
+		hashFunc hash.Func
+	}
+
+	// MigrateFunc defines the method to move a single key from source to target,
+	// used by ClusterStore.Rebalance.
+	MigrateFunc func(ctx context.Context, source, target *redis.Redis, key string) error
+
+	// ClusterStore interface represents a KV store whose nodes
+	// can be added and removed at runtime.
+	ClusterStore interface {
+		Store
+
+		// AddNode adds the given node into the hash ring,
+		// an existing node with the same host is replaced.
+		AddNode(conf cache.NodeConf)
+		// RemoveNode removes the node with the given host from the hash ring,
+		// and returns it for the caller to drain, nil if not found.
+		RemoveNode(host string) *redis.Redis
+		// Rebalance scans the keys on each node and moves the ones that
+		// no longer map to the node they reside on, typically called after
+		// AddNode or RemoveNode. migrate moves a single key, pass nil to use
+		// the default one that only handles string values. extraSources are
+		// scanned besides the current nodes, pass the node returned by
+		// RemoveNode to drain it.
+		Rebalance(ctx context.Context, migrate MigrateFunc, extraSources ...*redis.Redis) error
+	}
+
 	// Store interface represents a KV store.
 	Store interface {
 		Decr(key string) (int64, error)
@@ -151,28 +187,132 @@ type (
 
 	clusterStore struct {
 		dispatcher *hash.ConsistentHash
+		lock       *sync.Mutex
+		nodes      map[string]*redis.Redis
 	}
 )
 
 // NewStore returns a Store.
-func NewStore(c KvConf) Store {
+func NewStore(c KvConf, opts ...Option) Store {
+	return NewClusterStore(c, opts...)
+}
+
+// NewClusterStore returns a ClusterStore.
+func NewClusterStore(c KvConf, opts ...Option) ClusterStore {
 	if len(c) == 0 || cache.TotalWeights(c) <= 0 {
 		log.Fatal("no cache nodes")
 	}
 
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// even if only one node, we chose to use consistent hash,
 	// because Store and redis.Redis has different methods.
-	dispatcher := hash.NewConsistentHash()
+	cs := clusterStore{
+		dispatcher: hash.NewCustomConsistentHash(o.replicas, o.hashFunc),
+		lock:       new(sync.Mutex),
+		nodes:      make(map[string]*redis.Redis),
+	}
 	for _, node := range c {
-		cn := node.NewRedis()
-		dispatcher.AddWithWeight(cn, node.Weight)
+		cs.AddNode(node)
+	}
+
+	return cs
+}
+
+// WithHashFunc customizes the hash func on the ring of a Store.
+func WithHashFunc(fn hash.Func) Option {
+	return func(o *options) {
+		o.hashFunc = fn
 	}
+}
 
-	return clusterStore{
-		dispatcher: dispatcher,
+// WithReplicas customizes the number of virtual replicas per node on the ring
+// of a Store, the hash package enforces a minimum.
+func WithReplicas(replicas int) Option {
+	return func(o *options) {
+		o.replicas = replicas
 	}
 }
 
+func (cs clusterStore) AddNode(conf cache.NodeConf) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	if old, ok := cs.nodes[conf.Host]; ok {
+		cs.dispatcher.Remove(old)
+	}
+
+	node := conf.NewRedis()
+	cs.nodes[conf.Host] = node
+	cs.dispatcher.AddWithWeight(node, conf.Weight)
+}
+
+func (cs clusterStore) RemoveNode(host string) *redis.Redis {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	node, ok := cs.nodes[host]
+	if !ok {
+		return nil
+	}
+
+	cs.dispatcher.Remove(node)
+	delete(cs.nodes, host)
+	return node
+}
+
+func (cs clusterStore) Rebalance(ctx context.Context, migrate MigrateFunc,
+	extraSources ...*redis.Redis) error {
+	if migrate == nil {
+		migrate = migrateStringKey
+	}
+
+	cs.lock.Lock()
+	sources := make([]*redis.Redis, 0, len(cs.nodes)+len(extraSources))
+	for _, node := range cs.nodes {
+		sources = append(sources, node)
+	}
+	cs.lock.Unlock()
+	sources = append(sources, extraSources...)
+
+	var be errorx.BatchError
+	for _, source := range sources {
+		var cursor uint64
+		for {
+			keys, next, err := source.ScanCtx(ctx, cursor, "*", rebalanceScanCount)
+			if err != nil {
+				be.Add(err)
+				break
+			}
+
+			for _, key := range keys {
+				target, err := cs.getRedis(key)
+				if err != nil {
+					be.Add(err)
+					continue
+				}
+				if target == source {
+					continue
+				}
+
+				if err := migrate(ctx, source, target, key); err != nil {
+					be.Add(err)
+				}
+			}
+
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+	}
+
+	return be.Err()
+}
+
 func (cs clusterStore) Decr(key string) (int64, error) {
 	return cs.DecrCtx(context.Background(), key)
 }
@@ -1039,3 +1179,29 @@ func (cs clusterStore) getRedis(key string) (*redis.Redis, error) {
 
 	return val.(*redis.Redis), nil
 }
+
+// migrateStringKey is the default MigrateFunc, it only handles string values,
+// keys of other types need a custom MigrateFunc.
+func migrateStringKey(ctx context.Context, source, target *redis.Redis, key string) error {
+	val, err := source.GetCtx(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	seconds, err := source.TtlCtx(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if seconds > 0 {
+		err = target.SetexCtx(ctx, key, val, seconds)
+	} else {
+		err = target.SetCtx(ctx, key, val)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = source.DelCtx(ctx, key)
+	return err
+}
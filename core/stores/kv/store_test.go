@@ -1,6 +1,9 @@
 package kv
 
 import (
+	"context"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -757,3 +760,95 @@ func runOnCluster(fn func(cluster Store)) {
 
 	fn(store)
 }
+
+func TestNewClusterStoreWithOptions(t *testing.T) {
+	var called int32
+	store := NewClusterStore([]cache.NodeConf{
+		{
+			RedisConf: redis.RedisConf{
+				Host: s1.Addr(),
+				Type: redis.NodeType,
+			},
+			Weight: 100,
+		},
+	}, WithReplicas(200), WithHashFunc(func(data []byte) uint64 {
+		atomic.AddInt32(&called, 1)
+		return hash.Hash(data)
+	}))
+	assert.Nil(t, store.Set("option-key", "value"))
+	assert.True(t, atomic.LoadInt32(&called) > 0)
+}
+
+func TestClusterStoreAddRemoveNode(t *testing.T) {
+	s1.FlushAll()
+	s2.FlushAll()
+
+	store := NewClusterStore([]cache.NodeConf{
+		{
+			RedisConf: redis.RedisConf{
+				Host: s1.Addr(),
+				Type: redis.NodeType,
+			},
+			Weight: 100,
+		},
+	})
+	store.AddNode(cache.NodeConf{
+		RedisConf: redis.RedisConf{
+			Host: s2.Addr(),
+			Type: redis.NodeType,
+		},
+		Weight: 100,
+	})
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, store.Set("key"+strconv.Itoa(i), "value"))
+	}
+	assert.True(t, len(s1.Keys()) > 0)
+	assert.True(t, len(s2.Keys()) > 0)
+
+	assert.NotNil(t, store.RemoveNode(s2.Addr()))
+	assert.Nil(t, store.RemoveNode(s2.Addr()))
+	// all keys map to the remaining node now.
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, store.Set("key"+strconv.Itoa(i), "value"))
+	}
+	assert.Equal(t, 100, len(s1.Keys()))
+}
+
+func TestClusterStoreRebalance(t *testing.T) {
+	s1.FlushAll()
+	s2.FlushAll()
+
+	store := NewClusterStore([]cache.NodeConf{
+		{
+			RedisConf: redis.RedisConf{
+				Host: s1.Addr(),
+				Type: redis.NodeType,
+			},
+			Weight: 100,
+		},
+		{
+			RedisConf: redis.RedisConf{
+				Host: s2.Addr(),
+				Type: redis.NodeType,
+			},
+			Weight: 100,
+		},
+	})
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, store.Set("key"+strconv.Itoa(i), "value"+strconv.Itoa(i)))
+	}
+
+	// drain the removed node into the remaining ones.
+	removed := store.RemoveNode(s2.Addr())
+	assert.NotNil(t, removed)
+	assert.Nil(t, store.Rebalance(context.Background(), nil, removed))
+
+	assert.Equal(t, 100, len(s1.Keys()))
+	assert.Equal(t, 0, len(s2.Keys()))
+	for i := 0; i < 100; i++ {
+		val, err := store.Get("key" + strconv.Itoa(i))
+		assert.Nil(t, err)
+		assert.Equal(t, "value"+strconv.Itoa(i), val)
+	}
+}
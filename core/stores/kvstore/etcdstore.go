@@ -0,0 +1,90 @@
+package kvstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/threading"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// An etcdStore is an etcd backed Store. Watch is native, changes made by
+// any client are observed. TTLs are rounded up to whole seconds, the etcd
+// lease granularity.
+type etcdStore struct {
+	cli *clientv3.Client
+}
+
+// NewEtcdStore returns an etcd backed Store on given client.
+func NewEtcdStore(cli *clientv3.Client) Store {
+	return &etcdStore{
+		cli: cli,
+	}
+}
+
+func (s *etcdStore) Del(ctx context.Context, key string) error {
+	_, err := s.cli.Delete(ctx, key)
+	return err
+}
+
+func (s *etcdStore) Get(ctx context.Context, key string) (string, bool, error) {
+	resp, err := s.cli.Get(ctx, key)
+	if err != nil {
+		return "", false, err
+	}
+	if resp.Count == 0 {
+		return "", false, nil
+	}
+
+	return string(resp.Kvs[0].Value), true, nil
+}
+
+func (s *etcdStore) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	var opts []clientv3.OpOption
+	if ttl > 0 {
+		seconds := int64((ttl + time.Second - 1) / time.Second)
+		lease, err := s.cli.Grant(ctx, seconds)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, clientv3.WithLease(lease.ID))
+	}
+
+	_, err := s.cli.Put(ctx, key, value, opts...)
+	return err
+}
+
+func (s *etcdStore) Watch(ctx context.Context, key string, handler WatchHandler) error {
+	rch := s.cli.Watch(clientv3.WithRequireLeader(ctx), key)
+	threading.GoSafe(func() {
+		for resp := range rch {
+			if resp.Err() != nil {
+				continue
+			}
+
+			for _, ev := range resp.Events {
+				event := toEvent(ev)
+				threading.RunSafe(func() {
+					handler(event)
+				})
+			}
+		}
+	})
+
+	return nil
+}
+
+func toEvent(ev *clientv3.Event) Event {
+	event := Event{
+		Key: string(ev.Kv.Key),
+	}
+	switch ev.Type {
+	case clientv3.EventTypeDelete:
+		event.Type = EventDel
+	default:
+		event.Type = EventPut
+		event.Value = string(ev.Kv.Value)
+	}
+
+	return event
+}
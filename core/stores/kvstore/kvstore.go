@@ -0,0 +1,45 @@
+// Package kvstore defines a minimal key/value abstraction with redis, etcd
+// and in-memory implementations, so that libraries like feature flags and
+// locks can stay backend-agnostic and testable.
+package kvstore
+
+import (
+	"context"
+	"time"
+)
+
+// EventType is the type of watch events.
+type EventType int
+
+const (
+	// EventPut indicates the key was created or updated.
+	EventPut EventType = iota
+	// EventDel indicates the key was deleted.
+	EventDel
+)
+
+type (
+	// An Event describes a single change observed through Watch.
+	Event struct {
+		Type  EventType
+		Key   string
+		Value string
+	}
+
+	// WatchHandler handles a single watch event.
+	WatchHandler func(event Event)
+
+	// A Store is a minimal key/value store.
+	Store interface {
+		// Del removes the given key.
+		Del(ctx context.Context, key string) error
+		// Get returns the value of the given key,
+		// found reports whether the key exists.
+		Get(ctx context.Context, key string) (value string, found bool, err error)
+		// Set stores value under the given key, ttl <= 0 means no expiry.
+		Set(ctx context.Context, key, value string, ttl time.Duration) error
+		// Watch dispatches change events of the given key to handler
+		// until ctx is canceled.
+		Watch(ctx context.Context, key string, handler WatchHandler) error
+	}
+)
@@ -0,0 +1,150 @@
+package kvstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/qkbyte/go-zero/core/stores/redis/redistest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	_, found, err := store.Get(ctx, "flag")
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	assert.Nil(t, store.Set(ctx, "flag", "on", 0))
+	val, found, err := store.Get(ctx, "flag")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "on", val)
+
+	// empty values are distinguished from missing keys.
+	assert.Nil(t, store.Set(ctx, "empty", "", 0))
+	_, found, err = store.Get(ctx, "empty")
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	assert.Nil(t, store.Del(ctx, "flag"))
+	_, found, err = store.Get(ctx, "flag")
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestMemoryStoreTtl(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	assert.Nil(t, store.Set(ctx, "flag", "on", time.Millisecond))
+	time.Sleep(time.Millisecond * 10)
+	_, found, err := store.Get(ctx, "flag")
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestMemoryStoreWatch(t *testing.T) {
+	store := NewMemoryStore()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan Event, 4)
+	assert.Nil(t, store.Watch(ctx, "flag", func(event Event) {
+		events <- event
+	}))
+
+	assert.Nil(t, store.Set(ctx, "flag", "on", 0))
+	assert.Nil(t, store.Set(ctx, "other", "ignored", 0))
+	assert.Nil(t, store.Del(ctx, "flag"))
+	// deleting a missing key fires no event.
+	assert.Nil(t, store.Del(ctx, "flag"))
+
+	assert.Equal(t, Event{Type: EventPut, Key: "flag", Value: "on"}, <-events)
+	assert.Equal(t, Event{Type: EventDel, Key: "flag"}, <-events)
+	assert.Equal(t, 0, len(events))
+}
+
+func TestRedisStore(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	store := NewRedisStore(rds)
+	ctx := context.Background()
+
+	_, found, err := store.Get(ctx, "flag")
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	assert.Nil(t, store.Set(ctx, "flag", "on", time.Minute))
+	val, found, err := store.Get(ctx, "flag")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "on", val)
+
+	assert.Nil(t, store.Set(ctx, "empty", "", 0))
+	_, found, err = store.Get(ctx, "empty")
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	assert.Nil(t, store.Del(ctx, "flag"))
+	_, found, err = store.Get(ctx, "flag")
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestRedisStoreWatch(t *testing.T) {
+	rds, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	store := NewRedisStore(rds)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan Event, 4)
+	assert.Nil(t, store.Watch(ctx, "flag", func(event Event) {
+		events <- event
+	}))
+
+	assert.Nil(t, store.Set(ctx, "flag", "on", 0))
+	assert.Nil(t, store.Del(ctx, "flag"))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, Event{Type: EventPut, Key: "flag", Value: "on"}, event)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for put event")
+	}
+	select {
+	case event := <-events:
+		assert.Equal(t, Event{Type: EventDel, Key: "flag"}, event)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for del event")
+	}
+}
+
+func TestEtcdStoreToEvent(t *testing.T) {
+	event := toEvent(&clientv3.Event{
+		Type: clientv3.EventTypePut,
+		Kv: &mvccpb.KeyValue{
+			Key:   []byte("flag"),
+			Value: []byte("on"),
+		},
+	})
+	assert.Equal(t, Event{Type: EventPut, Key: "flag", Value: "on"}, event)
+
+	event = toEvent(&clientv3.Event{
+		Type: clientv3.EventTypeDelete,
+		Kv: &mvccpb.KeyValue{
+			Key: []byte("flag"),
+		},
+	})
+	assert.Equal(t, Event{Type: EventDel, Key: "flag"}, event)
+}
@@ -0,0 +1,126 @@
+package kvstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+type (
+	memoryEntry struct {
+		value    string
+		expireAt time.Time
+	}
+
+	// A memoryStore is an in-memory Store, mainly for tests and single
+	// process use. Keys expire lazily, expirations don't fire watch events.
+	memoryStore struct {
+		lock     sync.Mutex
+		entries  map[string]memoryEntry
+		watchers map[string]map[int64]WatchHandler
+		watchSeq int64
+	}
+)
+
+// NewMemoryStore returns an in-memory Store.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries:  make(map[string]memoryEntry),
+		watchers: make(map[string]map[int64]WatchHandler),
+	}
+}
+
+func (s *memoryStore) Del(_ context.Context, key string) error {
+	s.lock.Lock()
+	_, found := s.entries[key]
+	delete(s.entries, key)
+	s.lock.Unlock()
+
+	if found {
+		s.notify(Event{
+			Type: EventDel,
+			Key:  key,
+		})
+	}
+
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entry, found := s.entries[key]
+	if !found {
+		return "", false, nil
+	}
+	if !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		delete(s.entries, key)
+		return "", false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key, value string, ttl time.Duration) error {
+	entry := memoryEntry{
+		value: value,
+	}
+	if ttl > 0 {
+		entry.expireAt = time.Now().Add(ttl)
+	}
+
+	s.lock.Lock()
+	s.entries[key] = entry
+	s.lock.Unlock()
+
+	s.notify(Event{
+		Type:  EventPut,
+		Key:   key,
+		Value: value,
+	})
+
+	return nil
+}
+
+func (s *memoryStore) Watch(ctx context.Context, key string, handler WatchHandler) error {
+	s.lock.Lock()
+	s.watchSeq++
+	id := s.watchSeq
+	if s.watchers[key] == nil {
+		s.watchers[key] = make(map[int64]WatchHandler)
+	}
+	s.watchers[key][id] = handler
+	s.lock.Unlock()
+
+	threading.GoSafe(func() {
+		<-ctx.Done()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		delete(s.watchers[key], id)
+		if len(s.watchers[key]) == 0 {
+			delete(s.watchers, key)
+		}
+	})
+
+	return nil
+}
+
+func (s *memoryStore) notify(event Event) {
+	s.lock.Lock()
+	handlers := make([]WatchHandler, 0, len(s.watchers[event.Key]))
+	for _, handler := range s.watchers[event.Key] {
+		handlers = append(handlers, handler)
+	}
+	s.lock.Unlock()
+
+	for _, handler := range handlers {
+		handler := handler
+		threading.RunSafe(func() {
+			handler(event)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package kvstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/stores/redis"
+)
+
+const (
+	redisEventPrefix = "kvstore:events:"
+	redisPutMarker   = 'p'
+	redisDelMarker   = 'd'
+)
+
+// A redisStore is a redis backed Store. Changes are broadcast over pub/sub
+// by the store itself, so Watch only observes changes made through Store
+// instances, not direct redis writes. TTLs are rounded up to whole seconds.
+type redisStore struct {
+	rds *redis.Redis
+}
+
+// NewRedisStore returns a redis backed Store.
+func NewRedisStore(rds *redis.Redis) Store {
+	return &redisStore{
+		rds: rds,
+	}
+}
+
+func (s *redisStore) Del(ctx context.Context, key string) error {
+	if _, err := s.rds.DelCtx(ctx, key); err != nil {
+		return err
+	}
+
+	_, err := s.rds.PublishCtx(ctx, redisEventPrefix+key, string(redisDelMarker))
+	return err
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) (string, bool, error) {
+	val, err := s.rds.GetCtx(ctx, key)
+	if err != nil {
+		return "", false, err
+	}
+	if len(val) > 0 {
+		return val, true, nil
+	}
+
+	// empty values and missing keys both read as "",
+	// only then pay for the existence check.
+	found, err := s.rds.ExistsCtx(ctx, key)
+	if err != nil {
+		return "", false, err
+	}
+
+	return "", found, nil
+}
+
+func (s *redisStore) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	if ttl > 0 {
+		seconds := int((ttl + time.Second - 1) / time.Second)
+		if err := s.rds.SetexCtx(ctx, key, value, seconds); err != nil {
+			return err
+		}
+	} else if err := s.rds.SetCtx(ctx, key, value); err != nil {
+		return err
+	}
+
+	_, err := s.rds.PublishCtx(ctx, redisEventPrefix+key, string(redisPutMarker)+value)
+	return err
+}
+
+func (s *redisStore) Watch(ctx context.Context, key string, handler WatchHandler) error {
+	_, err := s.rds.SubscribeCtx(ctx, func(msg *redis.Message) {
+		if len(msg.Payload) == 0 {
+			return
+		}
+
+		switch msg.Payload[0] {
+		case redisPutMarker:
+			handler(Event{
+				Type:  EventPut,
+				Key:   key,
+				Value: msg.Payload[1:],
+			})
+		case redisDelMarker:
+			handler(Event{
+				Type: EventDel,
+				Key:  key,
+			})
+		}
+	}, redisEventPrefix+key)
+
+	return err
+}
@@ -137,7 +137,7 @@ func newCollection(collection *mongo.Collection, brk breaker.Breaker) Collection
 
 func (c *decoratedCollection) Aggregate(ctx context.Context, pipeline interface{},
 	opts ...*mopt.AggregateOptions) (cur *mongo.Cursor, err error) {
-	ctx, span := startSpan(ctx, aggregate)
+	ctx, span := startSpan(ctx, c.name, aggregate)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -157,7 +157,7 @@ func (c *decoratedCollection) Aggregate(ctx context.Context, pipeline interface{
 
 func (c *decoratedCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel,
 	opts ...*mopt.BulkWriteOptions) (res *mongo.BulkWriteResult, err error) {
-	ctx, span := startSpan(ctx, bulkWrite)
+	ctx, span := startSpan(ctx, c.name, bulkWrite)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -177,7 +177,7 @@ func (c *decoratedCollection) BulkWrite(ctx context.Context, models []mongo.Writ
 
 func (c *decoratedCollection) CountDocuments(ctx context.Context, filter interface{},
 	opts ...*mopt.CountOptions) (count int64, err error) {
-	ctx, span := startSpan(ctx, countDocuments)
+	ctx, span := startSpan(ctx, c.name, countDocuments)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -197,7 +197,7 @@ func (c *decoratedCollection) CountDocuments(ctx context.Context, filter interfa
 
 func (c *decoratedCollection) DeleteMany(ctx context.Context, filter interface{},
 	opts ...*mopt.DeleteOptions) (res *mongo.DeleteResult, err error) {
-	ctx, span := startSpan(ctx, deleteMany)
+	ctx, span := startSpan(ctx, c.name, deleteMany)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -217,7 +217,7 @@ func (c *decoratedCollection) DeleteMany(ctx context.Context, filter interface{}
 
 func (c *decoratedCollection) DeleteOne(ctx context.Context, filter interface{},
 	opts ...*mopt.DeleteOptions) (res *mongo.DeleteResult, err error) {
-	ctx, span := startSpan(ctx, deleteOne)
+	ctx, span := startSpan(ctx, c.name, deleteOne)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -237,7 +237,7 @@ func (c *decoratedCollection) DeleteOne(ctx context.Context, filter interface{},
 
 func (c *decoratedCollection) Distinct(ctx context.Context, fieldName string, filter interface{},
 	opts ...*mopt.DistinctOptions) (val []interface{}, err error) {
-	ctx, span := startSpan(ctx, distinct)
+	ctx, span := startSpan(ctx, c.name, distinct)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -257,7 +257,7 @@ func (c *decoratedCollection) Distinct(ctx context.Context, fieldName string, fi
 
 func (c *decoratedCollection) EstimatedDocumentCount(ctx context.Context,
 	opts ...*mopt.EstimatedDocumentCountOptions) (val int64, err error) {
-	ctx, span := startSpan(ctx, estimatedDocumentCount)
+	ctx, span := startSpan(ctx, c.name, estimatedDocumentCount)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -277,7 +277,7 @@ func (c *decoratedCollection) EstimatedDocumentCount(ctx context.Context,
 
 func (c *decoratedCollection) Find(ctx context.Context, filter interface{},
 	opts ...*mopt.FindOptions) (cur *mongo.Cursor, err error) {
-	ctx, span := startSpan(ctx, find)
+	ctx, span := startSpan(ctx, c.name, find)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -297,7 +297,7 @@ func (c *decoratedCollection) Find(ctx context.Context, filter interface{},
 
 func (c *decoratedCollection) FindOne(ctx context.Context, filter interface{},
 	opts ...*mopt.FindOneOptions) (res *mongo.SingleResult, err error) {
-	ctx, span := startSpan(ctx, findOne)
+	ctx, span := startSpan(ctx, c.name, findOne)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -318,7 +318,7 @@ func (c *decoratedCollection) FindOne(ctx context.Context, filter interface{},
 
 func (c *decoratedCollection) FindOneAndDelete(ctx context.Context, filter interface{},
 	opts ...*mopt.FindOneAndDeleteOptions) (res *mongo.SingleResult, err error) {
-	ctx, span := startSpan(ctx, findOneAndDelete)
+	ctx, span := startSpan(ctx, c.name, findOneAndDelete)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -340,7 +340,7 @@ func (c *decoratedCollection) FindOneAndDelete(ctx context.Context, filter inter
 func (c *decoratedCollection) FindOneAndReplace(ctx context.Context, filter interface{},
 	replacement interface{}, opts ...*mopt.FindOneAndReplaceOptions) (
 	res *mongo.SingleResult, err error) {
-	ctx, span := startSpan(ctx, findOneAndReplace)
+	ctx, span := startSpan(ctx, c.name, findOneAndReplace)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -361,7 +361,7 @@ func (c *decoratedCollection) FindOneAndReplace(ctx context.Context, filter inte
 
 func (c *decoratedCollection) FindOneAndUpdate(ctx context.Context, filter, update interface{},
 	opts ...*mopt.FindOneAndUpdateOptions) (res *mongo.SingleResult, err error) {
-	ctx, span := startSpan(ctx, findOneAndUpdate)
+	ctx, span := startSpan(ctx, c.name, findOneAndUpdate)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -382,7 +382,7 @@ func (c *decoratedCollection) FindOneAndUpdate(ctx context.Context, filter, upda
 
 func (c *decoratedCollection) InsertMany(ctx context.Context, documents []interface{},
 	opts ...*mopt.InsertManyOptions) (res *mongo.InsertManyResult, err error) {
-	ctx, span := startSpan(ctx, insertMany)
+	ctx, span := startSpan(ctx, c.name, insertMany)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -402,7 +402,7 @@ func (c *decoratedCollection) InsertMany(ctx context.Context, documents []interf
 
 func (c *decoratedCollection) InsertOne(ctx context.Context, document interface{},
 	opts ...*mopt.InsertOneOptions) (res *mongo.InsertOneResult, err error) {
-	ctx, span := startSpan(ctx, insertOne)
+	ctx, span := startSpan(ctx, c.name, insertOne)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -422,7 +422,7 @@ func (c *decoratedCollection) InsertOne(ctx context.Context, document interface{
 
 func (c *decoratedCollection) ReplaceOne(ctx context.Context, filter, replacement interface{},
 	opts ...*mopt.ReplaceOptions) (res *mongo.UpdateResult, err error) {
-	ctx, span := startSpan(ctx, replaceOne)
+	ctx, span := startSpan(ctx, c.name, replaceOne)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -442,7 +442,7 @@ func (c *decoratedCollection) ReplaceOne(ctx context.Context, filter, replacemen
 
 func (c *decoratedCollection) UpdateByID(ctx context.Context, id, update interface{},
 	opts ...*mopt.UpdateOptions) (res *mongo.UpdateResult, err error) {
-	ctx, span := startSpan(ctx, updateByID)
+	ctx, span := startSpan(ctx, c.name, updateByID)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -462,7 +462,7 @@ func (c *decoratedCollection) UpdateByID(ctx context.Context, id, update interfa
 
 func (c *decoratedCollection) UpdateMany(ctx context.Context, filter, update interface{},
 	opts ...*mopt.UpdateOptions) (res *mongo.UpdateResult, err error) {
-	ctx, span := startSpan(ctx, updateMany)
+	ctx, span := startSpan(ctx, c.name, updateMany)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -482,7 +482,7 @@ func (c *decoratedCollection) UpdateMany(ctx context.Context, filter, update int
 
 func (c *decoratedCollection) UpdateOne(ctx context.Context, filter, update interface{},
 	opts ...*mopt.UpdateOptions) (res *mongo.UpdateResult, err error) {
-	ctx, span := startSpan(ctx, updateOne)
+	ctx, span := startSpan(ctx, c.name, updateOne)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -503,6 +503,7 @@ func (c *decoratedCollection) UpdateOne(ctx context.Context, filter, update inte
 func (c *decoratedCollection) logDuration(ctx context.Context, method string,
 	startTime time.Duration, err error, docs ...interface{}) {
 	duration := timex.Since(startTime)
+	reportDuration(method, c.name, duration, err)
 	logger := logx.WithContext(ctx).WithDuration(duration)
 
 	content, jerr := json.Marshal(docs)
@@ -661,3 +661,16 @@ func (d *dropBreaker) DoWithFallbackAcceptable(_ func() error, _ func(err error)
 	_ breaker.Acceptable) error {
 	return nil
 }
+
+func (d *dropBreaker) State() breaker.State {
+	return breaker.StateOpen
+}
+
+func (d *dropBreaker) Open() {
+}
+
+func (d *dropBreaker) HalfOpen() {
+}
+
+func (d *dropBreaker) Close() {
+}
@@ -0,0 +1,246 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/breaker"
+	"github.com/qkbyte/go-zero/core/timex"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	mopt "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	gridfsUpload   = "GridFSUpload"
+	gridfsDownload = "GridFSDownload"
+	gridfsDelete   = "GridFSDelete"
+)
+
+// ErrFileTooLarge is returned when an upload exceeds the size limit of the bucket.
+var ErrFileTooLarge = errors.New("file exceeds the bucket size limit")
+
+type (
+	// BucketOption defines the method to customize a Bucket.
+	BucketOption func(o *bucketOptions)
+
+	bucketOptions struct {
+		name      string
+		chunkSize int32
+		sizeLimit int64
+	}
+
+	// A Bucket is a GridFS bucket with breaker and slowlog instrumentation,
+	// contexts are honored through their deadlines, the driver doesn't
+	// support mid-transfer cancellation.
+	Bucket struct {
+		bucket    *gridfs.Bucket
+		name      string
+		sizeLimit int64
+		brk       breaker.Breaker
+	}
+)
+
+// NewBucket returns a GridFS Bucket on given db.
+func NewBucket(uri, db string, opts ...BucketOption) (*Bucket, error) {
+	cli, err := getClient(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var o bucketOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	bucketOpts := mopt.GridFSBucket()
+	if len(o.name) > 0 {
+		bucketOpts.SetName(o.name)
+	}
+	if o.chunkSize > 0 {
+		bucketOpts.SetChunkSizeBytes(o.chunkSize)
+	}
+	bucket, err := gridfs.NewBucket(cli.Database(db), bucketOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bucket{
+		bucket:    bucket,
+		name:      strings.Join([]string{uri, db, o.name}, "/"),
+		sizeLimit: o.sizeLimit,
+		brk:       breaker.GetBreaker(uri),
+	}, nil
+}
+
+// WithBucketName customizes a Bucket with given name, defaults to fs.
+func WithBucketName(name string) BucketOption {
+	return func(o *bucketOptions) {
+		o.name = name
+	}
+}
+
+// WithChunkSize customizes a Bucket with given chunk size in bytes.
+func WithChunkSize(bytes int32) BucketOption {
+	return func(o *bucketOptions) {
+		o.chunkSize = bytes
+	}
+}
+
+// WithSizeLimit customizes a Bucket with given per-file upload limit in bytes.
+func WithSizeLimit(bytes int64) BucketOption {
+	return func(o *bucketOptions) {
+		o.sizeLimit = bytes
+	}
+}
+
+// Delete deletes the file with given id, both metadata and chunks.
+func (b *Bucket) Delete(ctx context.Context, id primitive.ObjectID) (err error) {
+	ctx, span := startSpan(ctx, b.name, gridfsDelete)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	return b.brk.DoWithAcceptable(func() error {
+		starTime := timex.Now()
+		defer func() {
+			logDuration(ctx, b.name, gridfsDelete, starTime, err)
+		}()
+
+		if err := b.applyDeadline(ctx); err != nil {
+			return err
+		}
+
+		return b.bucket.Delete(id)
+	}, acceptable)
+}
+
+// Download writes the content of the file with given id into w,
+// and returns the number of bytes written.
+func (b *Bucket) Download(ctx context.Context, id primitive.ObjectID, w io.Writer) (
+	n int64, err error) {
+	ctx, span := startSpan(ctx, b.name, gridfsDownload)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	err = b.brk.DoWithAcceptable(func() error {
+		starTime := timex.Now()
+		defer func() {
+			logDuration(ctx, b.name, gridfsDownload, starTime, err)
+		}()
+
+		if err := b.applyDeadline(ctx); err != nil {
+			return err
+		}
+
+		var downloadErr error
+		n, downloadErr = b.bucket.DownloadToStream(id, w)
+		return downloadErr
+	}, acceptable)
+
+	return
+}
+
+// OpenDownloadStream returns a stream to read the content of the file
+// with given id, the caller is responsible for closing it.
+func (b *Bucket) OpenDownloadStream(ctx context.Context, id primitive.ObjectID) (
+	stream *gridfs.DownloadStream, err error) {
+	ctx, span := startSpan(ctx, b.name, gridfsDownload)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	err = b.brk.DoWithAcceptable(func() error {
+		starTime := timex.Now()
+		defer func() {
+			logDuration(ctx, b.name, gridfsDownload, starTime, err)
+		}()
+
+		if err := b.applyDeadline(ctx); err != nil {
+			return err
+		}
+
+		var openErr error
+		stream, openErr = b.bucket.OpenDownloadStream(id)
+		return openErr
+	}, acceptable)
+
+	return
+}
+
+// Upload stores the content of r as a file with given filename,
+// and returns the id of the file. ErrFileTooLarge is returned with
+// the size limit of the bucket exceeded.
+func (b *Bucket) Upload(ctx context.Context, filename string, r io.Reader,
+	opts ...*mopt.UploadOptions) (id primitive.ObjectID, err error) {
+	ctx, span := startSpan(ctx, b.name, gridfsUpload)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	err = b.brk.DoWithAcceptable(func() error {
+		starTime := timex.Now()
+		defer func() {
+			logDuration(ctx, b.name, gridfsUpload, starTime, err)
+		}()
+
+		if err := b.applyDeadline(ctx); err != nil {
+			return err
+		}
+
+		source := r
+		if b.sizeLimit > 0 {
+			source = &limitedReader{r: r, remain: b.sizeLimit}
+		}
+
+		var uploadErr error
+		id, uploadErr = b.bucket.UploadFromStream(filename, source, opts...)
+		return uploadErr
+	}, acceptable)
+
+	return
+}
+
+// applyDeadline propagates the deadline of ctx to the bucket,
+// the driver doesn't take contexts on GridFS calls.
+func (b *Bucket) applyDeadline(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Time{}
+	}
+	if err := b.bucket.SetReadDeadline(deadline); err != nil {
+		return err
+	}
+
+	return b.bucket.SetWriteDeadline(deadline)
+}
+
+// A limitedReader fails with ErrFileTooLarge once more than remain bytes are read.
+type limitedReader struct {
+	r      io.Reader
+	remain int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	// read one byte beyond the limit to tell exact-size files
+	// from oversized ones.
+	if int64(len(p)) > r.remain+1 {
+		p = p[:r.remain+1]
+	}
+
+	n, err := r.r.Read(p)
+	if int64(n) > r.remain {
+		return 0, ErrFileTooLarge
+	}
+
+	r.remain -= int64(n)
+	return n, err
+}
@@ -0,0 +1,60 @@
+package mon
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestLimitedReader(t *testing.T) {
+	// exact-size content passes.
+	r := &limitedReader{r: strings.NewReader("hello"), remain: 5}
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, r)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, "hello", buf.String())
+
+	// oversized content fails.
+	r = &limitedReader{r: strings.NewReader("hello"), remain: 4}
+	_, err = io.Copy(io.Discard, r)
+	assert.Equal(t, ErrFileTooLarge, err)
+}
+
+func TestNewBucket(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("test", func(mt *mtest.T) {
+		Inject(mt.Name(), mt.Client)
+		bucket, err := NewBucket(mt.Name(), mt.DB.Name(),
+			WithBucketName("attachments"), WithChunkSize(1024), WithSizeLimit(4))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), bucket.sizeLimit)
+
+		// uploads over the size limit fail, the aborted stream cleans up chunks.
+		// the responses cover the index check on the first write and the abort.
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.fs.files", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "test.fs.files", mtest.FirstBatch),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "test.fs.chunks", mtest.FirstBatch),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(),
+		)
+		_, err = bucket.Upload(context.Background(), "file.txt",
+			strings.NewReader("oversized"))
+		assert.Equal(t, ErrFileTooLarge, err)
+
+		// a canceled context fails fast.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = bucket.Upload(ctx, "file.txt", strings.NewReader("ok"))
+		assert.Equal(t, context.Canceled, err)
+	})
+}
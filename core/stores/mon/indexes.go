@@ -0,0 +1,140 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/qkbyte/go-zero/core/lang"
+	"github.com/qkbyte/go-zero/core/timex"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopt "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ensureIndexes = "EnsureIndexes"
+	// indexTag is the struct tag that declares an index on the field,
+	// with comma-separated options: desc, unique, sparse.
+	indexTag = "index"
+	bsonTag  = "bson"
+)
+
+// IndexModel is an alias of mongo.IndexModel.
+type IndexModel = mongo.IndexModel
+
+// EnsureIndexes reconciles the given indexes with the collection,
+// typically called at startup. Missing indexes are created, existing ones
+// are left untouched, and nothing is dropped. MongoDB 4.2+ always builds
+// indexes in the background.
+func (m *Model) EnsureIndexes(ctx context.Context, indexes []mongo.IndexModel) (err error) {
+	ctx, span := startSpan(ctx, m.name, ensureIndexes)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	return m.brk.DoWithAcceptable(func() error {
+		starTime := timex.Now()
+		defer func() {
+			logDuration(ctx, m.name, ensureIndexes, starTime, err)
+		}()
+
+		specs, specErr := m.Collection.Indexes().ListSpecifications(ctx)
+		if specErr != nil {
+			return specErr
+		}
+
+		existing := make(map[string]lang.PlaceholderType, len(specs))
+		for _, spec := range specs {
+			existing[spec.Name] = lang.Placeholder
+		}
+
+		var missing []mongo.IndexModel
+		for _, index := range indexes {
+			name, nameErr := indexName(index)
+			if nameErr != nil {
+				return nameErr
+			}
+			if _, ok := existing[name]; ok {
+				continue
+			}
+
+			missing = append(missing, index)
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+
+		_, createErr := m.Collection.Indexes().CreateMany(ctx, missing)
+		return createErr
+	}, acceptable)
+}
+
+// IndexesFromStruct declares single-field indexes from the index tags of v,
+// compound indexes should be declared in code with mongo.IndexModel.
+func IndexesFromStruct(v interface{}) []mongo.IndexModel {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var indexes []mongo.IndexModel
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup(indexTag)
+		if !ok {
+			continue
+		}
+
+		order := int32(1)
+		opts := mopt.Index()
+		for _, opt := range strings.Split(tag, ",") {
+			switch strings.TrimSpace(opt) {
+			case "desc":
+				order = -1
+			case "unique":
+				opts.SetUnique(true)
+			case "sparse":
+				opts.SetSparse(true)
+			}
+		}
+
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: bsonFieldName(field), Value: order}},
+			Options: opts,
+		})
+	}
+
+	return indexes
+}
+
+// indexName returns the name of the index, derived from the keys
+// like the driver does if not set explicitly.
+func indexName(index mongo.IndexModel) (string, error) {
+	if index.Options != nil && index.Options.Name != nil {
+		return *index.Options.Name, nil
+	}
+
+	keys, ok := index.Keys.(bson.D)
+	if !ok {
+		return "", fmt.Errorf("mon: name the index explicitly for keys of type %T", index.Keys)
+	}
+
+	parts := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		parts = append(parts, key.Key, fmt.Sprintf("%v", key.Value))
+	}
+
+	return strings.Join(parts, "_"), nil
+}
+
+func bsonFieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup(bsonTag); ok {
+		if name := strings.Split(tag, ",")[0]; len(name) > 0 && name != "-" {
+			return name
+		}
+	}
+
+	return strings.ToLower(field.Name)
+}
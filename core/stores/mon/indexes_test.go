@@ -0,0 +1,82 @@
+package mon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	mopt "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestIndexesFromStruct(t *testing.T) {
+	type doc struct {
+		Name    string `bson:"name" index:"unique"`
+		Age     int    `bson:"age,omitempty" index:"desc,sparse"`
+		Ignored string `bson:"ignored"`
+		NoTag   string `index:""`
+	}
+
+	indexes := IndexesFromStruct(&doc{})
+	assert.Equal(t, 3, len(indexes))
+	assert.Equal(t, bson.D{{Key: "name", Value: int32(1)}}, indexes[0].Keys)
+	assert.True(t, *indexes[0].Options.Unique)
+	assert.Equal(t, bson.D{{Key: "age", Value: int32(-1)}}, indexes[1].Keys)
+	assert.True(t, *indexes[1].Options.Sparse)
+	assert.Equal(t, bson.D{{Key: "notag", Value: int32(1)}}, indexes[2].Keys)
+}
+
+func TestIndexName(t *testing.T) {
+	name, err := indexName(mongo.IndexModel{
+		Keys: bson.D{{Key: "name", Value: 1}, {Key: "age", Value: -1}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "name_1_age_-1", name)
+
+	name, err = indexName(mongo.IndexModel{
+		Keys:    bson.M{"name": 1},
+		Options: mopt.Index().SetName("custom"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "custom", name)
+
+	_, err = indexName(mongo.IndexModel{Keys: bson.M{"name": 1}})
+	assert.NotNil(t, err)
+}
+
+func TestModel_EnsureIndexes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("test", func(mt *mtest.T) {
+		m := createModel(mt)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch, bson.D{
+				{Key: "v", Value: 2},
+				{Key: "key", Value: bson.D{{Key: "name", Value: 1}}},
+				{Key: "name", Value: "name_1"},
+			}),
+			mtest.CreateSuccessResponse(),
+		)
+		err := m.EnsureIndexes(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "name", Value: 1}}},
+			{Keys: bson.D{{Key: "age", Value: -1}}},
+		})
+		assert.Nil(t, err)
+
+		// all indexes present, nothing to create.
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch, bson.D{
+				{Key: "v", Value: 2},
+				{Key: "key", Value: bson.D{{Key: "name", Value: 1}}},
+				{Key: "name", Value: "name_1"},
+			}),
+		)
+		err = m.EnsureIndexes(context.Background(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "name", Value: 1}}},
+		})
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,55 @@
+package mon
+
+import (
+	"time"
+
+	"github.com/qkbyte/go-zero/core/metric"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const namespace = "mongo_client"
+
+var (
+	metricReqDur = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "durations_ms",
+		Help:      "mongo client requests duration(ms).",
+		Labels:    []string{"command", "collection"},
+		Buckets:   []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500},
+	})
+	metricReqErr = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: namespace,
+		Subsystem: "requests",
+		Name:      "error_total",
+		Help:      "mongo client requests error count.",
+		Labels:    []string{"command", "collection", "error"},
+	})
+)
+
+// reportDuration updates the request metrics of a single mongo operation,
+// acceptable errors like mongo.ErrNoDocuments don't count as errors.
+// Breaker rejections don't reach here, they're counted by the breaker itself.
+func reportDuration(command, collection string, duration time.Duration, err error) {
+	metricReqDur.Observe(int64(duration/time.Millisecond), command, collection)
+	if class := classifyError(err); len(class) > 0 {
+		metricReqErr.Inc(command, collection, class)
+	}
+}
+
+// classifyError buckets err into a low cardinality error label,
+// an empty string means no error to report.
+func classifyError(err error) string {
+	switch {
+	case acceptable(err):
+		return ""
+	case mongo.IsDuplicateKeyError(err):
+		return "duplicate_key"
+	case mongo.IsTimeout(err):
+		return "timeout"
+	case mongo.IsNetworkError(err):
+		return "network"
+	default:
+		return "other"
+	}
+}
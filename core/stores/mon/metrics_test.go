@@ -0,0 +1,61 @@
+package mon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "not found",
+			err:  mongo.ErrNoDocuments,
+		},
+		{
+			name: "duplicate key",
+			err: mongo.WriteException{
+				WriteErrors: []mongo.WriteError{{Code: 11000}},
+			},
+			expect: "duplicate_key",
+		},
+		{
+			name:   "timeout",
+			err:    mongo.CommandError{Labels: []string{"NetworkTimeoutError"}},
+			expect: "timeout",
+		},
+		{
+			name:   "network",
+			err:    mongo.CommandError{Labels: []string{"NetworkError"}},
+			expect: "network",
+		},
+		{
+			name:   "other",
+			err:    errors.New("any"),
+			expect: "other",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, classifyError(test.err))
+		})
+	}
+}
+
+func TestReportDuration(t *testing.T) {
+	assert.NotPanics(t, func() {
+		reportDuration(findOne, "collection", time.Millisecond, nil)
+		reportDuration(findOne, "collection", time.Millisecond, errors.New("any"))
+	})
+}
@@ -95,6 +95,21 @@ func (m *Model) StartSession(opts ...*mopt.SessionOptions) (sess mongo.Session,
 	return
 }
 
+// Transact runs fn in a transaction on a new session, and ends the session
+// after fn returns. The transaction commits on nil error and aborts otherwise,
+// with the same breaker and slowlog instrumentation as the other calls.
+func (m *Model) Transact(ctx context.Context,
+	fn func(sessCtx mongo.SessionContext) (interface{}, error),
+	opts ...*mopt.TransactionOptions) (interface{}, error) {
+	sess, err := m.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.EndSession(ctx)
+
+	return sess.WithTransaction(ctx, fn, opts...)
+}
+
 // Aggregate executes an aggregation pipeline.
 func (m *Model) Aggregate(ctx context.Context, v, pipeline interface{}, opts ...*mopt.AggregateOptions) error {
 	cur, err := m.Collection.Aggregate(ctx, pipeline, opts...)
@@ -182,7 +197,7 @@ func (m *Model) FindOneAndUpdate(ctx context.Context, v, filter, update interfac
 
 // AbortTransaction implements the mongo.Session interface.
 func (w *wrappedSession) AbortTransaction(ctx context.Context) (err error) {
-	ctx, span := startSpan(ctx, abortTransaction)
+	ctx, span := startSpan(ctx, w.name, abortTransaction)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -199,7 +214,7 @@ func (w *wrappedSession) AbortTransaction(ctx context.Context) (err error) {
 
 // CommitTransaction implements the mongo.Session interface.
 func (w *wrappedSession) CommitTransaction(ctx context.Context) (err error) {
-	ctx, span := startSpan(ctx, commitTransaction)
+	ctx, span := startSpan(ctx, w.name, commitTransaction)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -220,7 +235,7 @@ func (w *wrappedSession) WithTransaction(
 	fn func(sessCtx mongo.SessionContext) (interface{}, error),
 	opts ...*mopt.TransactionOptions,
 ) (res interface{}, err error) {
-	ctx, span := startSpan(ctx, withTransaction)
+	ctx, span := startSpan(ctx, w.name, withTransaction)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -241,7 +256,7 @@ func (w *wrappedSession) WithTransaction(
 // EndSession implements the mongo.Session interface.
 func (w *wrappedSession) EndSession(ctx context.Context) {
 	var err error
-	ctx, span := startSpan(ctx, endSession)
+	ctx, span := startSpan(ctx, w.name, endSession)
 	defer func() {
 		endSpan(span, err)
 	}()
@@ -2,6 +2,7 @@ package mon
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -241,3 +242,23 @@ func createModel(mt *mtest.T) *Model {
 func triggerBreaker(m *Model) {
 	m.Collection.(*decoratedCollection).brk = new(dropBreaker)
 }
+
+func TestModel_Transact(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("test", func(mt *mtest.T) {
+		m := createModel(mt)
+		res, err := m.Transact(context.Background(), func(sessCtx mongo.SessionContext) (interface{}, error) {
+			sessCtx.Client().Database("1")
+			return 1, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, res)
+
+		_, err = m.Transact(context.Background(), func(sessCtx mongo.SessionContext) (interface{}, error) {
+			return nil, errors.New("dummy")
+		})
+		assert.NotNil(t, err)
+	})
+}
@@ -11,15 +11,20 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
-var mongoCmdAttributeKey = attribute.Key("mongo.cmd")
+var (
+	mongoCmdAttributeKey        = attribute.Key("mongo.cmd")
+	mongoCollectionAttributeKey = attribute.Key("mongo.collection")
+)
 
-func startSpan(ctx context.Context, cmd string) (context.Context, oteltrace.Span) {
+func startSpan(ctx context.Context, name, cmd string) (context.Context, oteltrace.Span) {
 	tracer := otel.GetTracerProvider().Tracer(trace.TraceName)
 	ctx, span := tracer.Start(ctx,
 		spanName,
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(trace.ContextAttributes(ctx)...),
 	)
-	span.SetAttributes(mongoCmdAttributeKey.String(cmd))
+	span.SetAttributes(mongoCmdAttributeKey.String(cmd),
+		mongoCollectionAttributeKey.String(name))
 	return ctx, span
 }
 
@@ -18,6 +18,7 @@ func FormatAddr(hosts []string) string {
 
 func logDuration(ctx context.Context, name, method string, startTime time.Duration, err error) {
 	duration := timex.Since(startTime)
+	reportDuration(method, name, duration, err)
 	logger := logx.WithContext(ctx).WithDuration(duration)
 	if err != nil {
 		logger.Infof("mongo(%s) - %s - fail(%s)", name, method, err.Error())
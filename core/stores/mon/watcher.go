@@ -0,0 +1,195 @@
+package mon
+
+import (
+	"context"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/threading"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mopt "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// operation type of the events that invalidate a change stream.
+	invalidateOperation = "invalidate"
+	// delay before recreating a broken change stream.
+	rewatchDelay = time.Second
+)
+
+type (
+	// A ChangeEvent is a decoded change stream event.
+	ChangeEvent struct {
+		OperationType     string   `bson:"operationType"`
+		DocumentKey       bson.Raw `bson:"documentKey"`
+		FullDocument      bson.Raw `bson:"fullDocument"`
+		UpdateDescription bson.Raw `bson:"updateDescription"`
+	}
+
+	// ChangeHandler handles a single change stream event,
+	// the resume token is only advanced on nil error.
+	ChangeHandler func(ctx context.Context, event ChangeEvent) error
+
+	// A TokenStore persists change stream resume tokens across restarts.
+	TokenStore interface {
+		LoadResumeToken(ctx context.Context) (bson.Raw, error)
+		SaveResumeToken(ctx context.Context, token bson.Raw) error
+	}
+
+	// WatcherOption defines the method to customize a Watcher.
+	WatcherOption func(w *Watcher)
+
+	// A Watcher is a managed change stream subscription on a collection,
+	// it recreates the stream on errors and invalidate events,
+	// and resumes from the persisted token if a TokenStore is set.
+	Watcher struct {
+		model    *Model
+		handler  ChangeHandler
+		pipeline interface{}
+		tokens   TokenStore
+		lookup   bool
+		ctx      context.Context
+		cancel   context.CancelFunc
+		group    *threading.RoutineGroup
+	}
+)
+
+// NewWatcher returns a Watcher on m with given handler.
+func (m *Model) NewWatcher(handler ChangeHandler, opts ...WatcherOption) *Watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Watcher{
+		model:    m,
+		handler:  handler,
+		pipeline: mongo.Pipeline{},
+		ctx:      ctx,
+		cancel:   cancel,
+		group:    threading.NewRoutineGroup(),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// WithFullDocument makes the Watcher deliver the post-image of updated
+// documents in ChangeEvent.FullDocument.
+func WithFullDocument() WatcherOption {
+	return func(w *Watcher) {
+		w.lookup = true
+	}
+}
+
+// WithPipeline customizes the Watcher with given aggregation pipeline
+// to filter or project the events.
+func WithPipeline(pipeline interface{}) WatcherOption {
+	return func(w *Watcher) {
+		w.pipeline = pipeline
+	}
+}
+
+// WithTokenStore customizes the Watcher with given store
+// to persist resume tokens across restarts.
+func WithTokenStore(store TokenStore) WatcherOption {
+	return func(w *Watcher) {
+		w.tokens = store
+	}
+}
+
+// Start starts watching the collection, until Stop is called.
+func (w *Watcher) Start() {
+	w.group.RunSafe(w.watchLoop)
+}
+
+// Stop stops the Watcher and waits for the inflight handler to return.
+func (w *Watcher) Stop() {
+	w.cancel()
+	w.group.Wait()
+}
+
+func (w *Watcher) watchLoop() {
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		default:
+		}
+
+		stream, err := w.watch()
+		if err != nil {
+			logx.Errorf("mon watcher: failed to watch %s, error: %v", w.model.name, err)
+			w.waitRewatch()
+			continue
+		}
+
+		w.consume(stream)
+		// use a fresh context, w.ctx might have been canceled.
+		if err := stream.Close(context.Background()); err != nil {
+			logx.Errorf("mon watcher: failed to close stream on %s, error: %v", w.model.name, err)
+		}
+	}
+}
+
+func (w *Watcher) watch() (*mongo.ChangeStream, error) {
+	opts := mopt.ChangeStream()
+	if w.lookup {
+		opts.SetFullDocument(mopt.UpdateLookup)
+	}
+	if w.tokens != nil {
+		token, err := w.tokens.LoadResumeToken(w.ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(token) > 0 {
+			// StartAfter also resumes after invalidate events,
+			// unlike ResumeAfter.
+			opts.SetStartAfter(token)
+		}
+	}
+
+	return w.model.Collection.Watch(w.ctx, w.pipeline, opts)
+}
+
+func (w *Watcher) consume(stream *mongo.ChangeStream) {
+	for stream.Next(w.ctx) {
+		var event ChangeEvent
+		if err := stream.Decode(&event); err != nil {
+			logx.Errorf("mon watcher: failed to decode event on %s, error: %v", w.model.name, err)
+			continue
+		}
+
+		if event.OperationType == invalidateOperation {
+			// persist the invalidate token and recreate the stream.
+			w.saveToken(stream.ResumeToken())
+			return
+		}
+
+		if err := w.handler(w.ctx, event); err != nil {
+			logx.Errorf("mon watcher: handler failed on %s, error: %v", w.model.name, err)
+			continue
+		}
+
+		w.saveToken(stream.ResumeToken())
+	}
+}
+
+func (w *Watcher) saveToken(token bson.Raw) {
+	if w.tokens == nil || len(token) == 0 {
+		return
+	}
+
+	if err := w.tokens.SaveResumeToken(w.ctx, token); err != nil {
+		logx.Errorf("mon watcher: failed to save resume token on %s, error: %v", w.model.name, err)
+	}
+}
+
+func (w *Watcher) waitRewatch() {
+	timer := time.NewTimer(rewatchDelay)
+	defer timer.Stop()
+
+	select {
+	case <-w.ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,76 @@
+package mon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+type memoryTokenStore struct {
+	lock  sync.Mutex
+	token bson.Raw
+}
+
+func (s *memoryTokenStore) LoadResumeToken(_ context.Context) (bson.Raw, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.token, nil
+}
+
+func (s *memoryTokenStore) SaveResumeToken(_ context.Context, token bson.Raw) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.token = token
+	return nil
+}
+
+func TestModel_NewWatcher(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("test", func(mt *mtest.T) {
+		m := createModel(mt)
+		store := new(memoryTokenStore)
+		w := m.NewWatcher(func(ctx context.Context, event ChangeEvent) error {
+			return nil
+		}, WithFullDocument(), WithPipeline(bson.A{}), WithTokenStore(store))
+		assert.True(t, w.lookup)
+		assert.NotNil(t, w.tokens)
+
+		// the mock client fails the watch, the watcher keeps retrying
+		// until stopped.
+		w.Start()
+		time.Sleep(time.Millisecond * 50)
+		w.Stop()
+	})
+}
+
+func TestWatcher_SaveToken(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("test", func(mt *mtest.T) {
+		m := createModel(mt)
+		store := new(memoryTokenStore)
+		w := m.NewWatcher(func(ctx context.Context, event ChangeEvent) error {
+			return nil
+		}, WithTokenStore(store))
+
+		token := bson.Raw([]byte{5, 0, 0, 0, 0})
+		w.saveToken(token)
+		saved, err := store.LoadResumeToken(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, token, saved)
+
+		// empty tokens are not persisted.
+		w.saveToken(nil)
+		saved, err = store.LoadResumeToken(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, token, saved)
+	})
+}
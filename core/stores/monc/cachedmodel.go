@@ -3,6 +3,7 @@ package monc
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/qkbyte/go-zero/core/stores/cache"
 	"github.com/qkbyte/go-zero/core/stores/mon"
@@ -103,6 +104,27 @@ func (mm *Model) DeleteOneNoCache(ctx context.Context, filter interface{},
 	return mm.Model.DeleteOne(ctx, filter, opts...)
 }
 
+// DeleteMany deletes the documents that match the filter, and removes the given keys from cache.
+func (mm *Model) DeleteMany(ctx context.Context, keys []string, filter interface{},
+	opts ...*mopt.DeleteOptions) (int64, error) {
+	val, err := mm.Model.DeleteMany(ctx, filter, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := mm.DelCache(ctx, keys...); err != nil {
+		return 0, err
+	}
+
+	return val, nil
+}
+
+// DeleteManyNoCache deletes the documents that match the filter.
+func (mm *Model) DeleteManyNoCache(ctx context.Context, filter interface{},
+	opts ...*mopt.DeleteOptions) (int64, error) {
+	return mm.Model.DeleteMany(ctx, filter, opts...)
+}
+
 // FindOne unmarshals a record into v with given key and query.
 func (mm *Model) FindOne(ctx context.Context, key string, v, filter interface{},
 	opts ...*mopt.FindOneOptions) error {
@@ -117,6 +139,24 @@ func (mm *Model) FindOneNoCache(ctx context.Context, v, filter interface{},
 	return mm.Model.FindOne(ctx, v, filter, opts...)
 }
 
+// FindOneWithExpire unmarshals a record into v with given key and query,
+// and caches it with given expire on misses, overriding the configured expiry.
+// Not-found records are not cached with the placeholder on this path.
+func (mm *Model) FindOneWithExpire(ctx context.Context, key string, v, filter interface{},
+	expire time.Duration, opts ...*mopt.FindOneOptions) error {
+	if err := mm.cache.GetCtx(ctx, key, v); err == nil {
+		return nil
+	} else if err != ErrNotFound {
+		return err
+	}
+
+	if err := mm.Model.FindOne(ctx, v, filter, opts...); err != nil {
+		return err
+	}
+
+	return mm.cache.SetWithExpireCtx(ctx, key, v, expire)
+}
+
 // FindOneAndDelete deletes the document with given filter, and unmarshals it into v.
 func (mm *Model) FindOneAndDelete(ctx context.Context, key string, v, filter interface{},
 	opts ...*mopt.FindOneAndDeleteOptions) error {
@@ -149,6 +189,19 @@ func (mm *Model) FindOneAndReplaceNoCache(ctx context.Context, v, filter interfa
 	return mm.Model.FindOneAndReplace(ctx, v, filter, replacement, opts...)
 }
 
+// FindOneAndReplaceWithCache replaces the document with given filter with replacement,
+// unmarshals the post-image into v, and caches it with given key instead of invalidating.
+func (mm *Model) FindOneAndReplaceWithCache(ctx context.Context, key string, v, filter interface{},
+	replacement interface{}, opts ...*mopt.FindOneAndReplaceOptions) error {
+	// make sure the post-image is returned, so the cache holds the replaced document.
+	opts = append(opts, mopt.FindOneAndReplace().SetReturnDocument(mopt.After))
+	if err := mm.Model.FindOneAndReplace(ctx, v, filter, replacement, opts...); err != nil {
+		return err
+	}
+
+	return mm.cache.SetCtx(ctx, key, v)
+}
+
 // FindOneAndUpdate updates the document with given filter with update, and unmarshals it into v.
 func (mm *Model) FindOneAndUpdate(ctx context.Context, key string, v, filter interface{},
 	update interface{}, opts ...*mopt.FindOneAndUpdateOptions) error {
@@ -165,6 +218,19 @@ func (mm *Model) FindOneAndUpdateNoCache(ctx context.Context, v, filter interfac
 	return mm.Model.FindOneAndUpdate(ctx, v, filter, update, opts...)
 }
 
+// FindOneAndUpdateWithCache updates the document with given filter with update,
+// unmarshals the post-image into v, and caches it with given key instead of invalidating.
+func (mm *Model) FindOneAndUpdateWithCache(ctx context.Context, key string, v, filter interface{},
+	update interface{}, opts ...*mopt.FindOneAndUpdateOptions) error {
+	// make sure the post-image is returned, so the cache holds the updated document.
+	opts = append(opts, mopt.FindOneAndUpdate().SetReturnDocument(mopt.After))
+	if err := mm.Model.FindOneAndUpdate(ctx, v, filter, update, opts...); err != nil {
+		return err
+	}
+
+	return mm.cache.SetCtx(ctx, key, v)
+}
+
 // GetCache unmarshal the cache into v with given key.
 func (mm *Model) GetCache(key string, v interface{}) error {
 	return mm.cache.Get(key, v)
@@ -217,6 +283,12 @@ func (mm *Model) SetCache(key string, v interface{}) error {
 	return mm.cache.Set(key, v)
 }
 
+// SetCacheWithExpire sets the cache with given key and value with expire,
+// overriding the configured expiry.
+func (mm *Model) SetCacheWithExpire(key string, v interface{}, expire time.Duration) error {
+	return mm.cache.SetWithExpire(key, v, expire)
+}
+
 // UpdateByID updates the document with given id with update, and remove the cache.
 func (mm *Model) UpdateByID(ctx context.Context, key string, id, update interface{},
 	opts ...*mopt.UpdateOptions) (*mongo.UpdateResult, error) {
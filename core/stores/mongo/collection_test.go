@@ -343,3 +343,16 @@ func (d *dropBreaker) DoWithFallbackAcceptable(req func() error, fallback func(e
 	acceptable breaker.Acceptable) error {
 	return nil
 }
+
+func (d *dropBreaker) State() breaker.State {
+	return breaker.StateOpen
+}
+
+func (d *dropBreaker) Open() {
+}
+
+func (d *dropBreaker) HalfOpen() {
+}
+
+func (d *dropBreaker) Close() {
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+const (
+	defaultMinReconnect = time.Second * 10
+	defaultMaxReconnect = time.Minute
+	defaultPingInterval = time.Second * 90
+)
+
+type (
+	// Notification is an alias of pq.Notification.
+	Notification = pq.Notification
+
+	// NotificationHandler handles a single notification,
+	// payload is empty with notifications sent without one.
+	NotificationHandler func(channel, payload string)
+
+	// ListenerOption defines the method to customize a Listener.
+	ListenerOption func(l *Listener)
+
+	// A Listener is a LISTEN/NOTIFY subscription, the underlying connection
+	// is re-established with backoff on failures, and notifications sent
+	// while disconnected are lost, like LISTEN/NOTIFY itself.
+	Listener struct {
+		datasource   string
+		handler      NotificationHandler
+		minReconnect time.Duration
+		maxReconnect time.Duration
+		pingInterval time.Duration
+		listener     *pq.Listener
+		ctx          context.Context
+		cancel       context.CancelFunc
+		group        *threading.RoutineGroup
+	}
+)
+
+// NewListener returns a Listener on given datasource with given handler.
+func NewListener(datasource string, handler NotificationHandler,
+	opts ...ListenerOption) *Listener {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Listener{
+		datasource:   datasource,
+		handler:      handler,
+		minReconnect: defaultMinReconnect,
+		maxReconnect: defaultMaxReconnect,
+		pingInterval: defaultPingInterval,
+		ctx:          ctx,
+		cancel:       cancel,
+		group:        threading.NewRoutineGroup(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// WithPingInterval customizes a Listener with given interval to ping
+// the server while idle, detecting dead connections early.
+func WithPingInterval(interval time.Duration) ListenerOption {
+	return func(l *Listener) {
+		l.pingInterval = interval
+	}
+}
+
+// WithReconnectInterval customizes a Listener with given backoff bounds
+// on re-establishing the connection.
+func WithReconnectInterval(min, max time.Duration) ListenerOption {
+	return func(l *Listener) {
+		l.minReconnect = min
+		l.maxReconnect = max
+	}
+}
+
+// Listen subscribes to the given channels and dispatches notifications
+// to the handler, until Close is called.
+func (l *Listener) Listen(channels ...string) error {
+	l.listener = pq.NewListener(l.datasource, l.minReconnect, l.maxReconnect,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				logx.Errorf("pg listener: event: %d, error: %v", event, err)
+			}
+		})
+	for _, channel := range channels {
+		if err := l.listener.Listen(channel); err != nil {
+			return err
+		}
+	}
+
+	l.group.RunSafe(l.notifyLoop)
+	return nil
+}
+
+// Close stops the Listener and closes the underlying connection.
+func (l *Listener) Close() error {
+	l.cancel()
+	l.group.Wait()
+	if l.listener == nil {
+		return nil
+	}
+
+	return l.listener.Close()
+}
+
+func (l *Listener) notifyLoop() {
+	ticker := time.NewTicker(l.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case notification := <-l.listener.Notify:
+			l.dispatch(notification)
+		case <-ticker.C:
+			if err := l.listener.Ping(); err != nil {
+				logx.Errorf("pg listener: ping failed, error: %v", err)
+			}
+		}
+	}
+}
+
+func (l *Listener) dispatch(notification *pq.Notification) {
+	// nil notifications are delivered on reconnects.
+	if notification == nil {
+		return
+	}
+
+	threading.RunSafe(func() {
+		l.handler(notification.Channel, notification.Extra)
+	})
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListener(t *testing.T) {
+	var notified bool
+	l := NewListener("postgres://localhost:5432/test", func(channel, payload string) {
+		notified = true
+		assert.Equal(t, "jobs", channel)
+		assert.Equal(t, "wake", payload)
+	}, WithReconnectInterval(time.Second, time.Second*10), WithPingInterval(time.Minute))
+	assert.Equal(t, time.Second, l.minReconnect)
+	assert.Equal(t, time.Second*10, l.maxReconnect)
+	assert.Equal(t, time.Minute, l.pingInterval)
+
+	// nil notifications from reconnects are skipped.
+	l.dispatch(nil)
+	assert.False(t, notified)
+	l.dispatch(&pq.Notification{Channel: "jobs", Extra: "wake"})
+	assert.True(t, notified)
+
+	// closing before Listen is a no-op.
+	assert.Nil(t, l.Close())
+}
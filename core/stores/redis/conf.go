@@ -1,6 +1,15 @@
 package redis
 
-import "errors"
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/logx"
+)
 
 var (
 	// ErrEmptyHost is an error that indicates no redis host is set.
@@ -14,10 +23,20 @@ var (
 type (
 	// A RedisConf is a redis config.
 	RedisConf struct {
-		Host string
-		Type string `json:",default=node,options=node|cluster"`
-		Pass string `json:",optional"`
-		Tls  bool   `json:",optional"`
+		Host         string
+		Type         string `json:",default=node,options=node|cluster"`
+		User         string `json:",optional"`
+		Pass         string `json:",optional"`
+		Tls          bool   `json:",optional"`
+		CaFile       string `json:",optional"`
+		CertFile     string `json:",optional"`
+		KeyFile      string `json:",optional"`
+		PoolSize     int    `json:",optional"`
+		MinIdleConns int    `json:",optional"`
+		// PoolTimeout is how long to wait for a connection with the pool exhausted.
+		PoolTimeout time.Duration `json:",optional"`
+		// IdleTimeout is how long an idle connection stays in the pool.
+		IdleTimeout time.Duration `json:",optional"`
 	}
 
 	// A RedisKeyConf is a redis config with key.
@@ -33,16 +52,65 @@ func (rc RedisConf) NewRedis() *Redis {
 	if rc.Type == ClusterType {
 		opts = append(opts, Cluster())
 	}
+	if len(rc.User) > 0 {
+		opts = append(opts, WithUser(rc.User))
+	}
 	if len(rc.Pass) > 0 {
 		opts = append(opts, WithPass(rc.Pass))
 	}
 	if rc.Tls {
-		opts = append(opts, WithTLS())
+		tlsConfig, err := rc.buildTLSConfig()
+		logx.Must(err)
+		opts = append(opts, WithTLSConfig(tlsConfig))
+	}
+	if rc.PoolSize > 0 {
+		opts = append(opts, WithPoolSize(rc.PoolSize))
+	}
+	if rc.MinIdleConns > 0 {
+		opts = append(opts, WithMinIdleConns(rc.MinIdleConns))
+	}
+	if rc.PoolTimeout > 0 {
+		opts = append(opts, WithPoolTimeout(rc.PoolTimeout))
+	}
+	if rc.IdleTimeout > 0 {
+		opts = append(opts, WithIdleTimeout(rc.IdleTimeout))
 	}
 
 	return New(rc.Host, opts...)
 }
 
+func (rc RedisConf) buildTLSConfig() (*tls.Config, error) {
+	if len(rc.CaFile) == 0 && len(rc.CertFile) == 0 {
+		// keep the previous behavior when only Tls is set.
+		return &tls.Config{
+			InsecureSkipVerify: true,
+		}, nil
+	}
+
+	var config tls.Config
+	if len(rc.CaFile) > 0 {
+		pem, err := os.ReadFile(rc.CaFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %q", rc.CaFile)
+		}
+		config.RootCAs = pool
+	}
+	if len(rc.CertFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(rc.CertFile, rc.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return &config, nil
+}
+
 // Validate validates the RedisConf.
 func (rc RedisConf) Validate() error {
 	if len(rc.Host) == 0 {
@@ -108,3 +108,27 @@ func TestRedisKeyConf(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisConfBuildTLSConfig(t *testing.T) {
+	config, err := RedisConf{
+		Host: "localhost:6379",
+		Tls:  true,
+	}.buildTLSConfig()
+	assert.Nil(t, err)
+	assert.True(t, config.InsecureSkipVerify)
+
+	_, err = RedisConf{
+		Host:   "localhost:6379",
+		Tls:    true,
+		CaFile: "not-exist.pem",
+	}.buildTLSConfig()
+	assert.NotNil(t, err)
+
+	_, err = RedisConf{
+		Host:     "localhost:6379",
+		Tls:      true,
+		CertFile: "not-exist.pem",
+		KeyFile:  "not-exist.key",
+	}.buildTLSConfig()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,264 @@
+package redis
+
+import (
+	"sync"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/jsonx"
+	"github.com/qkbyte/go-zero/core/lang"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/stringx"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+const (
+	defaultDelayQueueBatch        = 16
+	defaultDelayQueuePollInterval = time.Second
+	defaultDelayQueueVisibility   = time.Minute
+	defaultDelayQueueMaxAttempts  = 3
+
+	activeKeySuffix = ":active"
+	deadKeySuffix   = ":dead"
+
+	// takeDueScript atomically moves the due tasks from the pending sorted set
+	// to the active sorted set, scored with the visibility deadline.
+	takeDueScript = `local due = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[3])
+for _, task in ipairs(due) do
+    redis.call("ZREM", KEYS[1], task)
+    redis.call("ZADD", KEYS[2], ARGV[2], task)
+end
+return due`
+)
+
+type (
+	// DelayTaskHandler handles a due delayed task, a nil return acks the task.
+	DelayTaskHandler func(body string) error
+
+	// DelayQueueOption defines the method to customize a DelayQueue.
+	DelayQueueOption func(queue *DelayQueue)
+
+	// A DelayQueue runs tasks after a given delay, backed by a redis sorted
+	// set so pending tasks survive restarts. Delivery is at-least-once, tasks
+	// not acked within the visibility timeout are redelivered, and tasks that
+	// exhaust the attempts are pushed to the dead letter list <key>:dead.
+	DelayQueue struct {
+		store             *Redis
+		key               string
+		activeKey         string
+		deadKey           string
+		handler           DelayTaskHandler
+		batch             int
+		pollInterval      time.Duration
+		visibilityTimeout time.Duration
+		maxAttempts       int
+		done              chan lang.PlaceholderType
+		startOnce         sync.Once
+		stopOnce          sync.Once
+	}
+
+	delayTaskEnvelope struct {
+		Id       string `json:"id"`
+		Attempts int    `json:"attempts"`
+		Body     string `json:"body"`
+	}
+)
+
+// NewDelayQueue returns a DelayQueue with given store, key and handler.
+func NewDelayQueue(store *Redis, key string, handler DelayTaskHandler,
+	opts ...DelayQueueOption) *DelayQueue {
+	queue := &DelayQueue{
+		store:             store,
+		key:               key,
+		activeKey:         key + activeKeySuffix,
+		deadKey:           key + deadKeySuffix,
+		handler:           handler,
+		batch:             defaultDelayQueueBatch,
+		pollInterval:      defaultDelayQueuePollInterval,
+		visibilityTimeout: defaultDelayQueueVisibility,
+		maxAttempts:       defaultDelayQueueMaxAttempts,
+		done:              make(chan lang.PlaceholderType),
+	}
+	for _, opt := range opts {
+		opt(queue)
+	}
+
+	return queue
+}
+
+// Delay schedules body to be handled after given delay, returns the task id.
+func (q *DelayQueue) Delay(body string, delay time.Duration) (string, error) {
+	envelope := delayTaskEnvelope{
+		Id:   stringx.RandId(),
+		Body: body,
+	}
+	payload, err := jsonx.MarshalToString(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := q.store.Zadd(q.key, time.Now().Add(delay).UnixMilli(), payload); err != nil {
+		return "", err
+	}
+
+	return envelope.Id, nil
+}
+
+// Start starts polling for due tasks, safe to call more than once.
+func (q *DelayQueue) Start() {
+	q.startOnce.Do(func() {
+		threading.GoSafe(func() {
+			ticker := time.NewTicker(q.pollInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-q.done:
+					return
+				case <-ticker.C:
+					q.poll()
+				}
+			}
+		})
+	})
+}
+
+// Stop stops polling, in-flight tasks are redelivered after the visibility timeout.
+func (q *DelayQueue) Stop() {
+	q.stopOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+func (q *DelayQueue) poll() {
+	q.reapExpired()
+
+	now := time.Now().UnixMilli()
+	deadline := now + q.visibilityTimeout.Milliseconds()
+	resp, err := q.store.Eval(takeDueScript, []string{q.key, q.activeKey},
+		now, deadline, q.batch)
+	if err == Nil {
+		return
+	} else if err != nil {
+		logx.Errorf("Failed to take due tasks from %s, error: %v", q.key, err)
+		return
+	}
+
+	tasks, ok := resp.([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, task := range tasks {
+		payload, ok := task.(string)
+		if !ok {
+			continue
+		}
+
+		threading.GoSafe(func() {
+			q.handleTask(payload)
+		})
+	}
+}
+
+func (q *DelayQueue) handleTask(payload string) {
+	var envelope delayTaskEnvelope
+	if err := jsonx.UnmarshalFromString(payload, &envelope); err != nil {
+		logx.Errorf("Failed to decode task in %s, error: %v", q.key, err)
+		q.deadLetter(payload)
+		return
+	}
+
+	if err := q.handler(envelope.Body); err != nil {
+		// leave the task in the active set,
+		// it's redelivered once the visibility timeout expires.
+		logx.Errorf("Failed to handle task %s in %s, error: %v", envelope.Id, q.key, err)
+		return
+	}
+
+	if _, err := q.store.Zrem(q.activeKey, payload); err != nil {
+		logx.Errorf("Failed to ack task %s in %s, error: %v", envelope.Id, q.key, err)
+	}
+}
+
+// reapExpired redelivers the active tasks whose visibility timeout expired,
+// the ones that exhausted the attempts go to the dead letter list.
+// The new payload is written before the old one is removed,
+// so a crash in between redelivers instead of losing the task.
+func (q *DelayQueue) reapExpired() {
+	now := time.Now().UnixMilli()
+	pairs, err := q.store.ZrangebyscoreWithScores(q.activeKey, 0, now)
+	if err != nil {
+		logx.Errorf("Failed to reap expired tasks in %s, error: %v", q.key, err)
+		return
+	}
+
+	for _, pair := range pairs {
+		var envelope delayTaskEnvelope
+		if err := jsonx.UnmarshalFromString(pair.Key, &envelope); err != nil {
+			logx.Errorf("Failed to decode task in %s, error: %v", q.key, err)
+			q.deadLetter(pair.Key)
+			continue
+		}
+
+		envelope.Attempts++
+		payload, err := jsonx.MarshalToString(envelope)
+		if err != nil {
+			logx.Errorf("Failed to encode task %s in %s, error: %v", envelope.Id, q.key, err)
+			continue
+		}
+
+		if envelope.Attempts >= q.maxAttempts {
+			if _, err := q.store.Lpush(q.deadKey, payload); err != nil {
+				logx.Errorf("Failed to dead letter task %s in %s, error: %v", envelope.Id, q.key, err)
+				continue
+			}
+		} else if _, err := q.store.Zadd(q.key, now, payload); err != nil {
+			logx.Errorf("Failed to redeliver task %s in %s, error: %v", envelope.Id, q.key, err)
+			continue
+		}
+
+		if _, err := q.store.Zrem(q.activeKey, pair.Key); err != nil {
+			logx.Errorf("Failed to remove expired task %s in %s, error: %v", envelope.Id, q.key, err)
+		}
+	}
+}
+
+func (q *DelayQueue) deadLetter(payload string) {
+	if _, err := q.store.Lpush(q.deadKey, payload); err != nil {
+		logx.Errorf("Failed to dead letter task in %s, error: %v", q.key, err)
+		return
+	}
+	if _, err := q.store.Zrem(q.activeKey, payload); err != nil {
+		logx.Errorf("Failed to remove dead task in %s, error: %v", q.key, err)
+	}
+}
+
+// WithDelayQueueBatch customizes how many due tasks are taken per poll.
+func WithDelayQueueBatch(batch int) DelayQueueOption {
+	return func(queue *DelayQueue) {
+		queue.batch = batch
+	}
+}
+
+// WithDelayQueueMaxAttempts customizes how many deliveries a task gets
+// before it's dead lettered.
+func WithDelayQueueMaxAttempts(attempts int) DelayQueueOption {
+	return func(queue *DelayQueue) {
+		queue.maxAttempts = attempts
+	}
+}
+
+// WithDelayQueuePollInterval customizes the interval due tasks are polled with.
+func WithDelayQueuePollInterval(interval time.Duration) DelayQueueOption {
+	return func(queue *DelayQueue) {
+		queue.pollInterval = interval
+	}
+}
+
+// WithDelayQueueVisibilityTimeout customizes how long a delivered task stays
+// invisible before it's redelivered when not acked.
+func WithDelayQueueVisibilityTimeout(timeout time.Duration) DelayQueueOption {
+	return func(queue *DelayQueue) {
+		queue.visibilityTimeout = timeout
+	}
+}
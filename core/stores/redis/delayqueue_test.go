@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// runOnDelayRedis is like runOnRedis, but doesn't disable logx,
+// the hook tests following this file rely on capturing the log output.
+func runOnDelayRedis(t *testing.T, fn func(client *Redis)) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer s.Close()
+
+	fn(New(s.Addr()))
+}
+
+func TestDelayQueue(t *testing.T) {
+	runOnDelayRedis(t, func(client *Redis) {
+		delivered := make(chan string, 1)
+		queue := NewDelayQueue(client, "tasks", func(body string) error {
+			delivered <- body
+			return nil
+		}, WithDelayQueuePollInterval(time.Millisecond*10))
+		defer queue.Stop()
+
+		id, err := queue.Delay("send reminder", 0)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, id)
+		queue.Start()
+
+		select {
+		case body := <-delivered:
+			assert.Equal(t, "send reminder", body)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for delivery")
+		}
+
+		// the task gets acked after a successful delivery
+		assert.True(t, waitForEmptyZset(client, queue.activeKey))
+	})
+}
+
+func TestDelayQueue_SurvivesRestart(t *testing.T) {
+	runOnDelayRedis(t, func(client *Redis) {
+		producer := NewDelayQueue(client, "tasks", nil)
+		_, err := producer.Delay("pending work", 0)
+		assert.Nil(t, err)
+
+		delivered := make(chan string, 1)
+		consumer := NewDelayQueue(client, "tasks", func(body string) error {
+			delivered <- body
+			return nil
+		}, WithDelayQueuePollInterval(time.Millisecond*10))
+		defer consumer.Stop()
+		consumer.Start()
+
+		select {
+		case body := <-delivered:
+			assert.Equal(t, "pending work", body)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for delivery")
+		}
+	})
+}
+
+func TestDelayQueue_DeadLetter(t *testing.T) {
+	runOnDelayRedis(t, func(client *Redis) {
+		queue := NewDelayQueue(client, "tasks", func(body string) error {
+			return errors.New("always fails")
+		}, WithDelayQueuePollInterval(time.Millisecond*10),
+			WithDelayQueueVisibilityTimeout(time.Millisecond*20),
+			WithDelayQueueMaxAttempts(2))
+		defer queue.Stop()
+
+		_, err := queue.Delay("poison", 0)
+		assert.Nil(t, err)
+		queue.Start()
+
+		deadline := time.Now().Add(time.Second * 5)
+		for time.Now().Before(deadline) {
+			if count, err := client.Llen(queue.deadKey); err == nil && count > 0 {
+				break
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+
+		count, err := client.Llen(queue.deadKey)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+		assert.True(t, waitForEmptyZset(client, queue.key))
+		assert.True(t, waitForEmptyZset(client, queue.activeKey))
+	})
+}
+
+func waitForEmptyZset(client *Redis, key string) bool {
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		if val, err := client.Zcard(key); err == nil && val == 0 {
+			return true
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return false
+}
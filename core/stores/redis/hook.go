@@ -151,6 +151,7 @@ func (h hook) startSpan(ctx context.Context, cmds ...red.Cmder) context.Context
 	ctx, span := h.tracer.Start(ctx,
 		spanName,
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(trace.ContextAttributes(ctx)...),
 	)
 
 	cmdStrs := make([]string, 0, len(cmds))
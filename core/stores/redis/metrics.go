@@ -20,4 +20,46 @@ var (
 		Help:      "redis client requests error count.",
 		Labels:    []string{"command", "error"},
 	})
+	metricPoolConns = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "pool",
+		Name:      "total_conns",
+		Help:      "total connections in the pool.",
+		Labels:    []string{"addr"},
+	})
+	metricPoolIdleConns = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "pool",
+		Name:      "idle_conns",
+		Help:      "idle connections in the pool.",
+		Labels:    []string{"addr"},
+	})
+	metricPoolStaleConns = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "pool",
+		Name:      "stale_conns",
+		Help:      "stale connections removed from the pool.",
+		Labels:    []string{"addr"},
+	})
+	metricPoolHits = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "pool",
+		Name:      "hits",
+		Help:      "times a free connection was found in the pool.",
+		Labels:    []string{"addr"},
+	})
+	metricPoolMisses = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "pool",
+		Name:      "misses",
+		Help:      "times a free connection was not found in the pool.",
+		Labels:    []string{"addr"},
+	})
+	metricPoolTimeouts = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "pool",
+		Name:      "wait_timeouts",
+		Help:      "times waiting for a connection timed out.",
+		Labels:    []string{"addr"},
+	})
 )
@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"time"
+
+	red "github.com/go-redis/redis/v8"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+const poolStatsInterval = time.Second * 15
+
+// poolStatsProvider is implemented by both red.Client and red.ClusterClient.
+type poolStatsProvider interface {
+	PoolStats() *red.PoolStats
+}
+
+// monitorPoolStats publishes the pool stats of the given client periodically,
+// for capacity planning.
+func monitorPoolStats(client poolStatsProvider, addr string) {
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(poolStatsInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			reportPoolStats(client.PoolStats(), addr)
+		}
+	})
+}
+
+func reportPoolStats(stats *red.PoolStats, addr string) {
+	if stats == nil {
+		return
+	}
+
+	metricPoolConns.Set(float64(stats.TotalConns), addr)
+	metricPoolIdleConns.Set(float64(stats.IdleConns), addr)
+	metricPoolStaleConns.Set(float64(stats.StaleConns), addr)
+	metricPoolHits.Set(float64(stats.Hits), addr)
+	metricPoolMisses.Set(float64(stats.Misses), addr)
+	metricPoolTimeouts.Set(float64(stats.Timeouts), addr)
+}
@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	red "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportPoolStats(t *testing.T) {
+	assert.NotPanics(t, func() {
+		reportPoolStats(nil, "any")
+		reportPoolStats(&red.PoolStats{
+			Hits:       1,
+			Misses:     2,
+			Timeouts:   3,
+			TotalConns: 4,
+			IdleConns:  5,
+			StaleConns: 6,
+		}, "any")
+	})
+}
+
+func TestRedisWithPoolOptions(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer s.Close()
+
+	conf := RedisConf{
+		Host:         s.Addr(),
+		Type:         NodeType,
+		PoolSize:     4,
+		MinIdleConns: 2,
+		PoolTimeout:  time.Second,
+		IdleTimeout:  time.Minute,
+	}
+	client := conf.NewRedis()
+	assert.Equal(t, 4, client.poolSize)
+	assert.Equal(t, 2, client.minIdleConns)
+	assert.Equal(t, time.Second, client.poolTimeout)
+	assert.Equal(t, time.Minute, client.idleTimeout)
+	assert.True(t, client.Ping())
+}
@@ -0,0 +1,133 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	red "github.com/go-redis/redis/v8"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/threading"
+	"github.com/qkbyte/go-zero/core/timex"
+)
+
+const (
+	subscribeCommand  = "subscribe"
+	psubscribeCommand = "psubscribe"
+)
+
+type (
+	// Message is an alias of redis.Message.
+	Message = red.Message
+
+	// MessageHandler defines the method to handle subscribed messages.
+	MessageHandler func(msg *Message)
+
+	// A Subscription represents an active pub/sub subscription.
+	// The underlying connection reconnects automatically,
+	// call Close or cancel the subscribing context to stop receiving.
+	Subscription struct {
+		pubSub *red.PubSub
+		cancel context.CancelFunc
+	}
+)
+
+// Publish is the implementation of redis publish command.
+func (s *Redis) Publish(channel string, message interface{}) (int64, error) {
+	return s.PublishCtx(context.Background(), channel, message)
+}
+
+// PublishCtx is the implementation of redis publish command.
+func (s *Redis) PublishCtx(ctx context.Context, channel string, message interface{}) (
+	val int64, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.Publish(ctx, channel, message).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// Subscribe subscribes given channels, handler is called for each received message.
+func (s *Redis) Subscribe(handler MessageHandler, channels ...string) (*Subscription, error) {
+	return s.SubscribeCtx(context.Background(), handler, channels...)
+}
+
+// SubscribeCtx subscribes given channels, handler is called for each received message.
+func (s *Redis) SubscribeCtx(ctx context.Context, handler MessageHandler,
+	channels ...string) (*Subscription, error) {
+	return s.subscribe(ctx, handler, subscribeCommand, channels)
+}
+
+// PSubscribe subscribes given patterns, handler is called for each received message.
+func (s *Redis) PSubscribe(handler MessageHandler, patterns ...string) (*Subscription, error) {
+	return s.PSubscribeCtx(context.Background(), handler, patterns...)
+}
+
+// PSubscribeCtx subscribes given patterns, handler is called for each received message.
+func (s *Redis) PSubscribeCtx(ctx context.Context, handler MessageHandler,
+	patterns ...string) (*Subscription, error) {
+	return s.subscribe(ctx, handler, psubscribeCommand, patterns)
+}
+
+// Close closes the subscription and releases the underlying connection.
+func (s *Subscription) Close() {
+	s.cancel()
+}
+
+func (s *Redis) subscribe(ctx context.Context, handler MessageHandler, command string,
+	channels []string) (*Subscription, error) {
+	var pubSub *red.PubSub
+	switch s.Type {
+	case ClusterType:
+		conn, err := getCluster(s)
+		if err != nil {
+			return nil, err
+		}
+		if command == psubscribeCommand {
+			pubSub = conn.PSubscribe(ctx, channels...)
+		} else {
+			pubSub = conn.Subscribe(ctx, channels...)
+		}
+	case NodeType:
+		conn, err := getClient(s)
+		if err != nil {
+			return nil, err
+		}
+		if command == psubscribeCommand {
+			pubSub = conn.PSubscribe(ctx, channels...)
+		} else {
+			pubSub = conn.Subscribe(ctx, channels...)
+		}
+	default:
+		return nil, fmt.Errorf("redis type '%s' is not supported", s.Type)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	threading.GoSafe(func() {
+		<-subCtx.Done()
+		if err := pubSub.Close(); err != nil {
+			logx.Errorf("Error occurred on close redis subscription: %s", err)
+		}
+	})
+	threading.GoSafe(func() {
+		for msg := range pubSub.Channel() {
+			msg := msg
+			start := timex.Now()
+			threading.RunSafe(func() {
+				handler(msg)
+			})
+			metricReqDur.Observe(int64(timex.Since(start)/time.Millisecond), command)
+		}
+	})
+
+	return &Subscription{
+		pubSub: pubSub,
+		cancel: cancel,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisPubSub(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		_, err := New(client.Addr, badType()).Publish("channel", "message")
+		assert.NotNil(t, err)
+		_, err = New(client.Addr, badType()).Subscribe(func(msg *Message) {}, "channel")
+		assert.NotNil(t, err)
+
+		messages := make(chan string, 1)
+		sub, err := client.Subscribe(func(msg *Message) {
+			messages <- msg.Payload
+		}, "channel")
+		assert.Nil(t, err)
+		defer sub.Close()
+
+		// miniredis delivers synchronously once the subscription is registered.
+		var received int64
+		for i := 0; i < 10; i++ {
+			received, err = client.Publish("channel", "message")
+			assert.Nil(t, err)
+			if received > 0 {
+				break
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+		assert.Equal(t, int64(1), received)
+
+		select {
+		case payload := <-messages:
+			assert.Equal(t, "message", payload)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+	})
+}
+
+func TestRedisPSubscribe(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		messages := make(chan string, 1)
+		sub, err := client.PSubscribe(func(msg *Message) {
+			messages <- msg.Payload
+		}, "news.*")
+		assert.Nil(t, err)
+		defer sub.Close()
+
+		for i := 0; i < 10; i++ {
+			received, err := client.Publish("news.tech", "patterned")
+			assert.Nil(t, err)
+			if received > 0 {
+				break
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+
+		select {
+		case payload := <-messages:
+			assert.Equal(t, "patterned", payload)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+	})
+}
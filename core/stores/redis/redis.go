@@ -2,8 +2,10 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"time"
 
@@ -30,12 +32,20 @@ var (
 	// ErrNilNode is an error that indicates a nil redis node.
 	ErrNilNode    = errors.New("nil redis node")
 	slowThreshold = syncx.ForAtomicDuration(defaultSlowThreshold)
+
+	// NewScript returns a new Script instance, which keeps the script
+	// and its sha to run with EVALSHA and NOSCRIPT fallback.
+	NewScript = red.NewScript
 )
 
 type (
 	// Option defines the method to customize a Redis.
 	Option func(r *Redis)
 
+	// Dialer defines the method to dial redis connections,
+	// can be used to connect through SSH tunnels or unix sockets.
+	Dialer func(ctx context.Context, network, addr string) (net.Conn, error)
+
 	// A Pair is a key/pair set used in redis zset.
 	Pair struct {
 		Key   string
@@ -44,11 +54,19 @@ type (
 
 	// Redis defines a redis node/cluster. It is thread-safe.
 	Redis struct {
-		Addr string
-		Type string
-		Pass string
-		tls  bool
-		brk  breaker.Breaker
+		Addr         string
+		Type         string
+		User         string
+		Pass         string
+		tls          bool
+		tlsConfig    *tls.Config
+		dialer       Dialer
+		brk          breaker.Breaker
+		accept       func(err error) bool
+		poolSize     int
+		minIdleConns int
+		poolTimeout  time.Duration
+		idleTimeout  time.Duration
 	}
 
 	// RedisNode interface represents a redis node.
@@ -60,6 +78,10 @@ type (
 	GeoLocation = red.GeoLocation
 	// GeoRadiusQuery is used with GeoRadius to query geospatial index.
 	GeoRadiusQuery = red.GeoRadiusQuery
+	// GeoSearchQuery is used with GeoSearch to query geospatial index.
+	GeoSearchQuery = red.GeoSearchQuery
+	// GeoSearchLocationQuery is used with GeoSearchLocation to query geospatial index.
+	GeoSearchLocationQuery = red.GeoSearchLocationQuery
 	// GeoPos is used to represent a geo position.
 	GeoPos = red.GeoPos
 
@@ -71,6 +93,9 @@ type (
 	// ZStore is an alias of redis.ZStore.
 	ZStore = red.ZStore
 
+	// Script is an alias of redis.Script.
+	Script = red.Script
+
 	// IntCmd is an alias of redis.IntCmd.
 	IntCmd = red.IntCmd
 	// FloatCmd is an alias of redis.FloatCmd.
@@ -112,7 +137,7 @@ func (s *Redis) BitCountCtx(ctx context.Context, key string, start, end int64) (
 			End:   end,
 		}).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -132,7 +157,7 @@ func (s *Redis) BitOpAndCtx(ctx context.Context, destKey string, keys ...string)
 
 		val, err = conn.BitOpAnd(ctx, destKey, keys...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -152,7 +177,7 @@ func (s *Redis) BitOpNotCtx(ctx context.Context, destKey, key string) (val int64
 
 		val, err = conn.BitOpNot(ctx, destKey, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -172,7 +197,7 @@ func (s *Redis) BitOpOrCtx(ctx context.Context, destKey string, keys ...string)
 
 		val, err = conn.BitOpOr(ctx, destKey, keys...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -192,7 +217,7 @@ func (s *Redis) BitOpXorCtx(ctx context.Context, destKey string, keys ...string)
 
 		val, err = conn.BitOpXor(ctx, destKey, keys...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -212,7 +237,7 @@ func (s *Redis) BitPosCtx(ctx context.Context, key string, bit, start, end int64
 
 		val, err = conn.BitPos(ctx, key, bit, start, end).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -282,7 +307,7 @@ func (s *Redis) DecrCtx(ctx context.Context, key string) (val int64, err error)
 
 		val, err = conn.Decr(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -302,7 +327,7 @@ func (s *Redis) DecrbyCtx(ctx context.Context, key string, decrement int64) (val
 
 		val, err = conn.DecrBy(ctx, key, decrement).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -327,7 +352,7 @@ func (s *Redis) DelCtx(ctx context.Context, keys ...string) (val int, err error)
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -348,7 +373,7 @@ func (s *Redis) EvalCtx(ctx context.Context, script string, keys []string,
 
 		val, err = conn.Eval(ctx, script, keys, args...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -369,7 +394,30 @@ func (s *Redis) EvalShaCtx(ctx context.Context, sha string, keys []string,
 
 		val, err = conn.EvalSha(ctx, sha, keys, args...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
+
+	return
+}
+
+// ScriptRun runs given script with EVALSHA and loads the script on NOSCRIPT errors,
+// works in cluster mode as well, keys crossing slots are rejected by the server.
+func (s *Redis) ScriptRun(script *Script, keys []string, args ...interface{}) (interface{}, error) {
+	return s.ScriptRunCtx(context.Background(), script, keys, args...)
+}
+
+// ScriptRunCtx runs given script with EVALSHA and loads the script on NOSCRIPT errors,
+// works in cluster mode as well, keys crossing slots are rejected by the server.
+func (s *Redis) ScriptRunCtx(ctx context.Context, script *Script, keys []string,
+	args ...interface{}) (val interface{}, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = script.Run(ctx, conn, keys, args...).Result()
+		return err
+	}, s.acceptable)
 
 	return
 }
@@ -394,7 +442,7 @@ func (s *Redis) ExistsCtx(ctx context.Context, key string) (val bool, err error)
 
 		val = v == 1
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -413,7 +461,7 @@ func (s *Redis) ExpireCtx(ctx context.Context, key string, seconds int) error {
 		}
 
 		return conn.Expire(ctx, key, time.Duration(seconds)*time.Second).Err()
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // Expireat is the implementation of redis expireat command.
@@ -430,7 +478,7 @@ func (s *Redis) ExpireatCtx(ctx context.Context, key string, expireTime int64) e
 		}
 
 		return conn.ExpireAt(ctx, key, time.Unix(expireTime, 0)).Err()
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // GeoAdd is the implementation of redis geoadd command.
@@ -454,7 +502,7 @@ func (s *Redis) GeoAddCtx(ctx context.Context, key string, geoLocation ...*GeoLo
 
 		val = v
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -480,7 +528,7 @@ func (s *Redis) GeoDistCtx(ctx context.Context, key, member1, member2, unit stri
 
 		val = v
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -506,7 +554,7 @@ func (s *Redis) GeoHashCtx(ctx context.Context, key string, members ...string) (
 
 		val = v
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -533,7 +581,7 @@ func (s *Redis) GeoRadiusCtx(ctx context.Context, key string, longitude, latitud
 
 		val = v
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -559,7 +607,7 @@ func (s *Redis) GeoRadiusByMemberCtx(ctx context.Context, key, member string,
 
 		val = v
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -585,7 +633,62 @@ func (s *Redis) GeoPosCtx(ctx context.Context, key string, members ...string) (
 
 		val = v
 		return nil
-	}, acceptable)
+	}, s.acceptable)
+
+	return
+}
+
+// GeoSearch is the implementation of redis geosearch command.
+func (s *Redis) GeoSearch(key string, query *GeoSearchQuery) ([]string, error) {
+	return s.GeoSearchCtx(context.Background(), key, query)
+}
+
+// GeoSearchCtx is the implementation of redis geosearch command.
+func (s *Redis) GeoSearchCtx(ctx context.Context, key string, query *GeoSearchQuery) (
+	val []string, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		v, err := conn.GeoSearch(ctx, key, query).Result()
+		if err != nil {
+			return err
+		}
+
+		val = v
+		return nil
+	}, s.acceptable)
+
+	return
+}
+
+// GeoSearchLocation is the implementation of redis geosearch command,
+// with the coordinates of the matched members returned.
+func (s *Redis) GeoSearchLocation(key string, query *GeoSearchLocationQuery) (
+	[]GeoLocation, error) {
+	return s.GeoSearchLocationCtx(context.Background(), key, query)
+}
+
+// GeoSearchLocationCtx is the implementation of redis geosearch command,
+// with the coordinates of the matched members returned.
+func (s *Redis) GeoSearchLocationCtx(ctx context.Context, key string,
+	query *GeoSearchLocationQuery) (val []GeoLocation, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		v, err := conn.GeoSearchLocation(ctx, key, query).Result()
+		if err != nil {
+			return err
+		}
+
+		val = v
+		return nil
+	}, s.acceptable)
 
 	return
 }
@@ -610,7 +713,7 @@ func (s *Redis) GetCtx(ctx context.Context, key string) (val string, err error)
 		} else {
 			return nil
 		}
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -635,7 +738,7 @@ func (s *Redis) GetBitCtx(ctx context.Context, key string, offset int64) (val in
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -658,7 +761,7 @@ func (s *Redis) GetSetCtx(ctx context.Context, key, value string) (val string, e
 		}
 
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -683,7 +786,7 @@ func (s *Redis) HdelCtx(ctx context.Context, key string, fields ...string) (val
 
 		val = v >= 1
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -703,7 +806,7 @@ func (s *Redis) HexistsCtx(ctx context.Context, key, field string) (val bool, er
 
 		val, err = conn.HExists(ctx, key, field).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -723,7 +826,7 @@ func (s *Redis) HgetCtx(ctx context.Context, key, field string) (val string, err
 
 		val, err = conn.HGet(ctx, key, field).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -743,7 +846,7 @@ func (s *Redis) HgetallCtx(ctx context.Context, key string) (val map[string]stri
 
 		val, err = conn.HGetAll(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -768,7 +871,7 @@ func (s *Redis) HincrbyCtx(ctx context.Context, key, field string, increment int
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -788,7 +891,7 @@ func (s *Redis) HkeysCtx(ctx context.Context, key string) (val []string, err err
 
 		val, err = conn.HKeys(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -813,7 +916,7 @@ func (s *Redis) HlenCtx(ctx context.Context, key string) (val int, err error) {
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -838,7 +941,7 @@ func (s *Redis) HmgetCtx(ctx context.Context, key string, fields ...string) (val
 
 		val = toStrings(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -857,7 +960,7 @@ func (s *Redis) HsetCtx(ctx context.Context, key, field, value string) error {
 		}
 
 		return conn.HSet(ctx, key, field, value).Err()
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // Hsetnx is the implementation of redis hsetnx command.
@@ -875,7 +978,7 @@ func (s *Redis) HsetnxCtx(ctx context.Context, key, field, value string) (val bo
 
 		val, err = conn.HSetNX(ctx, key, field, value).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -899,7 +1002,7 @@ func (s *Redis) HmsetCtx(ctx context.Context, key string, fieldsAndValues map[st
 		}
 
 		return conn.HMSet(ctx, key, vals).Err()
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // Hscan is the implementation of redis hscan command.
@@ -919,7 +1022,7 @@ func (s *Redis) HscanCtx(ctx context.Context, key string, cursor uint64, match s
 
 		keys, cur, err = conn.HScan(ctx, key, cursor, match, count).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -939,7 +1042,7 @@ func (s *Redis) HvalsCtx(ctx context.Context, key string) (val []string, err err
 
 		val, err = conn.HVals(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -959,7 +1062,7 @@ func (s *Redis) IncrCtx(ctx context.Context, key string) (val int64, err error)
 
 		val, err = conn.Incr(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -979,7 +1082,7 @@ func (s *Redis) IncrbyCtx(ctx context.Context, key string, increment int64) (val
 
 		val, err = conn.IncrBy(ctx, key, increment).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -999,7 +1102,7 @@ func (s *Redis) KeysCtx(ctx context.Context, pattern string) (val []string, err
 
 		val, err = conn.Keys(ctx, pattern).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1024,7 +1127,7 @@ func (s *Redis) LlenCtx(ctx context.Context, key string) (val int, err error) {
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1044,7 +1147,7 @@ func (s *Redis) LindexCtx(ctx context.Context, key string, index int64) (val str
 
 		val, err = conn.LIndex(ctx, key, index).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1064,7 +1167,7 @@ func (s *Redis) LpopCtx(ctx context.Context, key string) (val string, err error)
 
 		val, err = conn.LPop(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1089,7 +1192,7 @@ func (s *Redis) LpushCtx(ctx context.Context, key string, values ...interface{})
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1109,7 +1212,7 @@ func (s *Redis) LrangeCtx(ctx context.Context, key string, start, stop int) (val
 
 		val, err = conn.LRange(ctx, key, int64(start), int64(stop)).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1134,7 +1237,7 @@ func (s *Redis) LremCtx(ctx context.Context, key string, count int, value string
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1153,7 +1256,7 @@ func (s *Redis) LtrimCtx(ctx context.Context, key string, start, stop int64) err
 		}
 
 		return conn.LTrim(ctx, key, start, stop).Err()
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // Mget is the implementation of redis mget command.
@@ -1169,6 +1272,11 @@ func (s *Redis) MgetCtx(ctx context.Context, keys ...string) (val []string, err
 			return err
 		}
 
+		if s.Type == ClusterType {
+			val, err = clusterMget(ctx, conn, keys)
+			return err
+		}
+
 		v, err := conn.MGet(ctx, keys...).Result()
 		if err != nil {
 			return err
@@ -1176,11 +1284,36 @@ func (s *Redis) MgetCtx(ctx context.Context, keys ...string) (val []string, err
 
 		val = toStrings(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
 
+// Mset is the implementation of redis mset command.
+func (s *Redis) Mset(kvs map[string]string) error {
+	return s.MsetCtx(context.Background(), kvs)
+}
+
+// MsetCtx is the implementation of redis mset command.
+func (s *Redis) MsetCtx(ctx context.Context, kvs map[string]string) error {
+	return s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		if s.Type == ClusterType {
+			return clusterMset(ctx, conn, kvs)
+		}
+
+		pairs := make([]interface{}, 0, len(kvs)*2)
+		for k, v := range kvs {
+			pairs = append(pairs, k, v)
+		}
+		return conn.MSet(ctx, pairs...).Err()
+	}, s.acceptable)
+}
+
 // Persist is the implementation of redis persist command.
 func (s *Redis) Persist(key string) (bool, error) {
 	return s.PersistCtx(context.Background(), key)
@@ -1196,7 +1329,7 @@ func (s *Redis) PersistCtx(ctx context.Context, key string) (val bool, err error
 
 		val, err = conn.Persist(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1221,7 +1354,7 @@ func (s *Redis) PfaddCtx(ctx context.Context, key string, values ...interface{})
 
 		val = v >= 1
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1241,7 +1374,7 @@ func (s *Redis) PfcountCtx(ctx context.Context, key string) (val int64, err erro
 
 		val, err = conn.PFCount(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1261,7 +1394,7 @@ func (s *Redis) PfmergeCtx(ctx context.Context, dest string, keys ...string) err
 
 		_, err = conn.PFMerge(ctx, dest, keys...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // Ping is the implementation of redis ping command.
@@ -1287,7 +1420,7 @@ func (s *Redis) PingCtx(ctx context.Context) (val bool) {
 
 		val = v == "PONG"
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1308,7 +1441,26 @@ func (s *Redis) PipelinedCtx(ctx context.Context, fn func(Pipeliner) error) erro
 
 		_, err = conn.Pipelined(ctx, fn)
 		return err
-	}, acceptable)
+	}, s.acceptable)
+}
+
+// TxPipelined lets fn execute pipelined commands in a transaction.
+func (s *Redis) TxPipelined(fn func(Pipeliner) error) error {
+	return s.TxPipelinedCtx(context.Background(), fn)
+}
+
+// TxPipelinedCtx lets fn execute pipelined commands wrapped with MULTI/EXEC.
+// Results need to be retrieved by calling Pipeline.Exec()
+func (s *Redis) TxPipelinedCtx(ctx context.Context, fn func(Pipeliner) error) error {
+	return s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.TxPipelined(ctx, fn)
+		return err
+	}, s.acceptable)
 }
 
 // Rpop is the implementation of redis rpop command.
@@ -1326,7 +1478,7 @@ func (s *Redis) RpopCtx(ctx context.Context, key string) (val string, err error)
 
 		val, err = conn.RPop(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1351,7 +1503,7 @@ func (s *Redis) RpushCtx(ctx context.Context, key string, values ...interface{})
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1376,7 +1528,7 @@ func (s *Redis) SaddCtx(ctx context.Context, key string, values ...interface{})
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1397,7 +1549,7 @@ func (s *Redis) ScanCtx(ctx context.Context, cursor uint64, match string, count
 
 		keys, cur, err = conn.Scan(ctx, cursor, match, count).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1422,7 +1574,7 @@ func (s *Redis) SetBitCtx(ctx context.Context, key string, offset int64, value i
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1444,7 +1596,7 @@ func (s *Redis) SscanCtx(ctx context.Context, key string, cursor uint64, match s
 
 		keys, cur, err = conn.SScan(ctx, key, cursor, match, count).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1464,7 +1616,7 @@ func (s *Redis) ScardCtx(ctx context.Context, key string) (val int64, err error)
 
 		val, err = conn.SCard(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1498,7 +1650,7 @@ func (s *Redis) SetCtx(ctx context.Context, key, value string) error {
 		}
 
 		return conn.Set(ctx, key, value, 0).Err()
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // Setex is the implementation of redis setex command.
@@ -1515,7 +1667,7 @@ func (s *Redis) SetexCtx(ctx context.Context, key, value string, seconds int) er
 		}
 
 		return conn.Set(ctx, key, value, time.Duration(seconds)*time.Second).Err()
-	}, acceptable)
+	}, s.acceptable)
 }
 
 // Setnx is the implementation of redis setnx command.
@@ -1533,7 +1685,7 @@ func (s *Redis) SetnxCtx(ctx context.Context, key, value string) (val bool, err
 
 		val, err = conn.SetNX(ctx, key, value, 0).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1553,7 +1705,7 @@ func (s *Redis) SetnxExCtx(ctx context.Context, key, value string, seconds int)
 
 		val, err = conn.SetNX(ctx, key, value, time.Duration(seconds)*time.Second).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1573,7 +1725,7 @@ func (s *Redis) SismemberCtx(ctx context.Context, key string, value interface{})
 
 		val, err = conn.SIsMember(ctx, key, value).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1593,7 +1745,7 @@ func (s *Redis) SmembersCtx(ctx context.Context, key string) (val []string, err
 
 		val, err = conn.SMembers(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1613,7 +1765,7 @@ func (s *Redis) SpopCtx(ctx context.Context, key string) (val string, err error)
 
 		val, err = conn.SPop(ctx, key).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1633,7 +1785,7 @@ func (s *Redis) SrandmemberCtx(ctx context.Context, key string, count int) (val
 
 		val, err = conn.SRandMemberN(ctx, key, int64(count)).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1658,7 +1810,7 @@ func (s *Redis) SremCtx(ctx context.Context, key string, values ...interface{})
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1683,7 +1835,7 @@ func (s *Redis) SunionCtx(ctx context.Context, keys ...string) (val []string, er
 
 		val, err = conn.SUnion(ctx, keys...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1709,7 +1861,7 @@ func (s *Redis) SunionstoreCtx(ctx context.Context, destination string, keys ...
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1729,7 +1881,7 @@ func (s *Redis) SdiffCtx(ctx context.Context, keys ...string) (val []string, err
 
 		val, err = conn.SDiff(ctx, keys...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1755,7 +1907,7 @@ func (s *Redis) SdiffstoreCtx(ctx context.Context, destination string, keys ...s
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1775,7 +1927,7 @@ func (s *Redis) SinterCtx(ctx context.Context, keys ...string) (val []string, er
 
 		val, err = conn.SInter(ctx, keys...).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1801,7 +1953,7 @@ func (s *Redis) SinterstoreCtx(ctx context.Context, destination string, keys ...
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1826,7 +1978,7 @@ func (s *Redis) TtlCtx(ctx context.Context, key string) (val int, err error) {
 
 		val = int(duration / time.Second)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1866,7 +2018,7 @@ func (s *Redis) ZaddFloatCtx(ctx context.Context, key string, score float64, val
 
 		val = v == 1
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1897,7 +2049,7 @@ func (s *Redis) ZaddsCtx(ctx context.Context, key string, ps ...Pair) (val int64
 
 		val = v
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1922,7 +2074,7 @@ func (s *Redis) ZcardCtx(ctx context.Context, key string) (val int, err error) {
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1948,7 +2100,7 @@ func (s *Redis) ZcountCtx(ctx context.Context, key string, start, stop int64) (v
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1974,7 +2126,7 @@ func (s *Redis) ZincrbyCtx(ctx context.Context, key string, increment int64, fie
 
 		val = int64(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -1999,7 +2151,7 @@ func (s *Redis) ZscoreCtx(ctx context.Context, key, value string) (val int64, er
 
 		val = int64(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2019,7 +2171,7 @@ func (s *Redis) ZrankCtx(ctx context.Context, key, field string) (val int64, err
 
 		val, err = conn.ZRank(ctx, key, field).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2044,7 +2196,7 @@ func (s *Redis) ZremCtx(ctx context.Context, key string, values ...interface{})
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2071,7 +2223,7 @@ func (s *Redis) ZremrangebyscoreCtx(ctx context.Context, key string, start, stop
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2097,7 +2249,7 @@ func (s *Redis) ZremrangebyrankCtx(ctx context.Context, key string, start, stop
 
 		val = int(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2118,7 +2270,7 @@ func (s *Redis) ZrangeCtx(ctx context.Context, key string, start, stop int64) (
 
 		val, err = conn.ZRange(ctx, key, start, stop).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2144,7 +2296,7 @@ func (s *Redis) ZrangeWithScoresCtx(ctx context.Context, key string, start, stop
 
 		val = toPairs(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2170,7 +2322,7 @@ func (s *Redis) ZRevRangeWithScoresCtx(ctx context.Context, key string, start, s
 
 		val = toPairs(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2199,7 +2351,7 @@ func (s *Redis) ZrangebyscoreWithScoresCtx(ctx context.Context, key string, star
 
 		val = toPairs(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2237,7 +2389,7 @@ func (s *Redis) ZrangebyscoreWithScoresAndLimitCtx(ctx context.Context, key stri
 
 		val = toPairs(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2258,7 +2410,7 @@ func (s *Redis) ZrevrangeCtx(ctx context.Context, key string, start, stop int64)
 
 		val, err = conn.ZRevRange(ctx, key, start, stop).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2287,7 +2439,7 @@ func (s *Redis) ZrevrangebyscoreWithScoresCtx(ctx context.Context, key string, s
 
 		val = toPairs(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2325,7 +2477,7 @@ func (s *Redis) ZrevrangebyscoreWithScoresAndLimitCtx(ctx context.Context, key s
 
 		val = toPairs(v)
 		return nil
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2345,7 +2497,7 @@ func (s *Redis) ZrevrankCtx(ctx context.Context, key, field string) (val int64,
 
 		val, err = conn.ZRevRank(ctx, key, field).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2366,7 +2518,7 @@ func (s *Redis) ZunionstoreCtx(ctx context.Context, dest string, store *ZStore)
 
 		val, err = conn.ZUnionStore(ctx, dest, store).Result()
 		return err
-	}, acceptable)
+	}, s.acceptable)
 
 	return
 }
@@ -2390,6 +2542,14 @@ func WithPass(pass string) Option {
 	}
 }
 
+// WithUser customizes the given Redis with given username,
+// used together with WithPass for Redis 6 ACL authentication.
+func WithUser(user string) Option {
+	return func(r *Redis) {
+		r.User = user
+	}
+}
+
 // WithTLS customizes the given Redis with TLS enabled.
 func WithTLS() Option {
 	return func(r *Redis) {
@@ -2397,6 +2557,84 @@ func WithTLS() Option {
 	}
 }
 
+// WithTLSConfig customizes the given Redis with given TLS config,
+// and enables TLS implicitly.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(r *Redis) {
+		r.tls = true
+		r.tlsConfig = config
+	}
+}
+
+// WithDialer customizes the given Redis with given dialer.
+func WithDialer(dialer Dialer) Option {
+	return func(r *Redis) {
+		r.dialer = dialer
+	}
+}
+
+// WithPoolSize customizes the given Redis with given pool size,
+// defaults to 10 connections per CPU.
+func WithPoolSize(size int) Option {
+	return func(r *Redis) {
+		r.poolSize = size
+	}
+}
+
+// WithMinIdleConns customizes the given Redis with given number of
+// minimum idle connections kept in the pool.
+func WithMinIdleConns(count int) Option {
+	return func(r *Redis) {
+		r.minIdleConns = count
+	}
+}
+
+// WithPoolTimeout customizes the given Redis with given pool wait timeout,
+// how long to wait for a connection with the pool exhausted.
+func WithPoolTimeout(timeout time.Duration) Option {
+	return func(r *Redis) {
+		r.poolTimeout = timeout
+	}
+}
+
+// WithIdleTimeout customizes the given Redis with given idle timeout,
+// how long an idle connection stays in the pool.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(r *Redis) {
+		r.idleTimeout = timeout
+	}
+}
+
+// WithBreaker customizes the given Redis with given breaker,
+// which can be tuned with breaker.WithWindow etc.
+func WithBreaker(brk breaker.Breaker) Option {
+	return func(r *Redis) {
+		r.brk = brk
+	}
+}
+
+// WithoutBreaker disables the built-in breaker of the given Redis,
+// useful for batch jobs that tolerate slow or failed commands.
+func WithoutBreaker() Option {
+	return func(r *Redis) {
+		r.brk = breaker.NopBreaker()
+	}
+}
+
+// WithAcceptable customizes the given Redis with given accept func,
+// errors accepted by it don't count as failures on the breaker,
+// in addition to the built-in classification.
+func WithAcceptable(accept func(err error) bool) Option {
+	return func(r *Redis) {
+		r.accept = accept
+	}
+}
+
+// acceptable checks if the given error counts as a successful call on the breaker.
+func (s *Redis) acceptable(err error) bool {
+	return acceptable(err) || (s.accept != nil && s.accept(err))
+}
+
 func acceptable(err error) bool {
 	return err == nil || err == red.Nil || err == context.Canceled
 }
@@ -5,12 +5,15 @@ import (
 	"crypto/tls"
 	"errors"
 	"io"
+	"net"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	red "github.com/go-redis/redis/v8"
+	"github.com/qkbyte/go-zero/core/breaker"
 	"github.com/qkbyte/go-zero/core/logx"
 	"github.com/qkbyte/go-zero/core/stringx"
 	"github.com/stretchr/testify/assert"
@@ -1217,3 +1220,120 @@ type mockedNode struct {
 func (n mockedNode) BLPop(_ context.Context, _ time.Duration, _ ...string) *red.StringSliceCmd {
 	return red.NewStringSliceCmd(context.Background(), "foo", "bar")
 }
+
+func TestRedisWithDialer(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer s.Close()
+
+	var dialed int32
+	client := New(s.Addr(), WithDialer(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		atomic.AddInt32(&dialed, 1)
+		var d net.Dialer
+		return d.DialContext(ctx, network, addr)
+	}))
+	assert.Nil(t, client.Set("dialer", "value"))
+	assert.True(t, atomic.LoadInt32(&dialed) > 0)
+}
+
+func TestRedisWithUser(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer s.Close()
+	s.RequireUserAuth("alice", "secret")
+
+	client := New(s.Addr(), WithUser("alice"), WithPass("secret"))
+	assert.Nil(t, client.Set("acl", "value"))
+
+	another, err := miniredis.Run()
+	assert.Nil(t, err)
+	defer another.Close()
+	another.RequireUserAuth("alice", "secret")
+
+	wrong := New(another.Addr(), WithUser("alice"), WithPass("wrong"))
+	assert.NotNil(t, wrong.Set("acl", "value"))
+}
+
+func TestRedis_TxPipelined(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		assert.NotNil(t, New(client.Addr, badType()).TxPipelined(func(pipeliner Pipeliner) error {
+			return nil
+		}))
+		err := client.TxPipelined(
+			func(pipe Pipeliner) error {
+				pipe.Incr(context.Background(), "tx_pipelined_counter")
+				pipe.Expire(context.Background(), "tx_pipelined_counter", time.Hour)
+				return nil
+			},
+		)
+		assert.Nil(t, err)
+		ttl, err := client.Ttl("tx_pipelined_counter")
+		assert.Nil(t, err)
+		assert.Equal(t, 3600, ttl)
+		value, err := client.Get("tx_pipelined_counter")
+		assert.Nil(t, err)
+		assert.Equal(t, "1", value)
+	})
+}
+
+func TestRedisScriptRun(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		script := NewScript(`return redis.call("SET", KEYS[1], ARGV[1])`)
+		_, err := New(client.Addr, badType()).ScriptRun(script, []string{"key"}, "value")
+		assert.NotNil(t, err)
+		// first run loads the script with NOSCRIPT fallback.
+		_, err = client.ScriptRun(script, []string{"key"}, "value")
+		assert.Nil(t, err)
+		// second run hits EVALSHA directly.
+		_, err = client.ScriptRun(script, []string{"key"}, "another")
+		assert.Nil(t, err)
+		val, err := client.Get("key")
+		assert.Nil(t, err)
+		assert.Equal(t, "another", val)
+	})
+}
+
+func TestRedisWithBreaker(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		s := New(client.Addr, WithoutBreaker())
+		assert.Equal(t, "nopBreaker", s.brk.Name())
+		assert.True(t, s.Ping())
+
+		brk := breaker.NewBreaker(breaker.WithName("redis-test"))
+		s = New(client.Addr, WithBreaker(brk))
+		assert.Equal(t, "redis-test", s.brk.Name())
+		assert.True(t, s.Ping())
+	})
+}
+
+func TestRedisWithAcceptable(t *testing.T) {
+	errTimeout := errors.New("i/o timeout")
+	s := New("any", WithAcceptable(func(err error) bool {
+		return err == errTimeout
+	}))
+	assert.True(t, s.acceptable(nil))
+	assert.True(t, s.acceptable(red.Nil))
+	assert.True(t, s.acceptable(errTimeout))
+	assert.False(t, s.acceptable(errors.New("another")))
+
+	s = New("any")
+	assert.False(t, s.acceptable(errTimeout))
+}
+
+func TestRedis_GeoSearch(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		_, err := New(client.Addr, badType()).GeoSearch("sicily", &GeoSearchQuery{
+			Member: "Palermo", Radius: 200, RadiusUnit: "km",
+		})
+		assert.NotNil(t, err)
+		_, err = New(client.Addr, badType()).GeoSearchLocation("sicily", &GeoSearchLocationQuery{
+			GeoSearchQuery: GeoSearchQuery{Member: "Palermo", Radius: 200, RadiusUnit: "km"},
+		})
+		assert.NotNil(t, err)
+		// geosearch is not supported by miniredis
+		_, err = client.GeoSearch("sicily", &GeoSearchQuery{
+			Member: "Palermo", Radius: 200, RadiusUnit: "km",
+		})
+		assert.NotNil(t, err)
+	})
+}
@@ -20,19 +20,29 @@ func getClient(r *Redis) (*red.Client, error) {
 	val, err := clientManager.GetResource(r.Addr, func() (io.Closer, error) {
 		var tlsConfig *tls.Config
 		if r.tls {
-			tlsConfig = &tls.Config{
-				InsecureSkipVerify: true,
+			if r.tlsConfig != nil {
+				tlsConfig = r.tlsConfig
+			} else {
+				tlsConfig = &tls.Config{
+					InsecureSkipVerify: true,
+				}
 			}
 		}
 		store := red.NewClient(&red.Options{
 			Addr:         r.Addr,
+			Username:     r.User,
 			Password:     r.Pass,
 			DB:           defaultDatabase,
 			MaxRetries:   maxRetries,
-			MinIdleConns: idleConns,
+			PoolSize:     r.poolSize,
+			MinIdleConns: minIdleConns(r),
+			PoolTimeout:  r.poolTimeout,
+			IdleTimeout:  r.idleTimeout,
 			TLSConfig:    tlsConfig,
+			Dialer:       r.dialer,
 		})
 		store.AddHook(durationHook)
+		monitorPoolStats(store, r.Addr)
 
 		return store, nil
 	})
@@ -42,3 +52,11 @@ func getClient(r *Redis) (*red.Client, error) {
 
 	return val.(*red.Client), nil
 }
+
+func minIdleConns(r *Redis) int {
+	if r.minIdleConns > 0 {
+		return r.minIdleConns
+	}
+
+	return idleConns
+}
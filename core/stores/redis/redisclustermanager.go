@@ -14,18 +14,28 @@ func getCluster(r *Redis) (*red.ClusterClient, error) {
 	val, err := clusterManager.GetResource(r.Addr, func() (io.Closer, error) {
 		var tlsConfig *tls.Config
 		if r.tls {
-			tlsConfig = &tls.Config{
-				InsecureSkipVerify: true,
+			if r.tlsConfig != nil {
+				tlsConfig = r.tlsConfig
+			} else {
+				tlsConfig = &tls.Config{
+					InsecureSkipVerify: true,
+				}
 			}
 		}
 		store := red.NewClusterClient(&red.ClusterOptions{
 			Addrs:        []string{r.Addr},
+			Username:     r.User,
 			Password:     r.Pass,
 			MaxRetries:   maxRetries,
-			MinIdleConns: idleConns,
+			PoolSize:     r.poolSize,
+			MinIdleConns: minIdleConns(r),
+			PoolTimeout:  r.poolTimeout,
+			IdleTimeout:  r.idleTimeout,
 			TLSConfig:    tlsConfig,
+			Dialer:       r.dialer,
 		})
 		store.AddHook(durationHook)
+		monitorPoolStats(store, r.Addr)
 
 		return store, nil
 	})
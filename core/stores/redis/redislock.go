@@ -4,19 +4,24 @@ import (
 	"context"
 	"math/rand"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	red "github.com/go-redis/redis/v8"
 
+	"github.com/qkbyte/go-zero/core/lang"
 	"github.com/qkbyte/go-zero/core/logx"
 	"github.com/qkbyte/go-zero/core/stringx"
+	"github.com/qkbyte/go-zero/core/threading"
 )
 
 const (
 	randomLen       = 16
 	tolerance       = 500 // milliseconds
 	millisPerSecond = 1000
+	baseRetryDelay  = time.Millisecond * 50
+	maxRetryDelay   = time.Second
 	lockCommand     = `if redis.call("GET", KEYS[1]) == ARGV[1] then
     redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
     return "OK"
@@ -32,10 +37,13 @@ end`
 
 // A RedisLock is a redis lock.
 type RedisLock struct {
-	store   *Redis
-	seconds uint32
-	key     string
-	id      string
+	store     *Redis
+	seconds   uint32
+	key       string
+	id        string
+	watchdog  bool
+	renewLock sync.Mutex
+	renewStop chan lang.PlaceholderType
 }
 
 func init() {
@@ -58,26 +66,43 @@ func (rl *RedisLock) Acquire() (bool, error) {
 
 // AcquireCtx acquires the lock with the given ctx.
 func (rl *RedisLock) AcquireCtx(ctx context.Context) (bool, error) {
-	seconds := atomic.LoadUint32(&rl.seconds)
-	resp, err := rl.store.EvalCtx(ctx, lockCommand, []string{rl.key}, []string{
-		rl.id, strconv.Itoa(int(seconds)*millisPerSecond + tolerance),
-	})
-	if err == red.Nil {
-		return false, nil
-	} else if err != nil {
-		logx.Errorf("Error on acquiring lock for %s, %s", rl.key, err.Error())
-		return false, err
-	} else if resp == nil {
-		return false, nil
+	ok, err := rl.doAcquire(ctx)
+	if ok && rl.watchdog {
+		rl.startWatchdog()
 	}
 
-	reply, ok := resp.(string)
-	if ok && reply == "OK" {
-		return true, nil
+	return ok, err
+}
+
+// AcquireBlockingCtx blocks with backoff until the lock is acquired,
+// an error occurred or ctx is done.
+func (rl *RedisLock) AcquireBlockingCtx(ctx context.Context) error {
+	delay := baseRetryDelay
+	for {
+		ok, err := rl.AcquireCtx(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay + time.Duration(rand.Int63n(int64(delay)))):
+		}
+
+		if delay < maxRetryDelay {
+			delay *= 2
+		}
 	}
+}
 
-	logx.Errorf("Unknown reply when acquiring lock for %s: %v", rl.key, resp)
-	return false, nil
+// EnableWatchdog lets the lock extend itself while the holder is alive,
+// releasing the lock stops the renewal.
+func (rl *RedisLock) EnableWatchdog() {
+	rl.watchdog = true
 }
 
 // Release releases the lock.
@@ -87,6 +112,8 @@ func (rl *RedisLock) Release() (bool, error) {
 
 // ReleaseCtx releases the lock with the given ctx.
 func (rl *RedisLock) ReleaseCtx(ctx context.Context) (bool, error) {
+	rl.stopWatchdog()
+
 	resp, err := rl.store.EvalCtx(ctx, delCommand, []string{rl.key}, []string{rl.id})
 	if err != nil {
 		return false, err
@@ -104,3 +131,72 @@ func (rl *RedisLock) ReleaseCtx(ctx context.Context) (bool, error) {
 func (rl *RedisLock) SetExpire(seconds int) {
 	atomic.StoreUint32(&rl.seconds, uint32(seconds))
 }
+
+func (rl *RedisLock) doAcquire(ctx context.Context) (bool, error) {
+	seconds := atomic.LoadUint32(&rl.seconds)
+	resp, err := rl.store.EvalCtx(ctx, lockCommand, []string{rl.key}, []string{
+		rl.id, strconv.Itoa(int(seconds)*millisPerSecond + tolerance),
+	})
+	if err == red.Nil {
+		return false, nil
+	} else if err != nil {
+		logx.Errorf("Error on acquiring lock for %s, %s", rl.key, err.Error())
+		return false, err
+	} else if resp == nil {
+		return false, nil
+	}
+
+	reply, ok := resp.(string)
+	if ok && reply == "OK" {
+		return true, nil
+	}
+
+	logx.Errorf("Unknown reply when acquiring lock for %s: %v", rl.key, resp)
+	return false, nil
+}
+
+func (rl *RedisLock) renewInterval() time.Duration {
+	seconds := atomic.LoadUint32(&rl.seconds)
+	expiry := time.Duration(int(seconds)*millisPerSecond+tolerance) * time.Millisecond
+	return expiry / 3
+}
+
+func (rl *RedisLock) startWatchdog() {
+	rl.renewLock.Lock()
+	defer rl.renewLock.Unlock()
+
+	if rl.renewStop != nil {
+		return
+	}
+
+	stop := make(chan lang.PlaceholderType)
+	rl.renewStop = stop
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(rl.renewInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				ok, err := rl.doAcquire(context.Background())
+				if err != nil || !ok {
+					logx.Errorf("Failed to extend lock for %s, stop watchdog", rl.key)
+					rl.stopWatchdog()
+					return
+				}
+			}
+		}
+	})
+}
+
+func (rl *RedisLock) stopWatchdog() {
+	rl.renewLock.Lock()
+	defer rl.renewLock.Unlock()
+
+	if rl.renewStop != nil {
+		close(rl.renewStop)
+		rl.renewStop = nil
+	}
+}
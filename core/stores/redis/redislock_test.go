@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -63,3 +64,49 @@ func TestRedisLock_Expired(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestRedisLock_AcquireBlockingCtx(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		holder := NewRedisLock(client, "blockingLock")
+		holder.SetExpire(5)
+		ok, err := holder.Acquire()
+		assert.Nil(t, err)
+		assert.True(t, ok)
+
+		waiter := NewRedisLock(client, "blockingLock")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, waiter.AcquireBlockingCtx(ctx))
+
+		_, err = holder.Release()
+		assert.Nil(t, err)
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		assert.Nil(t, waiter.AcquireBlockingCtx(ctx))
+		_, err = waiter.Release()
+		assert.Nil(t, err)
+	})
+}
+
+func TestRedisLock_Watchdog(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		lock := NewRedisLock(client, "watchdogLock")
+		lock.EnableWatchdog()
+		ok, err := lock.Acquire()
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.NotNil(t, lock.renewStop)
+
+		// renew interval is a third of the expiry, wait for renewals to happen.
+		time.Sleep(time.Millisecond * 700)
+		another := NewRedisLock(client, "watchdogLock")
+		ok, err = another.Acquire()
+		assert.Nil(t, err)
+		assert.False(t, ok)
+
+		released, err := lock.Release()
+		assert.Nil(t, err)
+		assert.True(t, released)
+		assert.Nil(t, lock.renewStop)
+	})
+}
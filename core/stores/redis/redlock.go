@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	red "github.com/go-redis/redis/v8"
+
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/stringx"
+	"github.com/qkbyte/go-zero/core/timex"
+)
+
+const (
+	// minimum drift compensation, the algorithm adds 1% of the expiry on top.
+	clockDriftFloor = time.Millisecond * 2
+)
+
+// A RedLock is a distributed lock over multiple independent redis nodes,
+// it's held only when acquired on a quorum of the nodes, so it survives
+// a single-node failure. See https://redis.io/docs/manual/patterns/distributed-locks/
+type RedLock struct {
+	nodes   []*Redis
+	seconds uint32
+	key     string
+	id      string
+}
+
+// NewRedLock returns a RedLock.
+func NewRedLock(nodes []*Redis, key string) *RedLock {
+	if len(nodes) == 0 {
+		panic("no redis nodes for red lock")
+	}
+
+	return &RedLock{
+		nodes: nodes,
+		key:   key,
+		id:    stringx.Randn(randomLen),
+	}
+}
+
+// Acquire acquires the lock on a quorum of the nodes.
+func (rl *RedLock) Acquire() (bool, error) {
+	return rl.AcquireCtx(context.Background())
+}
+
+// AcquireCtx acquires the lock on a quorum of the nodes with the given ctx.
+func (rl *RedLock) AcquireCtx(ctx context.Context) (bool, error) {
+	seconds := atomic.LoadUint32(&rl.seconds)
+	expiry := time.Duration(int(seconds)*millisPerSecond+tolerance) * time.Millisecond
+	start := timex.Now()
+
+	var acquired int
+	for _, node := range rl.nodes {
+		ok, err := rl.acquireOnNode(ctx, node)
+		if err != nil {
+			logx.Errorf("Error on acquiring red lock for %s, %s", rl.key, err.Error())
+		} else if ok {
+			acquired++
+		}
+	}
+
+	drift := expiry/100 + clockDriftFloor
+	validity := expiry - timex.Since(start) - drift
+	if acquired >= rl.quorum() && validity > 0 {
+		return true, nil
+	}
+
+	// not enough nodes or took too long, give the partial locks back.
+	if _, err := rl.ReleaseCtx(ctx); err != nil {
+		logx.Errorf("Error on releasing red lock for %s, %s", rl.key, err.Error())
+	}
+
+	return false, nil
+}
+
+// Release releases the lock on all the nodes.
+func (rl *RedLock) Release() (bool, error) {
+	return rl.ReleaseCtx(context.Background())
+}
+
+// ReleaseCtx releases the lock on all the nodes with the given ctx,
+// it returns true if released on a quorum of the nodes.
+func (rl *RedLock) ReleaseCtx(ctx context.Context) (bool, error) {
+	var released int
+	var lastErr error
+	for _, node := range rl.nodes {
+		resp, err := node.EvalCtx(ctx, delCommand, []string{rl.key}, []string{rl.id})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if reply, ok := resp.(int64); ok && reply == 1 {
+			released++
+		}
+	}
+
+	if released >= rl.quorum() {
+		return true, nil
+	}
+
+	return false, lastErr
+}
+
+// SetExpire sets the expiration.
+func (rl *RedLock) SetExpire(seconds int) {
+	atomic.StoreUint32(&rl.seconds, uint32(seconds))
+}
+
+func (rl *RedLock) acquireOnNode(ctx context.Context, node *Redis) (bool, error) {
+	seconds := atomic.LoadUint32(&rl.seconds)
+	resp, err := node.EvalCtx(ctx, lockCommand, []string{rl.key}, []string{
+		rl.id, strconv.Itoa(int(seconds)*millisPerSecond + tolerance),
+	})
+	if err == red.Nil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	} else if resp == nil {
+		return false, nil
+	}
+
+	reply, ok := resp.(string)
+	return ok && reply == "OK", nil
+}
+
+func (rl *RedLock) quorum() int {
+	return len(rl.nodes)/2 + 1
+}
@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedLock(t *testing.T) {
+	var nodes []*Redis
+	for i := 0; i < 3; i++ {
+		s, err := miniredis.Run()
+		assert.Nil(t, err)
+		defer s.Close()
+		nodes = append(nodes, New(s.Addr()))
+	}
+
+	assert.Panics(t, func() {
+		NewRedLock(nil, "panicLock")
+	})
+
+	lock := NewRedLock(nodes, "redLock")
+	lock.SetExpire(5)
+	ok, err := lock.Acquire()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	another := NewRedLock(nodes, "redLock")
+	another.SetExpire(5)
+	ok, err = another.Acquire()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	released, err := lock.Release()
+	assert.Nil(t, err)
+	assert.True(t, released)
+
+	ok, err = another.Acquire()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	_, err = another.Release()
+	assert.Nil(t, err)
+}
+
+func TestRedLock_NodeFailure(t *testing.T) {
+	var nodes []*Redis
+	var servers []*miniredis.Miniredis
+	for i := 0; i < 3; i++ {
+		s, err := miniredis.Run()
+		assert.Nil(t, err)
+		defer s.Close()
+		servers = append(servers, s)
+		nodes = append(nodes, New(s.Addr()))
+	}
+
+	// one node down, the lock is still acquirable on the quorum.
+	servers[0].Close()
+	lock := NewRedLock(nodes, "failoverLock")
+	lock.SetExpire(5)
+	ok, err := lock.Acquire()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	_, err = lock.Release()
+	assert.Nil(t, err)
+}
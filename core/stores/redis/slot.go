@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"context"
+	"strings"
+)
+
+const slotCount = 16384
+
+// hashSlot returns the cluster hash slot of given key,
+// implements the CRC16 (XMODEM) based algorithm with hash tag support.
+func hashSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+
+	return int(crc16([]byte(key))) % slotCount
+}
+
+// groupBySlot groups the indexes of given keys by their cluster hash slot,
+// the groups keep the relative order of the keys.
+func groupBySlot(keys []string) [][]int {
+	slots := make(map[int][]int)
+	var order []int
+	for i, key := range keys {
+		slot := hashSlot(key)
+		if _, ok := slots[slot]; !ok {
+			order = append(order, slot)
+		}
+		slots[slot] = append(slots[slot], i)
+	}
+
+	groups := make([][]int, 0, len(order))
+	for _, slot := range order {
+		groups = append(groups, slots[slot])
+	}
+
+	return groups
+}
+
+// clusterMget splits given keys by hash slot to avoid CROSSSLOT errors,
+// and merges the responses in the order of the keys.
+func clusterMget(ctx context.Context, conn RedisNode, keys []string) ([]string, error) {
+	vals := make([]string, len(keys))
+	for _, group := range groupBySlot(keys) {
+		groupKeys := make([]string, 0, len(group))
+		for _, i := range group {
+			groupKeys = append(groupKeys, keys[i])
+		}
+
+		v, err := conn.MGet(ctx, groupKeys...).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		strs := toStrings(v)
+		for j, i := range group {
+			vals[i] = strs[j]
+		}
+	}
+
+	return vals, nil
+}
+
+// clusterMset splits given key/value pairs by hash slot to avoid CROSSSLOT errors.
+func clusterMset(ctx context.Context, conn RedisNode, kvs map[string]string) error {
+	keys := make([]string, 0, len(kvs))
+	for k := range kvs {
+		keys = append(keys, k)
+	}
+
+	for _, group := range groupBySlot(keys) {
+		pairs := make([]interface{}, 0, len(group)*2)
+		for _, i := range group {
+			pairs = append(pairs, keys[i], kvs[keys[i]])
+		}
+
+		if err := conn.MSet(ctx, pairs...).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
@@ -0,0 +1,36 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashSlot(t *testing.T) {
+	// reference values from the cluster spec, CRC16("123456789") == 0x31C3.
+	assert.Equal(t, int(0x31c3)%slotCount, hashSlot("123456789"))
+	// hash tags map to the slot of the tag content only.
+	assert.Equal(t, hashSlot("user1000"), hashSlot("{user1000}.following"))
+	assert.Equal(t, hashSlot("{user1000}.following"), hashSlot("{user1000}.followers"))
+	// empty tag falls back to the whole key.
+	assert.Equal(t, hashSlot("foo{}bar"), int(crc16([]byte("foo{}bar")))%slotCount)
+}
+
+func TestGroupBySlot(t *testing.T) {
+	groups := groupBySlot([]string{"{user1}.name", "{user2}.name", "{user1}.age"})
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, []int{0, 2}, groups[0])
+	assert.Equal(t, []int{1}, groups[1])
+}
+
+func TestMsetMget(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		assert.Nil(t, client.Mset(map[string]string{
+			"mset_a": "1",
+			"mset_b": "2",
+		}))
+		vals, err := client.Mget("mset_a", "mset_b", "mset_missing")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"1", "2", ""}, vals)
+	})
+}
@@ -0,0 +1,451 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	red "github.com/go-redis/redis/v8"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+const (
+	defaultReadCount     = 100
+	defaultBlockTimeout  = time.Second * 5
+	defaultClaimInterval = time.Second * 30
+	defaultMinIdleTime   = time.Minute
+	busyGroupError       = "BUSYGROUP"
+)
+
+type (
+	// XAddArgs is an alias of redis.XAddArgs.
+	XAddArgs = red.XAddArgs
+	// XReadGroupArgs is an alias of redis.XReadGroupArgs.
+	XReadGroupArgs = red.XReadGroupArgs
+	// XPendingExtArgs is an alias of redis.XPendingExtArgs.
+	XPendingExtArgs = red.XPendingExtArgs
+	// XClaimArgs is an alias of redis.XClaimArgs.
+	XClaimArgs = red.XClaimArgs
+	// XMessage is an alias of redis.XMessage.
+	XMessage = red.XMessage
+	// XStream is an alias of redis.XStream.
+	XStream = red.XStream
+	// XPendingExt is an alias of redis.XPendingExt.
+	XPendingExt = red.XPendingExt
+)
+
+// XAdd is the implementation of redis xadd command.
+func (s *Redis) XAdd(args *XAddArgs) (string, error) {
+	return s.XAddCtx(context.Background(), args)
+}
+
+// XAddCtx is the implementation of redis xadd command.
+func (s *Redis) XAddCtx(ctx context.Context, args *XAddArgs) (val string, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XAdd(ctx, args).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// XAck is the implementation of redis xack command.
+func (s *Redis) XAck(stream, group string, ids ...string) (int64, error) {
+	return s.XAckCtx(context.Background(), stream, group, ids...)
+}
+
+// XAckCtx is the implementation of redis xack command.
+func (s *Redis) XAckCtx(ctx context.Context, stream, group string, ids ...string) (
+	val int64, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XAck(ctx, stream, group, ids...).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// XClaim is the implementation of redis xclaim command.
+func (s *Redis) XClaim(args *XClaimArgs) ([]XMessage, error) {
+	return s.XClaimCtx(context.Background(), args)
+}
+
+// XClaimCtx is the implementation of redis xclaim command.
+func (s *Redis) XClaimCtx(ctx context.Context, args *XClaimArgs) (val []XMessage, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XClaim(ctx, args).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// XDel is the implementation of redis xdel command.
+func (s *Redis) XDel(stream string, ids ...string) (int64, error) {
+	return s.XDelCtx(context.Background(), stream, ids...)
+}
+
+// XDelCtx is the implementation of redis xdel command.
+func (s *Redis) XDelCtx(ctx context.Context, stream string, ids ...string) (
+	val int64, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XDel(ctx, stream, ids...).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// XGroupCreateMkStream is the implementation of redis xgroup create command,
+// the stream is created if it doesn't exist.
+func (s *Redis) XGroupCreateMkStream(stream, group, start string) error {
+	return s.XGroupCreateMkStreamCtx(context.Background(), stream, group, start)
+}
+
+// XGroupCreateMkStreamCtx is the implementation of redis xgroup create command,
+// the stream is created if it doesn't exist.
+func (s *Redis) XGroupCreateMkStreamCtx(ctx context.Context, stream, group, start string) error {
+	return s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		return conn.XGroupCreateMkStream(ctx, stream, group, start).Err()
+	}, s.acceptable)
+}
+
+// XLen is the implementation of redis xlen command.
+func (s *Redis) XLen(stream string) (int64, error) {
+	return s.XLenCtx(context.Background(), stream)
+}
+
+// XLenCtx is the implementation of redis xlen command.
+func (s *Redis) XLenCtx(ctx context.Context, stream string) (val int64, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XLen(ctx, stream).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// XPendingExt is the implementation of redis xpending command with extended options.
+func (s *Redis) XPendingExt(args *XPendingExtArgs) ([]XPendingExt, error) {
+	return s.XPendingExtCtx(context.Background(), args)
+}
+
+// XPendingExtCtx is the implementation of redis xpending command with extended options.
+func (s *Redis) XPendingExtCtx(ctx context.Context, args *XPendingExtArgs) (
+	val []XPendingExt, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XPendingExt(ctx, args).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// XRange is the implementation of redis xrange command.
+func (s *Redis) XRange(stream, start, stop string) ([]XMessage, error) {
+	return s.XRangeCtx(context.Background(), stream, start, stop)
+}
+
+// XRangeCtx is the implementation of redis xrange command.
+func (s *Redis) XRangeCtx(ctx context.Context, stream, start, stop string) (
+	val []XMessage, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XRange(ctx, stream, start, stop).Result()
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+// XReadGroup is the implementation of redis xreadgroup command.
+func (s *Redis) XReadGroup(args *XReadGroupArgs) ([]XStream, error) {
+	return s.XReadGroupCtx(context.Background(), args)
+}
+
+// XReadGroupCtx is the implementation of redis xreadgroup command.
+func (s *Redis) XReadGroupCtx(ctx context.Context, args *XReadGroupArgs) (
+	val []XStream, err error) {
+	err = s.brk.DoWithAcceptable(func() error {
+		conn, err := getRedis(s)
+		if err != nil {
+			return err
+		}
+
+		val, err = conn.XReadGroup(ctx, args).Result()
+		if err == red.Nil {
+			err = nil
+		}
+		return err
+	}, s.acceptable)
+
+	return
+}
+
+type (
+	// StreamHandler defines the method to handle stream messages.
+	StreamHandler func(ctx context.Context, msg XMessage) error
+
+	// StreamGroupOption defines the method to customize a StreamGroupConsumer.
+	StreamGroupOption func(c *StreamGroupConsumer)
+
+	// A StreamGroupConsumer consumes a redis stream within a consumer group,
+	// claims entries pending on dead consumers and moves repeatedly failing
+	// entries to a dead-letter stream.
+	StreamGroupConsumer struct {
+		store            *Redis
+		stream           string
+		group            string
+		consumer         string
+		handler          StreamHandler
+		readCount        int64
+		blockTimeout     time.Duration
+		claimInterval    time.Duration
+		minIdleTime      time.Duration
+		maxRetries       int64
+		deadLetterStream string
+		ctx              context.Context
+		cancel           context.CancelFunc
+		routineGroup     *threading.RoutineGroup
+	}
+)
+
+// NewStreamGroupConsumer returns a StreamGroupConsumer.
+func NewStreamGroupConsumer(store *Redis, stream, group, consumer string,
+	handler StreamHandler, opts ...StreamGroupOption) *StreamGroupConsumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &StreamGroupConsumer{
+		store:         store,
+		stream:        stream,
+		group:         group,
+		consumer:      consumer,
+		handler:       handler,
+		readCount:     defaultReadCount,
+		blockTimeout:  defaultBlockTimeout,
+		claimInterval: defaultClaimInterval,
+		minIdleTime:   defaultMinIdleTime,
+		ctx:           ctx,
+		cancel:        cancel,
+		routineGroup:  threading.NewRoutineGroup(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithBlockTimeout customizes the consumer with given xreadgroup block timeout.
+func WithBlockTimeout(timeout time.Duration) StreamGroupOption {
+	return func(c *StreamGroupConsumer) {
+		c.blockTimeout = timeout
+	}
+}
+
+// WithClaimInterval customizes the consumer with given interval and min idle time
+// to claim entries pending on dead consumers.
+func WithClaimInterval(interval, minIdleTime time.Duration) StreamGroupOption {
+	return func(c *StreamGroupConsumer) {
+		c.claimInterval = interval
+		c.minIdleTime = minIdleTime
+	}
+}
+
+// WithDeadLetter customizes the consumer to move entries that reached
+// maxRetries deliveries to given dead-letter stream.
+func WithDeadLetter(stream string, maxRetries int64) StreamGroupOption {
+	return func(c *StreamGroupConsumer) {
+		c.deadLetterStream = stream
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithReadCount customizes the consumer with given count per xreadgroup call.
+func WithReadCount(count int64) StreamGroupOption {
+	return func(c *StreamGroupConsumer) {
+		c.readCount = count
+	}
+}
+
+// Start creates the consumer group if needed and starts consuming,
+// it returns immediately after the consuming loops are started.
+func (c *StreamGroupConsumer) Start() error {
+	if err := c.store.XGroupCreateMkStreamCtx(c.ctx, c.stream, c.group, "0"); err != nil &&
+		!strings.Contains(err.Error(), busyGroupError) {
+		return err
+	}
+
+	c.routineGroup.RunSafe(c.consumeLoop)
+	c.routineGroup.RunSafe(c.claimLoop)
+
+	return nil
+}
+
+// Stop stops consuming and waits for the consuming loops to quit.
+func (c *StreamGroupConsumer) Stop() {
+	c.cancel()
+	c.routineGroup.Wait()
+}
+
+func (c *StreamGroupConsumer) claimLoop() {
+	ticker := time.NewTicker(c.claimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.claimPending()
+		}
+	}
+}
+
+func (c *StreamGroupConsumer) claimPending() {
+	pending, err := c.store.XPendingExtCtx(c.ctx, &XPendingExtArgs{
+		Stream: c.stream,
+		Group:  c.group,
+		Idle:   c.minIdleTime,
+		Start:  "-",
+		End:    "+",
+		Count:  c.readCount,
+	})
+	if err != nil {
+		logx.Errorf("failed to query pending entries on stream %s: %s", c.stream, err)
+		return
+	}
+
+	var ids []string
+	for _, entry := range pending {
+		if c.maxRetries > 0 && entry.RetryCount >= c.maxRetries {
+			c.deadLetter(entry.ID)
+		} else {
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	msgs, err := c.store.XClaimCtx(c.ctx, &XClaimArgs{
+		Stream:   c.stream,
+		Group:    c.group,
+		Consumer: c.consumer,
+		MinIdle:  c.minIdleTime,
+		Messages: ids,
+	})
+	if err != nil {
+		logx.Errorf("failed to claim pending entries on stream %s: %s", c.stream, err)
+		return
+	}
+
+	for _, msg := range msgs {
+		c.handleMessage(msg)
+	}
+}
+
+func (c *StreamGroupConsumer) consumeLoop() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		default:
+		}
+
+		streams, err := c.store.XReadGroupCtx(c.ctx, &XReadGroupArgs{
+			Group:    c.group,
+			Consumer: c.consumer,
+			Streams:  []string{c.stream, ">"},
+			Count:    c.readCount,
+			Block:    c.blockTimeout,
+		})
+		if err != nil {
+			if c.ctx.Err() != nil {
+				return
+			}
+
+			logx.Errorf("failed to read stream %s: %s", c.stream, err)
+			time.Sleep(c.blockTimeout)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				c.handleMessage(msg)
+			}
+		}
+	}
+}
+
+func (c *StreamGroupConsumer) deadLetter(id string) {
+	msgs, err := c.store.XRangeCtx(c.ctx, c.stream, id, id)
+	if err != nil || len(msgs) == 0 {
+		logx.Errorf("failed to load entry %s on stream %s: %s", id, c.stream, err)
+		return
+	}
+
+	if _, err := c.store.XAddCtx(c.ctx, &XAddArgs{
+		Stream: c.deadLetterStream,
+		Values: msgs[0].Values,
+	}); err != nil {
+		logx.Errorf("failed to dead-letter entry %s on stream %s: %s", id, c.stream, err)
+		return
+	}
+
+	if _, err := c.store.XAckCtx(c.ctx, c.stream, c.group, id); err != nil {
+		logx.Errorf("failed to ack dead-lettered entry %s on stream %s: %s", id, c.stream, err)
+	}
+}
+
+func (c *StreamGroupConsumer) handleMessage(msg XMessage) {
+	if err := c.handler(c.ctx, msg); err != nil {
+		// leave the entry pending, it will be retried by the claim loop.
+		logx.Errorf("failed to handle entry %s on stream %s: %s", msg.ID, c.stream, err)
+		return
+	}
+
+	if _, err := c.store.XAckCtx(c.ctx, c.stream, c.group, msg.ID); err != nil {
+		logx.Errorf("failed to ack entry %s on stream %s: %s", msg.ID, c.stream, err)
+	}
+}
@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStreamCommands(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		id, err := client.XAdd(&XAddArgs{
+			Stream: "stream",
+			Values: map[string]interface{}{"event": "created"},
+		})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, id)
+
+		length, err := client.XLen("stream")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), length)
+
+		msgs, err := client.XRange("stream", "-", "+")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(msgs))
+		assert.Equal(t, "created", msgs[0].Values["event"])
+
+		assert.Nil(t, client.XGroupCreateMkStream("stream", "group", "0"))
+
+		streams, err := client.XReadGroup(&XReadGroupArgs{
+			Group:    "group",
+			Consumer: "consumer",
+			Streams:  []string{"stream", ">"},
+			Count:    10,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(streams))
+		assert.Equal(t, 1, len(streams[0].Messages))
+
+		pending, err := client.XPendingExt(&XPendingExtArgs{
+			Stream: "stream",
+			Group:  "group",
+			Start:  "-",
+			End:    "+",
+			Count:  10,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(pending))
+
+		acked, err := client.XAck("stream", "group", id)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), acked)
+
+		deleted, err := client.XDel("stream", id)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+}
+
+func TestStreamGroupConsumer(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		var handled int32
+		consumer := NewStreamGroupConsumer(client, "orders", "group", "consumer",
+			func(ctx context.Context, msg XMessage) error {
+				atomic.AddInt32(&handled, 1)
+				return nil
+			}, WithBlockTimeout(time.Millisecond*50), WithReadCount(10))
+		assert.Nil(t, consumer.Start())
+		defer consumer.Stop()
+
+		_, err := client.XAdd(&XAddArgs{
+			Stream: "orders",
+			Values: map[string]interface{}{"id": "1"},
+		})
+		assert.Nil(t, err)
+
+		waitFor(t, func() bool {
+			return atomic.LoadInt32(&handled) == 1
+		})
+	})
+}
+
+func TestStreamGroupConsumer_DeadLetter(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		consumer := NewStreamGroupConsumer(client, "orders", "group", "consumer",
+			func(ctx context.Context, msg XMessage) error {
+				return errors.New("cannot handle")
+			}, WithBlockTimeout(time.Millisecond*50),
+			WithClaimInterval(time.Millisecond*50, time.Millisecond*10),
+			WithDeadLetter("orders.dead", 1))
+		assert.Nil(t, consumer.Start())
+		defer consumer.Stop()
+
+		_, err := client.XAdd(&XAddArgs{
+			Stream: "orders",
+			Values: map[string]interface{}{"id": "1"},
+		})
+		assert.Nil(t, err)
+
+		waitFor(t, func() bool {
+			length, err := client.XLen("orders.dead")
+			return err == nil && length == 1
+		})
+	})
+}
+
+func waitFor(t *testing.T, fn func() bool) {
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		if fn() {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatal("condition not met before deadline")
+}
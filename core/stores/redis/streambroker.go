@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/queue"
+	"github.com/qkbyte/go-zero/core/stringx"
+)
+
+const streamBodyField = "body"
+
+type (
+	// A StreamBrokerOption customizes a StreamBroker.
+	StreamBrokerOption func(b *StreamBroker)
+
+	// A StreamBroker is a queue.Broker backed by redis streams,
+	// each subscription runs a StreamGroupConsumer, so the subscribers
+	// with the same group share the messages of a topic and the failed
+	// messages are redelivered and dead lettered per the retry policy.
+	StreamBroker struct {
+		store        *Redis
+		policy       queue.RetryPolicy
+		consumerOpts []StreamGroupOption
+	}
+
+	streamSubscription struct {
+		consumer *StreamGroupConsumer
+	}
+)
+
+// NewStreamBroker returns a StreamBroker backed by store.
+func NewStreamBroker(store *Redis, opts ...StreamBrokerOption) *StreamBroker {
+	broker := &StreamBroker{
+		store: store,
+	}
+	for _, opt := range opts {
+		opt(broker)
+	}
+
+	return broker
+}
+
+// WithStreamBlockTimeout customizes the xreadgroup block timeout of the
+// subscriptions.
+func WithStreamBlockTimeout(timeout time.Duration) StreamBrokerOption {
+	return func(b *StreamBroker) {
+		b.consumerOpts = append(b.consumerOpts, WithBlockTimeout(timeout))
+	}
+}
+
+// WithStreamClaimInterval customizes the interval and min idle time to
+// redeliver the messages pending on dead consumers.
+func WithStreamClaimInterval(interval, minIdleTime time.Duration) StreamBrokerOption {
+	return func(b *StreamBroker) {
+		b.consumerOpts = append(b.consumerOpts, WithClaimInterval(interval, minIdleTime))
+	}
+}
+
+// WithStreamRetryPolicy customizes the retry policy of the broker.
+func WithStreamRetryPolicy(policy queue.RetryPolicy) StreamBrokerOption {
+	return func(b *StreamBroker) {
+		b.policy = policy
+	}
+}
+
+// Publish publishes body to the stream of topic.
+func (b *StreamBroker) Publish(topic, body string) error {
+	_, err := b.store.XAdd(&XAddArgs{
+		Stream: topic,
+		Values: map[string]interface{}{
+			streamBodyField: body,
+		},
+	})
+	return err
+}
+
+// Subscribe subscribes handler to the stream of topic within group.
+func (b *StreamBroker) Subscribe(topic, group string, handler queue.Handler) (
+	queue.Subscription, error) {
+	opts := b.consumerOpts
+	if b.policy.MaxAttempts > 0 && len(b.policy.DeadLetterTopic) > 0 {
+		opts = append(opts, WithDeadLetter(b.policy.DeadLetterTopic,
+			int64(b.policy.MaxAttempts)))
+	}
+
+	consumer := NewStreamGroupConsumer(b.store, topic, group, stringx.RandId(),
+		b.wrapHandler(handler), opts...)
+	if err := consumer.Start(); err != nil {
+		return nil, err
+	}
+
+	return &streamSubscription{
+		consumer: consumer,
+	}, nil
+}
+
+func (b *StreamBroker) wrapHandler(handler queue.Handler) StreamHandler {
+	return func(ctx context.Context, msg XMessage) error {
+		body, _ := msg.Values[streamBodyField].(string)
+		return handler(queue.Message{
+			Id:   msg.ID,
+			Body: body,
+			// the retry count is tracked by the consumer group,
+			// the handler only sees the current delivery.
+			Attempts: 1,
+		})
+	}
+}
+
+func (s *streamSubscription) Close() error {
+	s.consumer.Stop()
+	return nil
+}
@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamBroker(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		broker := NewStreamBroker(client, WithStreamBlockTimeout(time.Millisecond*50))
+		var handled int32
+		var body atomic.Value
+		sub, err := broker.Subscribe("orders", "group", func(msg queue.Message) error {
+			body.Store(msg.Body)
+			atomic.AddInt32(&handled, 1)
+			return nil
+		})
+		assert.Nil(t, err)
+		defer sub.Close()
+
+		assert.Nil(t, broker.Publish("orders", "order created"))
+
+		waitFor(t, func() bool {
+			return atomic.LoadInt32(&handled) == 1
+		})
+		assert.Equal(t, "order created", body.Load())
+	})
+}
+
+func TestStreamBroker_DeadLetter(t *testing.T) {
+	runOnRedis(t, func(client *Redis) {
+		broker := NewStreamBroker(client,
+			WithStreamBlockTimeout(time.Millisecond*50),
+			WithStreamClaimInterval(time.Millisecond*50, time.Millisecond*10),
+			WithStreamRetryPolicy(queue.RetryPolicy{
+				MaxAttempts:     1,
+				DeadLetterTopic: "orders.dead",
+			}))
+		sub, err := broker.Subscribe("orders", "group", func(msg queue.Message) error {
+			return errors.New("cannot handle")
+		})
+		assert.Nil(t, err)
+		defer sub.Close()
+
+		assert.Nil(t, broker.Publish("orders", "order created"))
+
+		waitFor(t, func() bool {
+			length, err := client.XLen("orders.dead")
+			return err == nil && length == 1
+		})
+	})
+}
@@ -3,8 +3,13 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
 	"time"
 
+	"github.com/qkbyte/go-zero/core/errorx"
+	"github.com/qkbyte/go-zero/core/mapping"
 	"github.com/qkbyte/go-zero/core/stores/cache"
 	"github.com/qkbyte/go-zero/core/stores/redis"
 	"github.com/qkbyte/go-zero/core/stores/sqlx"
@@ -17,6 +22,11 @@ const cacheSafeGapBetweenIndexAndPrimary = time.Second * 5
 var (
 	// ErrNotFound is an alias of sqlx.ErrNotFound.
 	ErrNotFound = sqlx.ErrNotFound
+	// ErrNotMatchDestination is an alias of sqlx.ErrNotMatchDestination.
+	ErrNotMatchDestination = sqlx.ErrNotMatchDestination
+	// ErrVersionConflict indicates that an optimistic update touched no rows,
+	// the row was updated or deleted concurrently.
+	ErrVersionConflict = errors.New("version conflict on optimistic update")
 
 	// can't use one SingleFlight per conn, because multiple conns may share the same cache key.
 	singleFlights = syncx.NewSingleFlight()
@@ -40,6 +50,13 @@ type (
 	QueryFn func(conn sqlx.SqlConn, v interface{}) error
 	// QueryCtxFn defines the query method.
 	QueryCtxFn func(ctx context.Context, conn sqlx.SqlConn, v interface{}) error
+	// KeyQueryCtxFn defines the query method that loads the row of a single cache key.
+	KeyQueryCtxFn func(ctx context.Context, conn sqlx.SqlConn, v interface{}, key string) error
+	// ManyQueryFn defines the query method that fetches rows by multiple primary keys.
+	ManyQueryFn func(conn sqlx.SqlConn, v interface{}, primaries []interface{}) error
+	// ManyQueryCtxFn defines the query method that fetches rows by multiple primary keys.
+	ManyQueryCtxFn func(ctx context.Context, conn sqlx.SqlConn, v interface{},
+		primaries []interface{}) error
 
 	// A CachedConn is a DB connection with cache capability.
 	CachedConn struct {
@@ -111,6 +128,43 @@ func (cc CachedConn) ExecCtx(ctx context.Context, exec ExecCtxFn, keys ...string
 	return res, nil
 }
 
+// ExecWithVersion runs exec that guards the update with a version column,
+// like `set version = version + 1 where id = ? and version = ?`.
+// If no rows are affected, ErrVersionConflict is returned and the cache is
+// kept, so concurrent updates are detected without select ... for update.
+func (cc CachedConn) ExecWithVersion(exec ExecFn, keys ...string) (sql.Result, error) {
+	execCtx := func(_ context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+		return exec(conn)
+	}
+	return cc.ExecWithVersionCtx(context.Background(), execCtx, keys...)
+}
+
+// ExecWithVersionCtx runs exec that guards the update with a version column,
+// like `set version = version + 1 where id = ? and version = ?`.
+// If no rows are affected, ErrVersionConflict is returned and the cache is
+// kept, so concurrent updates are detected without select ... for update.
+func (cc CachedConn) ExecWithVersionCtx(ctx context.Context, exec ExecCtxFn,
+	keys ...string) (sql.Result, error) {
+	res, err := exec(ctx, cc.db)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, ErrVersionConflict
+	}
+
+	if err := cc.DelCacheCtx(ctx, keys...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // ExecNoCache runs exec with given sql statement, without affecting cache.
 func (cc CachedConn) ExecNoCache(q string, args ...interface{}) (sql.Result, error) {
 	return cc.ExecNoCacheCtx(context.Background(), q, args...)
@@ -122,19 +176,185 @@ func (cc CachedConn) ExecNoCacheCtx(ctx context.Context, q string, args ...inter
 	return cc.db.ExecCtx(ctx, q, args...)
 }
 
+// PreloadKeys warms up the cache with the given keys, typically at service
+// start or after a cache flush. proto returns a fresh value to unmarshal a row
+// into, query loads the row of a missed key from DB. Loads are coordinated
+// with the shared singleflight, so concurrent warm-ups and normal reads share
+// the same DB queries. Keys that are not found in DB are cached as not found.
+func (cc CachedConn) PreloadKeys(ctx context.Context, proto func() interface{},
+	keys []string, query KeyQueryCtxFn) error {
+	var be errorx.BatchError
+	for _, key := range keys {
+		v := proto()
+		if err := cc.cache.TakeCtx(ctx, v, key, func(v interface{}) error {
+			return query(ctx, cc.db, v, key)
+		}); err != nil && err != ErrNotFound {
+			be.Add(fmt.Errorf("preload key %q: %w", key, err))
+		}
+	}
+
+	return be.Err()
+}
+
+// WarmUp loads rows with the given query and populates the cache with them.
+// v must be a pointer to a slice of structs or pointers to structs,
+// keyer maps a loaded row to its cache key.
+func (cc CachedConn) WarmUp(v interface{}, keyer func(row interface{}) string, query QueryFn) error {
+	queryCtx := func(_ context.Context, conn sqlx.SqlConn, v interface{}) error {
+		return query(conn, v)
+	}
+	return cc.WarmUpCtx(context.Background(), v, keyer, queryCtx)
+}
+
+// WarmUpCtx loads rows with the given query and populates the cache with them.
+// v must be a pointer to a slice of structs or pointers to structs,
+// keyer maps a loaded row to its cache key.
+func (cc CachedConn) WarmUpCtx(ctx context.Context, v interface{},
+	keyer func(row interface{}) string, query QueryCtxFn) error {
+	if err := query(ctx, cc.db, v); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if err := mapping.ValidatePtr(&rv); err != nil {
+		return err
+	}
+
+	rve := rv.Elem()
+	if rve.Kind() != reflect.Slice {
+		return ErrNotMatchDestination
+	}
+
+	var be errorx.BatchError
+	for i := 0; i < rve.Len(); i++ {
+		row := rve.Index(i).Interface()
+		key := keyer(row)
+		if err := cc.cache.SetCtx(ctx, key, row); err != nil {
+			be.Add(fmt.Errorf("warm up key %q: %w", key, err))
+		}
+	}
+
+	return be.Err()
+}
+
+// TakeMany unmarshals rows with given primary keys into v, which must be a
+// pointer to a slice of structs or pointers to structs.
+// It gets the cached rows in one round trip, fetches only the missed primaries
+// from DB with a single query, and backfills the cache with the fetched rows.
+// keyer maps a primary key to its cache key, indexer maps a fetched row back
+// to its primary key. Rows that exist in neither cache nor DB are skipped.
+func (cc CachedConn) TakeMany(v interface{}, primaries []interface{},
+	keyer func(primary interface{}) string, indexer func(row interface{}) interface{},
+	query ManyQueryFn) error {
+	queryCtx := func(_ context.Context, conn sqlx.SqlConn, v interface{},
+		missed []interface{}) error {
+		return query(conn, v, missed)
+	}
+	return cc.TakeManyCtx(context.Background(), v, primaries, keyer, indexer, queryCtx)
+}
+
+// TakeManyCtx unmarshals rows with given primary keys into v, which must be a
+// pointer to a slice of structs or pointers to structs.
+// It gets the cached rows in one round trip, fetches only the missed primaries
+// from DB with a single query, and backfills the cache with the fetched rows.
+// keyer maps a primary key to its cache key, indexer maps a fetched row back
+// to its primary key. Rows that exist in neither cache nor DB are skipped.
+func (cc CachedConn) TakeManyCtx(ctx context.Context, v interface{}, primaries []interface{},
+	keyer func(primary interface{}) string, indexer func(row interface{}) interface{},
+	query ManyQueryCtxFn) error {
+	rv := reflect.ValueOf(v)
+	if err := mapping.ValidatePtr(&rv); err != nil {
+		return err
+	}
+
+	rve := rv.Elem()
+	if rve.Kind() != reflect.Slice {
+		return ErrNotMatchDestination
+	}
+
+	ptr := rve.Type().Elem().Kind() == reflect.Ptr
+	base := mapping.Deref(rve.Type().Elem())
+	keys := make([]string, len(primaries))
+	vals := make([]interface{}, len(primaries))
+	for i, primary := range primaries {
+		keys[i] = keyer(primary)
+		vals[i] = reflect.New(base).Interface()
+	}
+
+	missed, err := cc.cache.GetManyCtx(ctx, keys, vals)
+	if err != nil {
+		return err
+	}
+
+	rows := make(map[string]reflect.Value, len(primaries))
+	missedSet := make(map[int]struct{}, len(missed))
+	for _, index := range missed {
+		missedSet[index] = struct{}{}
+	}
+	for i := range primaries {
+		if _, ok := missedSet[i]; !ok {
+			rows[keys[i]] = reflect.ValueOf(vals[i])
+		}
+	}
+
+	if len(missed) > 0 {
+		missedPrimaries := make([]interface{}, 0, len(missed))
+		for _, index := range missed {
+			missedPrimaries = append(missedPrimaries, primaries[index])
+		}
+
+		fetched := reflect.New(reflect.SliceOf(reflect.PtrTo(base)))
+		if err := query(ctx, cc.db, fetched.Interface(), missedPrimaries); err != nil &&
+			err != ErrNotFound {
+			return err
+		}
+
+		fetchedRows := fetched.Elem()
+		for i := 0; i < fetchedRows.Len(); i++ {
+			row := fetchedRows.Index(i)
+			key := keyer(indexer(row.Interface()))
+			if err := cc.cache.SetCtx(ctx, key, row.Interface()); err != nil {
+				return err
+			}
+
+			rows[key] = row
+		}
+	}
+
+	result := reflect.MakeSlice(rve.Type(), 0, len(rows))
+	for _, key := range keys {
+		row, ok := rows[key]
+		if !ok {
+			continue
+		}
+
+		if ptr {
+			result = reflect.Append(result, row)
+		} else {
+			result = reflect.Append(result, row.Elem())
+		}
+	}
+	rve.Set(result)
+
+	return nil
+}
+
 // QueryRow unmarshals into v with given key and query func.
-func (cc CachedConn) QueryRow(v interface{}, key string, query QueryFn) error {
+// opts can be used to override the default cache expiry with cache.WithExpiry.
+func (cc CachedConn) QueryRow(v interface{}, key string, query QueryFn, opts ...cache.Option) error {
 	queryCtx := func(_ context.Context, conn sqlx.SqlConn, v interface{}) error {
 		return query(conn, v)
 	}
-	return cc.QueryRowCtx(context.Background(), v, key, queryCtx)
+	return cc.QueryRowCtx(context.Background(), v, key, queryCtx, opts...)
 }
 
 // QueryRowCtx unmarshals into v with given key and query func.
-func (cc CachedConn) QueryRowCtx(ctx context.Context, v interface{}, key string, query QueryCtxFn) error {
+// opts can be used to override the default cache expiry with cache.WithExpiry.
+func (cc CachedConn) QueryRowCtx(ctx context.Context, v interface{}, key string,
+	query QueryCtxFn, opts ...cache.Option) error {
 	return cc.cache.TakeCtx(ctx, v, key, func(v interface{}) error {
 		return query(ctx, cc.db, v)
-	})
+	}, opts...)
 }
 
 // QueryRowIndex unmarshals into v with given key.
@@ -22,6 +22,7 @@ import (
 	"github.com/qkbyte/go-zero/core/stores/cache"
 	"github.com/qkbyte/go-zero/core/stores/redis"
 	"github.com/qkbyte/go-zero/core/stores/redis/redistest"
+	"github.com/qkbyte/go-zero/core/stores/sqlite"
 	"github.com/qkbyte/go-zero/core/stores/sqlx"
 	"github.com/stretchr/testify/assert"
 )
@@ -606,6 +607,14 @@ func (d dummySqlConn) QueryRowsPartialCtx(ctx context.Context, v interface{}, qu
 	return nil
 }
 
+func (d dummySqlConn) QueryRowsToMaps(v *[]map[string]interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func (d dummySqlConn) QueryRowsToMapsCtx(ctx context.Context, v *[]map[string]interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
 func (d dummySqlConn) TransactCtx(ctx context.Context, fn func(context.Context, sqlx.Session) error) error {
 	return nil
 }
@@ -688,3 +697,139 @@ func (c *trackedConn) TransactCtx(ctx context.Context, fn func(context.Context,
 	c.transactValue = true
 	return c.dummySqlConn.TransactCtx(ctx, fn)
 }
+
+func TestCachedConn_TakeMany(t *testing.T) {
+	r, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	type user struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	keyer := func(primary interface{}) string {
+		return fmt.Sprintf("user#%v", primary)
+	}
+	indexer := func(row interface{}) interface{} {
+		return row.(*user).Id
+	}
+
+	c := NewNodeConn(dummySqlConn{}, r, cache.WithExpiry(time.Second*30))
+	assert.Nil(t, c.SetCache(keyer(2), user{Id: 2, Name: "cached"}))
+
+	var users []user
+	var queriedPrimaries []interface{}
+	err = c.TakeMany(&users, []interface{}{1, 2, 3, 4}, keyer, indexer,
+		func(conn sqlx.SqlConn, v interface{}, primaries []interface{}) error {
+			queriedPrimaries = primaries
+			rows := v.(*[]*user)
+			// primary key 4 doesn't exist in db
+			*rows = append(*rows, &user{Id: 1, Name: "first"}, &user{Id: 3, Name: "third"})
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 3, 4}, queriedPrimaries)
+	assert.Equal(t, []user{
+		{Id: 1, Name: "first"},
+		{Id: 2, Name: "cached"},
+		{Id: 3, Name: "third"},
+	}, users)
+
+	// fetched rows should be backfilled into the cache
+	var cached user
+	assert.Nil(t, c.GetCache(keyer(3), &cached))
+	assert.Equal(t, user{Id: 3, Name: "third"}, cached)
+}
+
+func TestCachedConn_PreloadKeys(t *testing.T) {
+	r, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	c := NewNodeConn(dummySqlConn{}, r, cache.WithExpiry(time.Second*30))
+	var loaded []string
+	err = c.PreloadKeys(context.Background(), func() interface{} {
+		return new(string)
+	}, []string{"key1", "key2"}, func(ctx context.Context, conn sqlx.SqlConn,
+		v interface{}, key string) error {
+		loaded = append(loaded, key)
+		*v.(*string) = "value of " + key
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key1", "key2"}, loaded)
+
+	var value string
+	assert.Nil(t, c.GetCache("key2", &value))
+	assert.Equal(t, "value of key2", value)
+
+	// already cached keys should not be loaded again
+	loaded = nil
+	err = c.PreloadKeys(context.Background(), func() interface{} {
+		return new(string)
+	}, []string{"key1"}, func(ctx context.Context, conn sqlx.SqlConn,
+		v interface{}, key string) error {
+		loaded = append(loaded, key)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, loaded)
+}
+
+func TestCachedConn_WarmUp(t *testing.T) {
+	r, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	type user struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	c := NewNodeConn(dummySqlConn{}, r, cache.WithExpiry(time.Second*30))
+	var users []user
+	err = c.WarmUp(&users, func(row interface{}) string {
+		return fmt.Sprintf("user#%d", row.(user).Id)
+	}, func(conn sqlx.SqlConn, v interface{}) error {
+		*v.(*[]user) = append(*v.(*[]user), user{Id: 1, Name: "first"}, user{Id: 2, Name: "second"})
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+
+	var cached user
+	assert.Nil(t, c.GetCache("user#1", &cached))
+	assert.Equal(t, user{Id: 1, Name: "first"}, cached)
+}
+
+func TestCachedConn_ExecWithVersion(t *testing.T) {
+	r, clean, err := redistest.CreateRedis()
+	assert.Nil(t, err)
+	defer clean()
+
+	conn := sqlite.New("file:sqlc_version_test?mode=memory&cache=shared")
+	_, err = conn.Exec(`create table version_rows (
+		id integer primary key, name text, version integer not null default 0)`)
+	assert.Nil(t, err)
+	_, err = conn.Exec(`insert into version_rows (id, name) values (1, 'initial')`)
+	assert.Nil(t, err)
+
+	c := NewNodeConn(conn, r, cache.WithExpiry(time.Second*30))
+	assert.Nil(t, c.SetCache("version_rows#1", "cached"))
+
+	_, err = c.ExecWithVersion(func(conn sqlx.SqlConn) (sql.Result, error) {
+		return conn.Exec(`update version_rows set name = 'changed', version = version + 1
+			where id = ? and version = ?`, 1, 0)
+	}, "version_rows#1")
+	assert.Nil(t, err)
+
+	var cached string
+	assert.Equal(t, ErrNotFound, c.GetCache("version_rows#1", &cached))
+
+	// stale version must report a conflict
+	_, err = c.ExecWithVersion(func(conn sqlx.SqlConn) (sql.Result, error) {
+		return conn.Exec(`update version_rows set name = 'again', version = version + 1
+			where id = ? and version = ?`, 1, 0)
+	}, "version_rows#1")
+	assert.Equal(t, ErrVersionConflict, err)
+}
@@ -0,0 +1,135 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/qkbyte/go-zero/core/stores/sqlx"
+)
+
+type (
+	// Column mirrors one row of INFORMATION_SCHEMA.COLUMNS,
+	// so tools written against mysql metadata (like goctl) work with sqlite.
+	Column struct {
+		TableName  string  `db:"TABLE_NAME"`
+		ColumnName string  `db:"COLUMN_NAME"`
+		DataType   string  `db:"DATA_TYPE"`
+		ColumnKey  string  `db:"COLUMN_KEY"`
+		Extra      string  `db:"EXTRA"`
+		IsNullable string  `db:"IS_NULLABLE"`
+		Default    *string `db:"COLUMN_DEFAULT"`
+	}
+
+	// IndexColumn mirrors one row of INFORMATION_SCHEMA.STATISTICS.
+	IndexColumn struct {
+		TableName  string `db:"TABLE_NAME"`
+		IndexName  string `db:"INDEX_NAME"`
+		ColumnName string `db:"COLUMN_NAME"`
+		NonUnique  int    `db:"NON_UNIQUE"`
+		SeqInIndex int    `db:"SEQ_IN_INDEX"`
+	}
+
+	tableColumn struct {
+		Cid       int            `db:"cid"`
+		Name      string         `db:"name"`
+		Type      string         `db:"type"`
+		NotNull   int            `db:"notnull"`
+		DfltValue sql.NullString `db:"dflt_value"`
+		Pk        int            `db:"pk"`
+	}
+
+	tableIndex struct {
+		Seq     int    `db:"seq"`
+		Name    string `db:"name"`
+		Unique  int    `db:"unique"`
+		Origin  string `db:"origin"`
+		Partial int    `db:"partial"`
+	}
+
+	indexInfo struct {
+		Seqno int    `db:"seqno"`
+		Cid   int    `db:"cid"`
+		Name  string `db:"name"`
+	}
+)
+
+// ListTables returns the user table names in the sqlite database.
+func ListTables(ctx context.Context, conn sqlx.SqlConn) ([]string, error) {
+	var tables []string
+	err := conn.QueryRowsCtx(ctx, &tables,
+		`select name from sqlite_master where type = 'table' and name not like 'sqlite_%'`)
+	return tables, err
+}
+
+// DescribeColumns returns the columns of the given table,
+// shaped like the rows of INFORMATION_SCHEMA.COLUMNS.
+func DescribeColumns(ctx context.Context, conn sqlx.SqlConn, table string) ([]Column, error) {
+	var raw []tableColumn
+	if err := conn.QueryRowsCtx(ctx, &raw,
+		fmt.Sprintf("pragma table_info(%q)", table)); err != nil {
+		return nil, err
+	}
+
+	columns := make([]Column, 0, len(raw))
+	for _, col := range raw {
+		column := Column{
+			TableName:  table,
+			ColumnName: col.Name,
+			DataType:   strings.ToLower(col.Type),
+			IsNullable: "YES",
+		}
+		if col.DfltValue.Valid {
+			value := col.DfltValue.String
+			column.Default = &value
+		}
+		if col.NotNull != 0 || col.Pk != 0 {
+			column.IsNullable = "NO"
+		}
+		if col.Pk != 0 {
+			column.ColumnKey = "PRI"
+			if strings.EqualFold(col.Type, "integer") {
+				column.Extra = "auto_increment"
+			}
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// DescribeIndexes returns the index columns of the given table,
+// shaped like the rows of INFORMATION_SCHEMA.STATISTICS.
+func DescribeIndexes(ctx context.Context, conn sqlx.SqlConn, table string) ([]IndexColumn, error) {
+	var indexes []tableIndex
+	if err := conn.QueryRowsCtx(ctx, &indexes,
+		fmt.Sprintf("pragma index_list(%q)", table)); err != nil {
+		return nil, err
+	}
+
+	var result []IndexColumn
+	for _, index := range indexes {
+		var cols []indexInfo
+		if err := conn.QueryRowsCtx(ctx, &cols,
+			fmt.Sprintf("pragma index_info(%q)", index.Name)); err != nil {
+			return nil, err
+		}
+
+		nonUnique := 1
+		if index.Unique != 0 {
+			nonUnique = 0
+		}
+		for _, col := range cols {
+			result = append(result, IndexColumn{
+				TableName:  table,
+				IndexName:  index.Name,
+				ColumnName: col.Name,
+				NonUnique:  nonUnique,
+				SeqInIndex: col.Seqno + 1,
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"github.com/qkbyte/go-zero/core/stores/sqlx"
+	// imports the driver, don't remove this comment, golint requires.
+	sqlite3 "modernc.org/sqlite"
+)
+
+const (
+	sqliteDriverName = "sqlite"
+
+	constraintCode           = 19
+	constraintPrimaryKeyCode = 1555
+	constraintUniqueCode     = 2067
+)
+
+// New returns a sqlite connection.
+func New(datasource string, opts ...sqlx.SqlOption) sqlx.SqlConn {
+	opts = append(opts, sqlx.WithAcceptable(sqliteAcceptable))
+	return sqlx.NewSqlConn(sqliteDriverName, datasource, opts...)
+}
+
+func sqliteAcceptable(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	myerr, ok := err.(*sqlite3.Error)
+	if !ok {
+		return false
+	}
+
+	switch myerr.Code() {
+	case constraintCode, constraintPrimaryKeyCode, constraintUniqueCode:
+		return true
+	default:
+		return false
+	}
+}
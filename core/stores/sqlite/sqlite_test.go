@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlite(t *testing.T) {
+	conn := New("file:sqlite_test?mode=memory&cache=shared")
+	assert.NotNil(t, conn)
+
+	_, err := conn.Exec(`create table users (
+		id integer primary key,
+		name varchar(64) not null,
+		mobile varchar(16) not null default '',
+		unique (mobile)
+	)`)
+	assert.Nil(t, err)
+	_, err = conn.Exec(`create index idx_name on users (name)`)
+	assert.Nil(t, err)
+
+	_, err = conn.Exec(`insert into users (name, mobile) values (?, ?)`, "alice", "135")
+	assert.Nil(t, err)
+	_, err = conn.Exec(`insert into users (name, mobile) values (?, ?)`, "bob", "135")
+	assert.NotNil(t, err)
+	assert.True(t, sqliteAcceptable(err))
+
+	tables, err := ListTables(context.Background(), conn)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"users"}, tables)
+
+	columns, err := DescribeColumns(context.Background(), conn, "users")
+	assert.Nil(t, err)
+	assert.Len(t, columns, 3)
+	assert.Equal(t, "id", columns[0].ColumnName)
+	assert.Equal(t, "PRI", columns[0].ColumnKey)
+	assert.Equal(t, "auto_increment", columns[0].Extra)
+	assert.Equal(t, "NO", columns[1].IsNullable)
+
+	indexes, err := DescribeIndexes(context.Background(), conn, "users")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, indexes)
+}
+
+func TestSqliteAcceptable(t *testing.T) {
+	assert.True(t, sqliteAcceptable(nil))
+	assert.False(t, sqliteAcceptable(errors.New("any")))
+}
@@ -0,0 +1,166 @@
+// Package barrier implements the sub-transaction barrier pattern compatible
+// with DTM, so saga / TCC / transactional-message branches built on sqlx
+// sessions are idempotent and safe against null compensation and hanging
+// requests.
+//
+// The barrier table is expected to be like:
+//
+//	create table dtm_barrier (
+//	    id bigint primary key auto_increment,
+//	    trans_type varchar(45) not null default '',
+//	    gid varchar(128) not null default '',
+//	    branch_id varchar(128) not null default '',
+//	    op varchar(45) not null default '',
+//	    barrier_id varchar(45) not null default '',
+//	    reason varchar(45) not null default '',
+//	    unique key uniq_barrier (gid, branch_id, op, barrier_id)
+//	);
+package barrier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/qkbyte/go-zero/core/stores/sqlx"
+)
+
+// the operations of a distributed transaction branch.
+const (
+	OpAction     = "action"
+	OpCancel     = "cancel"
+	OpCompensate = "compensate"
+	OpMsg        = "msg"
+	OpTry        = "try"
+)
+
+const defaultTable = "dtm_barrier"
+
+// insert statements that ignore duplicated barrier rows, per dialect.
+const (
+	mysqlInsert    = "insert ignore into %s (trans_type, gid, branch_id, op, barrier_id, reason) values (?, ?, ?, ?, ?, ?)"
+	postgresInsert = "insert into %s (trans_type, gid, branch_id, op, barrier_id, reason) values ($1, $2, $3, $4, $5, $6) on conflict do nothing"
+	sqliteInsert   = "insert or ignore into %s (trans_type, gid, branch_id, op, barrier_id, reason) values (?, ?, ?, ?, ?, ?)"
+)
+
+// ErrDuplicated is returned on duplicated message branches, so the caller
+// can tell dtm the message is already consumed.
+var ErrDuplicated = errors.New("barrier: duplicate request or result unknown")
+
+// the origin operation that a rollback operation compensates.
+var originOps = map[string]string{
+	OpCancel:     OpTry,
+	OpCompensate: OpAction,
+}
+
+type (
+	// A Barrier guards one branch operation of a distributed transaction.
+	Barrier struct {
+		TransType string
+		Gid       string
+		BranchID  string
+		Op        string
+		barrierID int
+		table     string
+		insert    string
+	}
+
+	// Option defines the method to customize a Barrier.
+	Option func(b *Barrier)
+)
+
+// New returns a Barrier for the given branch operation, the arguments
+// typically come from the dtm request.
+func New(transType, gid, branchID, op string, opts ...Option) *Barrier {
+	b := &Barrier{
+		TransType: transType,
+		Gid:       gid,
+		BranchID:  branchID,
+		Op:        op,
+		table:     defaultTable,
+		insert:    mysqlInsert,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// WithPostgres returns an Option to use the postgres dialect.
+func WithPostgres() Option {
+	return func(b *Barrier) {
+		b.insert = postgresInsert
+	}
+}
+
+// WithSqlite returns an Option to use the sqlite dialect.
+func WithSqlite() Option {
+	return func(b *Barrier) {
+		b.insert = sqliteInsert
+	}
+}
+
+// WithTable returns an Option to customize the barrier table name.
+func WithTable(table string) Option {
+	return func(b *Barrier) {
+		b.table = table
+	}
+}
+
+// CallWithTrans runs fn in a local transaction guarded by the barrier.
+// Duplicated calls and compensations whose origin operation never ran
+// are skipped silently, as dtm expects.
+func (b *Barrier) CallWithTrans(ctx context.Context, conn sqlx.SqlConn,
+	fn func(ctx context.Context, session sqlx.Session) error) error {
+	barrierID := b.newBarrierID()
+	return conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		originAffected, err := b.insertBarrier(ctx, session, originOps[b.Op], barrierID)
+		if err != nil {
+			return err
+		}
+
+		currentAffected, err := b.insertBarrier(ctx, session, b.Op, barrierID)
+		if err != nil {
+			return err
+		}
+
+		if b.Op == OpMsg && currentAffected == 0 {
+			return ErrDuplicated
+		}
+
+		// null compensation: the rollback arrived before the origin operation,
+		// the origin row insert succeeded so the origin operation will be
+		// suppressed when it arrives later (hanging request).
+		if len(originOps[b.Op]) > 0 && (originAffected > 0 || currentAffected == 0) {
+			return nil
+		}
+
+		// duplicate request, the operation already ran.
+		if currentAffected == 0 {
+			return nil
+		}
+
+		return fn(ctx, session)
+	})
+}
+
+func (b *Barrier) insertBarrier(ctx context.Context, session sqlx.Session,
+	op string, barrierID string) (int64, error) {
+	if len(op) == 0 {
+		return 0, nil
+	}
+
+	result, err := session.ExecCtx(ctx, fmt.Sprintf(b.insert, b.table),
+		b.TransType, b.Gid, b.BranchID, op, barrierID, b.Op)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (b *Barrier) newBarrierID() string {
+	b.barrierID++
+	return fmt.Sprintf("%02d", b.barrierID)
+}
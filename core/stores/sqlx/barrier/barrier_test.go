@@ -0,0 +1,81 @@
+package barrier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/qkbyte/go-zero/core/stores/sqlite"
+	"github.com/qkbyte/go-zero/core/stores/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func createBarrierTable(t *testing.T, conn sqlx.SqlConn) {
+	_, err := conn.Exec(`create table if not exists dtm_barrier (
+		id integer primary key,
+		trans_type varchar(45) not null default '',
+		gid varchar(128) not null default '',
+		branch_id varchar(128) not null default '',
+		op varchar(45) not null default '',
+		barrier_id varchar(45) not null default '',
+		reason varchar(45) not null default '',
+		unique (gid, branch_id, op, barrier_id)
+	)`)
+	assert.Nil(t, err)
+}
+
+func TestBarrierCallOnce(t *testing.T) {
+	conn := sqlite.New("file:barrier_once?mode=memory&cache=shared")
+	createBarrierTable(t, conn)
+
+	var calls int
+	fn := func(ctx context.Context, session sqlx.Session) error {
+		calls++
+		return nil
+	}
+
+	assert.Nil(t, New("saga", "gid1", "01", OpAction, WithSqlite()).
+		CallWithTrans(context.Background(), conn, fn))
+	assert.Equal(t, 1, calls)
+
+	// the same branch operation again is a duplicate and should be skipped
+	assert.Nil(t, New("saga", "gid1", "01", OpAction, WithSqlite()).
+		CallWithTrans(context.Background(), conn, fn))
+	assert.Equal(t, 1, calls)
+}
+
+func TestBarrierNullCompensation(t *testing.T) {
+	conn := sqlite.New("file:barrier_null?mode=memory&cache=shared")
+	createBarrierTable(t, conn)
+
+	var compensated, acted bool
+	// compensate arrives before the action ran: it must be suppressed
+	assert.Nil(t, New("saga", "gid2", "01", OpCompensate, WithSqlite()).
+		CallWithTrans(context.Background(), conn,
+			func(ctx context.Context, session sqlx.Session) error {
+				compensated = true
+				return nil
+			}))
+	assert.False(t, compensated)
+
+	// the late action must be suppressed as well (hanging request)
+	assert.Nil(t, New("saga", "gid2", "01", OpAction, WithSqlite()).
+		CallWithTrans(context.Background(), conn,
+			func(ctx context.Context, session sqlx.Session) error {
+				acted = true
+				return nil
+			}))
+	assert.False(t, acted)
+}
+
+func TestBarrierDuplicatedMsg(t *testing.T) {
+	conn := sqlite.New("file:barrier_msg?mode=memory&cache=shared")
+	createBarrierTable(t, conn)
+
+	fn := func(ctx context.Context, session sqlx.Session) error {
+		return nil
+	}
+	assert.Nil(t, New("msg", "gid3", "01", OpMsg, WithSqlite()).
+		CallWithTrans(context.Background(), conn, fn))
+	assert.Equal(t, ErrDuplicated, New("msg", "gid3", "01", OpMsg, WithSqlite()).
+		CallWithTrans(context.Background(), conn, fn))
+}
@@ -12,17 +12,22 @@ import (
 )
 
 const (
-	flushInterval = time.Second
-	maxBulkRows   = 1000
-	valuesKeyword = "values"
+	flushInterval     = time.Second
+	maxBulkRows       = 1000
+	valuesKeyword     = "values"
+	defaultRetryDelay = time.Millisecond * 100
 )
 
 var emptyBulkStmt bulkStmt
 
 type (
-	// ResultHandler defines the method of result handlers.
+	// ResultHandler defines the method of result handlers,
+	// it's called once per executed batch.
 	ResultHandler func(sql.Result, error)
 
+	// BulkOption defines the method to customize a BulkInserter.
+	BulkOption func(in *dbInserter)
+
 	// A BulkInserter is used to batch insert records.
 	// Postgresql is not supported yet, because of the sql is formated with symbol `$`.
 	// Oracle is not supported yet, because of the sql is formated with symbol `:`.
@@ -40,15 +45,21 @@ type (
 )
 
 // NewBulkInserter returns a BulkInserter.
-func NewBulkInserter(sqlConn SqlConn, stmt string) (*BulkInserter, error) {
+// Pending rows are flushed on graceful shutdown through the proc shutdown
+// listener registered by the underlying PeriodicalExecutor.
+func NewBulkInserter(sqlConn SqlConn, stmt string, opts ...BulkOption) (*BulkInserter, error) {
 	bkStmt, err := parseInsertStmt(stmt)
 	if err != nil {
 		return nil, err
 	}
 
 	inserter := &dbInserter{
-		sqlConn: sqlConn,
-		stmt:    bkStmt,
+		sqlConn:    sqlConn,
+		stmt:       bkStmt,
+		retryDelay: defaultRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(inserter)
 	}
 
 	return &BulkInserter{
@@ -58,6 +69,15 @@ func NewBulkInserter(sqlConn SqlConn, stmt string) (*BulkInserter, error) {
 	}, nil
 }
 
+// WithBulkRetries returns a BulkOption that retries failed batches the given
+// times before reporting the error, with the given delay between attempts.
+func WithBulkRetries(retries int, delay time.Duration) BulkOption {
+	return func(in *dbInserter) {
+		in.retries = retries
+		in.retryDelay = delay
+	}
+}
+
 // Flush flushes all the pending records.
 func (bi *BulkInserter) Flush() {
 	bi.executor.Flush()
@@ -108,6 +128,8 @@ type dbInserter struct {
 	stmt          bulkStmt
 	values        []string
 	resultHandler ResultHandler
+	retries       int
+	retryDelay    time.Duration
 }
 
 func (in *dbInserter) AddTask(task interface{}) bool {
@@ -127,7 +149,17 @@ func (in *dbInserter) Execute(bulk interface{}) {
 	if len(in.stmt.suffix) > 0 {
 		stmt = strings.Join([]string{stmt, in.stmt.suffix}, " ")
 	}
-	result, err := in.sqlConn.Exec(stmt)
+	var result sql.Result
+	var err error
+	for i := 0; ; i++ {
+		result, err = in.sqlConn.Exec(stmt)
+		if err == nil || i >= in.retries {
+			break
+		}
+
+		logx.Errorf("bulk insert failed, retry %d/%d, error: %s", i+1, in.retries, err)
+		time.Sleep(in.retryDelay)
+	}
 	if in.resultHandler != nil {
 		in.resultHandler(result, err)
 	} else if err != nil {
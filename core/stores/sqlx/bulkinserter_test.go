@@ -5,7 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/qkbyte/go-zero/core/logx"
@@ -44,6 +47,14 @@ func (c *mockedConn) QueryRowsPartialCtx(ctx context.Context, v interface{}, que
 	panic("implement me")
 }
 
+func (c *mockedConn) QueryRowsToMaps(v *[]map[string]interface{}, query string, args ...interface{}) error {
+	panic("implement me")
+}
+
+func (c *mockedConn) QueryRowsToMapsCtx(ctx context.Context, v *[]map[string]interface{}, query string, args ...interface{}) error {
+	panic("implement me")
+}
+
 func (c *mockedConn) TransactCtx(ctx context.Context, fn func(context.Context, Session) error) error {
 	panic("should not called")
 }
@@ -160,3 +171,34 @@ func runSqlTest(t *testing.T, fn func(db *sql.DB, mock sqlmock.Sqlmock)) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestBulkInserterRetry(t *testing.T) {
+	var conn flakyConn
+	inserter, err := NewBulkInserter(&conn, "insert into users(id, name) values(?, ?)",
+		WithBulkRetries(2, time.Millisecond))
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	inserter.SetResultHandler(func(result sql.Result, err error) {
+		defer wg.Done()
+		assert.Nil(t, err)
+	})
+	assert.Nil(t, inserter.Insert(1, "first"))
+	inserter.Flush()
+	wg.Wait()
+	assert.Equal(t, int32(3), conn.execTimes.Load())
+}
+
+type flakyConn struct {
+	mockedConn
+	execTimes atomic.Int32
+}
+
+func (c *flakyConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if c.execTimes.Add(1) < 3 {
+		return nil, errors.New("flaky")
+	}
+
+	return nil, nil
+}
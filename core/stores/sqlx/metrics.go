@@ -1,8 +1,22 @@
 package sqlx
 
-import "github.com/qkbyte/go-zero/core/metric"
+import (
+	"database/sql"
+	"net/url"
+	"strings"
+	"time"
 
-const namespace = "sql_client"
+	"github.com/qkbyte/go-zero/core/metric"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+const (
+	namespace = "sql_client"
+	// the database label value when the database name is unknown.
+	defaultDatabaseLabel = "unknown"
+	// interval to update the connection pool gauges.
+	poolStatsInterval = time.Second * 15
+)
 
 var (
 	metricReqDur = metric.NewHistogramVec(&metric.HistogramVecOpts{
@@ -10,7 +24,7 @@ var (
 		Subsystem: "requests",
 		Name:      "durations_ms",
 		Help:      "mysql client requests duration(ms).",
-		Labels:    []string{"command"},
+		Labels:    []string{"command", "database"},
 		Buckets:   []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500},
 	})
 	metricReqErr = metric.NewCounterVec(&metric.CounterVecOpts{
@@ -18,6 +32,82 @@ var (
 		Subsystem: "requests",
 		Name:      "error_total",
 		Help:      "mysql client requests error count.",
-		Labels:    []string{"command", "error"},
+		Labels:    []string{"command", "error", "database"},
+	})
+	metricConnOpen = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "connections",
+		Name:      "open",
+		Help:      "mysql client open connections.",
+		Labels:    []string{"database"},
+	})
+	metricConnIdle = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "connections",
+		Name:      "idle",
+		Help:      "mysql client idle connections.",
+		Labels:    []string{"database"},
+	})
+	metricConnWaitCount = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "connections",
+		Name:      "wait_count",
+		Help:      "mysql client connections waited for.",
+		Labels:    []string{"database"},
+	})
+	metricConnWaitDuration = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: namespace,
+		Subsystem: "connections",
+		Name:      "wait_duration_ms",
+		Help:      "mysql client total time blocked waiting for connections(ms).",
+		Labels:    []string{"database"},
 	})
 )
+
+// monitorPoolStats periodically updates the connection pool gauges of db.
+func monitorPoolStats(db *sql.DB, database string) {
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(poolStatsInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			stats := db.Stats()
+			metricConnOpen.Set(float64(stats.OpenConnections), database)
+			metricConnIdle.Set(float64(stats.Idle), database)
+			metricConnWaitCount.Set(float64(stats.WaitCount), database)
+			metricConnWaitDuration.Set(float64(stats.WaitDuration/time.Millisecond), database)
+		}
+	})
+}
+
+// parseDatabase derives the database name from the datasource for metrics
+// labels, it understands url style and mysql style DSNs.
+func parseDatabase(datasource string) string {
+	if strings.Contains(datasource, "://") {
+		if u, err := url.Parse(datasource); err == nil {
+			if db := strings.TrimPrefix(u.Path, "/"); len(db) > 0 {
+				return db
+			}
+		}
+	}
+
+	rest := datasource
+	if pos := strings.LastIndexByte(rest, '/'); pos >= 0 {
+		rest = rest[pos+1:]
+		if pos := strings.IndexByte(rest, '?'); pos >= 0 {
+			rest = rest[:pos]
+		}
+		if len(rest) > 0 {
+			return rest
+		}
+	}
+
+	// postgres key/value style datasource
+	for _, field := range strings.Fields(datasource) {
+		if strings.HasPrefix(field, "dbname=") {
+			return field[len("dbname="):]
+		}
+	}
+
+	return defaultDatabaseLabel
+}
@@ -0,0 +1,322 @@
+// Package migrate provides a schema migration runner on top of sqlx,
+// so services can keep their schema up to date at start time without
+// pulling in a third party migration tool with its own DB pool.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/stores/sqlx"
+)
+
+const defaultTable = "schema_migrations"
+
+// bookkeeping statements, per dialect.
+const (
+	mysqlInsertVersion    = "insert into %s (version) values (?)"
+	postgresInsertVersion = "insert into %s (version) values ($1)"
+	mysqlDeleteVersion    = "delete from %s where version = ?"
+	postgresDeleteVersion = "delete from %s where version = $1"
+)
+
+// migration files are named like 0001_create_users.up.sql / 0001_create_users.down.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type (
+	// A Migration is a single versioned schema change.
+	Migration struct {
+		Version int64
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	// A Migrator applies versioned migrations from a fs.FS,
+	// use os.DirFS for directory-based migrations or an embed.FS.
+	Migrator struct {
+		conn          sqlx.SqlConn
+		fsys          fs.FS
+		table         string
+		insertVersion string
+		deleteVersion string
+		acquire       func(ctx context.Context, conn sqlx.SqlConn) error
+		release       func(ctx context.Context, conn sqlx.SqlConn) error
+	}
+
+	// Option defines the method to customize a Migrator.
+	Option func(m *Migrator)
+)
+
+// New returns a Migrator that reads migrations from fsys and applies them with conn.
+func New(conn sqlx.SqlConn, fsys fs.FS, opts ...Option) *Migrator {
+	m := &Migrator{
+		conn:          conn,
+		fsys:          fsys,
+		table:         defaultTable,
+		insertVersion: mysqlInsertVersion,
+		deleteVersion: mysqlDeleteVersion,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// WithLock returns an Option that protects migration runs with the given
+// acquire and release funcs, typically database advisory locks.
+func WithLock(acquire, release func(ctx context.Context, conn sqlx.SqlConn) error) Option {
+	return func(m *Migrator) {
+		m.acquire = acquire
+		m.release = release
+	}
+}
+
+// WithMysqlLock returns an Option that serializes migration runs
+// with the mysql GET_LOCK advisory lock.
+func WithMysqlLock(timeoutSeconds int) Option {
+	return WithLock(func(ctx context.Context, conn sqlx.SqlConn) error {
+		var locked int
+		if err := conn.QueryRowCtx(ctx, &locked,
+			"select get_lock('go-zero-migrate', ?)", timeoutSeconds); err != nil {
+			return err
+		}
+		if locked != 1 {
+			return fmt.Errorf("migrate: lock not acquired within %d seconds", timeoutSeconds)
+		}
+		return nil
+	}, func(ctx context.Context, conn sqlx.SqlConn) error {
+		var released int
+		return conn.QueryRowCtx(ctx, &released, "select release_lock('go-zero-migrate')")
+	})
+}
+
+// WithPostgres returns an Option to use the postgres dialect
+// for the bookkeeping statements.
+func WithPostgres() Option {
+	return func(m *Migrator) {
+		m.insertVersion = postgresInsertVersion
+		m.deleteVersion = postgresDeleteVersion
+	}
+}
+
+// WithPostgresLock returns an Option that serializes migration runs
+// with the postgres pg_advisory_lock, it implies WithPostgres.
+func WithPostgresLock(key int64) Option {
+	return func(m *Migrator) {
+		WithPostgres()(m)
+		WithLock(func(ctx context.Context, conn sqlx.SqlConn) error {
+			_, err := conn.ExecCtx(ctx, "select pg_advisory_lock($1)", key)
+			return err
+		}, func(ctx context.Context, conn sqlx.SqlConn) error {
+			_, err := conn.ExecCtx(ctx, "select pg_advisory_unlock($1)", key)
+			return err
+		})(m)
+	}
+}
+
+// WithTable returns an Option that customizes the migration bookkeeping table.
+func WithTable(table string) Option {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// Down rolls back at most steps applied migrations, latest first.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.run(ctx, func(ctx context.Context) error {
+		migrations, err := m.load()
+		if err != nil {
+			return err
+		}
+
+		applied, err := m.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			if err := m.roll(ctx, migration); err != nil {
+				return err
+			}
+			steps--
+		}
+
+		return nil
+	})
+}
+
+// Up applies all pending migrations in version order.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.run(ctx, func(ctx context.Context) error {
+		migrations, err := m.load()
+		if err != nil {
+			return err
+		}
+
+		applied, err := m.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			if err := m.apply(ctx, migration); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Version returns the highest applied migration version, 0 if none applied.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err := m.conn.QueryRowCtx(ctx, &version,
+		fmt.Sprintf("select coalesce(max(version), 0) from %s", m.table))
+	return version, err
+}
+
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	logx.Infof("migrate: applying %d_%s", migration.Version, migration.Name)
+	return m.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		if _, err := session.ExecCtx(ctx, migration.Up); err != nil {
+			return fmt.Errorf("migrate: apply %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err := session.ExecCtx(ctx, fmt.Sprintf(m.insertVersion, m.table),
+			migration.Version)
+		return err
+	})
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int64]struct{}, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var versions []int64
+	if err := m.conn.QueryRowsCtx(ctx, &versions,
+		fmt.Sprintf("select version from %s", m.table)); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]struct{}, len(versions))
+	for _, version := range versions {
+		applied[version] = struct{}{}
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.conn.ExecCtx(ctx, fmt.Sprintf(
+		"create table if not exists %s (version bigint primary key)", m.table))
+	return err
+}
+
+func (m *Migrator) load() ([]Migration, error) {
+	entries, err := fs.ReadDir(m.fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		groups := fileNamePattern.FindStringSubmatch(entry.Name())
+		if len(groups) == 0 {
+			continue
+		}
+
+		version, err := strconv.ParseInt(groups[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: bad version in %q: %w", entry.Name(), err)
+		}
+
+		data, err := fs.ReadFile(m.fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{
+				Version: version,
+				Name:    groups[2],
+			}
+			byVersion[version] = migration
+		}
+		if groups[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func (m *Migrator) roll(ctx context.Context, migration Migration) error {
+	if len(migration.Down) == 0 {
+		return fmt.Errorf("migrate: no down migration for %d_%s",
+			migration.Version, migration.Name)
+	}
+
+	logx.Infof("migrate: rolling back %d_%s", migration.Version, migration.Name)
+	return m.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		if _, err := session.ExecCtx(ctx, migration.Down); err != nil {
+			return fmt.Errorf("migrate: roll back %d_%s: %w",
+				migration.Version, migration.Name, err)
+		}
+
+		_, err := session.ExecCtx(ctx, fmt.Sprintf(m.deleteVersion, m.table),
+			migration.Version)
+		return err
+	})
+}
+
+func (m *Migrator) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.acquire != nil {
+		if err := m.acquire(ctx, m.conn); err != nil {
+			return err
+		}
+		defer func() {
+			if err := m.release(ctx, m.conn); err != nil {
+				logx.Errorf("migrate: release lock: %v", err)
+			}
+		}()
+	}
+
+	return fn(ctx)
+}
@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/qkbyte/go-zero/core/stores/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigratorUpDown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_create_users.up.sql": &fstest.MapFile{
+			Data: []byte("create table users (id integer primary key, name text)"),
+		},
+		"0001_create_users.down.sql": &fstest.MapFile{
+			Data: []byte("drop table users"),
+		},
+		"0002_create_orders.up.sql": &fstest.MapFile{
+			Data: []byte("create table orders (id integer primary key)"),
+		},
+		"0002_create_orders.down.sql": &fstest.MapFile{
+			Data: []byte("drop table orders"),
+		},
+		"ignored.txt": &fstest.MapFile{Data: []byte("not a migration")},
+	}
+
+	conn := sqlite.New("file:migrate_test?mode=memory&cache=shared")
+	m := New(conn, fsys)
+
+	assert.Nil(t, m.Up(context.Background()))
+	version, err := m.Version(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), version)
+
+	// both tables should exist
+	_, err = conn.Exec("insert into users (name) values ('alice')")
+	assert.Nil(t, err)
+	_, err = conn.Exec("insert into orders default values")
+	assert.Nil(t, err)
+
+	// up again is a no-op
+	assert.Nil(t, m.Up(context.Background()))
+
+	assert.Nil(t, m.Down(context.Background(), 1))
+	version, err = m.Version(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), version)
+	_, err = conn.Exec("insert into orders default values")
+	assert.NotNil(t, err)
+}
+
+func TestMigratorPostgresStatements(t *testing.T) {
+	m := New(nil, nil)
+	assert.Equal(t, mysqlInsertVersion, m.insertVersion)
+	assert.Equal(t, mysqlDeleteVersion, m.deleteVersion)
+
+	m = New(nil, nil, WithPostgres())
+	assert.Equal(t, postgresInsertVersion, m.insertVersion)
+	assert.Equal(t, postgresDeleteVersion, m.deleteVersion)
+	assert.NotContains(t, m.insertVersion, "?")
+	assert.NotContains(t, m.deleteVersion, "?")
+
+	// the advisory lock option implies the postgres dialect
+	m = New(nil, nil, WithPostgresLock(1))
+	assert.Equal(t, postgresInsertVersion, m.insertVersion)
+	assert.Equal(t, postgresDeleteVersion, m.deleteVersion)
+	assert.NotNil(t, m.acquire)
+	assert.NotNil(t, m.release)
+}
+
+func TestMigratorMissingDown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_create_things.up.sql": &fstest.MapFile{
+			Data: []byte("create table things (id integer primary key)"),
+		},
+	}
+
+	conn := sqlite.New("file:migrate_missing_down?mode=memory&cache=shared")
+	m := New(conn, fsys, WithTable("custom_migrations"))
+
+	assert.Nil(t, m.Up(context.Background()))
+	assert.NotNil(t, m.Down(context.Background(), 1))
+}
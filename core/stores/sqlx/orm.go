@@ -160,6 +160,37 @@ func unmarshalRow(v interface{}, scanner rowsScanner, strict bool) error {
 	}
 }
 
+func unmarshalRowsToMaps(v *[]map[string]interface{}, scanner rowsScanner) error {
+	columns, err := scanner.Columns()
+	if err != nil {
+		return err
+	}
+
+	for scanner.Next() {
+		values := make([]interface{}, len(columns))
+		for i := range values {
+			values[i] = new(interface{})
+		}
+		if err := scanner.Scan(values...); err != nil {
+			return err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			value := *(values[i].(*interface{}))
+			// database/sql returns []byte for text-ish columns,
+			// convert to string for friendliness to callers.
+			if bs, ok := value.([]byte); ok {
+				value = string(bs)
+			}
+			row[column] = value
+		}
+		*v = append(*v, row)
+	}
+
+	return scanner.Err()
+}
+
 func unmarshalRows(v interface{}, scanner rowsScanner, strict bool) error {
 	rv := reflect.ValueOf(v)
 	if err := mapping.ValidatePtr(&rv); err != nil {
@@ -0,0 +1,161 @@
+// Package sharding routes queries to database and table shards based on a
+// configurable key function, and scatter-gathers cross-shard reads, so
+// generated models can be sharded without hand-written routing code.
+package sharding
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/qkbyte/go-zero/core/hash"
+	"github.com/qkbyte/go-zero/core/mapping"
+	"github.com/qkbyte/go-zero/core/mr"
+	"github.com/qkbyte/go-zero/core/stores/sqlx"
+)
+
+type (
+	// RouteFn maps a sharding key to a global table shard index,
+	// the value is taken modulo the total shard count.
+	// The default implementation hashes the key, replace it with a
+	// range-based fn to do range sharding.
+	RouteFn func(key interface{}) uint64
+
+	// BuildFn builds the query and args with the rewritten table name.
+	BuildFn func(table string) (query string, args []interface{})
+
+	// A ShardedConn fans queries out to multiple database and table shards.
+	// Tables are rewritten as base_<index> with index in [0, dbs*tables).
+	ShardedConn struct {
+		conns  []sqlx.SqlConn
+		tables int
+		route  RouteFn
+	}
+
+	// Option defines the method to customize a ShardedConn.
+	Option func(sc *ShardedConn)
+)
+
+// New returns a ShardedConn with the given database shards.
+func New(conns []sqlx.SqlConn, opts ...Option) *ShardedConn {
+	if len(conns) == 0 {
+		panic("sharding: no database shards")
+	}
+
+	sc := &ShardedConn{
+		conns:  conns,
+		tables: 1,
+		route: func(key interface{}) uint64 {
+			return hash.Hash([]byte(fmt.Sprint(key)))
+		},
+	}
+	for _, opt := range opts {
+		opt(sc)
+	}
+
+	return sc
+}
+
+// WithRoute returns an Option to customize the routing function.
+func WithRoute(route RouteFn) Option {
+	return func(sc *ShardedConn) {
+		sc.route = route
+	}
+}
+
+// WithTableShards returns an Option to set the table shard count per database.
+func WithTableShards(count int) Option {
+	return func(sc *ShardedConn) {
+		sc.tables = count
+	}
+}
+
+// Conn returns the database shard that holds the given key.
+func (sc *ShardedConn) Conn(key interface{}) sqlx.SqlConn {
+	return sc.conns[sc.index(key)/sc.tables]
+}
+
+// Table returns the sharded table name of base for the given key.
+func (sc *ShardedConn) Table(base string, key interface{}) string {
+	return fmt.Sprintf("%s_%d", base, sc.index(key))
+}
+
+// Exec runs the built query on the shard of key.
+func (sc *ShardedConn) Exec(key interface{}, base string, build BuildFn) (sql.Result, error) {
+	return sc.ExecCtx(context.Background(), key, base, build)
+}
+
+// ExecCtx runs the built query on the shard of key.
+func (sc *ShardedConn) ExecCtx(ctx context.Context, key interface{}, base string,
+	build BuildFn) (sql.Result, error) {
+	query, args := build(sc.Table(base, key))
+	return sc.Conn(key).ExecCtx(ctx, query, args...)
+}
+
+// QueryRow runs the built query on the shard of key and unmarshals into v.
+func (sc *ShardedConn) QueryRow(v interface{}, key interface{}, base string, build BuildFn) error {
+	return sc.QueryRowCtx(context.Background(), v, key, base, build)
+}
+
+// QueryRowCtx runs the built query on the shard of key and unmarshals into v.
+func (sc *ShardedConn) QueryRowCtx(ctx context.Context, v interface{}, key interface{},
+	base string, build BuildFn) error {
+	query, args := build(sc.Table(base, key))
+	return sc.Conn(key).QueryRowCtx(ctx, v, query, args...)
+}
+
+// QueryRowsAll scatter-gathers the built query across all shards,
+// v must be a pointer to a slice. Row order across shards is not defined.
+func (sc *ShardedConn) QueryRowsAll(v interface{}, base string, build BuildFn) error {
+	return sc.QueryRowsAllCtx(context.Background(), v, base, build)
+}
+
+// QueryRowsAllCtx scatter-gathers the built query across all shards,
+// v must be a pointer to a slice. Row order across shards is not defined.
+func (sc *ShardedConn) QueryRowsAllCtx(ctx context.Context, v interface{}, base string,
+	build BuildFn) error {
+	rv := reflect.ValueOf(v)
+	if err := mapping.ValidatePtr(&rv); err != nil {
+		return err
+	}
+
+	rve := rv.Elem()
+	if rve.Kind() != reflect.Slice {
+		return sqlx.ErrNotMatchDestination
+	}
+
+	var lock sync.Mutex
+	total := len(sc.conns) * sc.tables
+	err := mr.MapReduceVoid(func(source chan<- interface{}) {
+		for i := 0; i < total; i++ {
+			source <- i
+		}
+	}, func(item interface{}, writer mr.Writer, cancel func(error)) {
+		index := item.(int)
+		query, args := build(fmt.Sprintf("%s_%d", base, index))
+		rows := reflect.New(rve.Type())
+		if err := sc.conns[index/sc.tables].QueryRowsCtx(ctx, rows.Interface(),
+			query, args...); err != nil {
+			cancel(err)
+			return
+		}
+
+		writer.Write(rows.Elem())
+	}, func(pipe <-chan interface{}, cancel func(error)) {
+		for item := range pipe {
+			rows := item.(reflect.Value)
+			lock.Lock()
+			rve.Set(reflect.AppendSlice(rve, rows))
+			lock.Unlock()
+		}
+	})
+
+	return err
+}
+
+func (sc *ShardedConn) index(key interface{}) int {
+	total := uint64(len(sc.conns) * sc.tables)
+	return int(sc.route(key) % total)
+}
@@ -0,0 +1,70 @@
+package sharding
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/qkbyte/go-zero/core/stores/sqlite"
+	"github.com/qkbyte/go-zero/core/stores/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildShards(t *testing.T, name string, dbs, tables int) []sqlx.SqlConn {
+	conns := make([]sqlx.SqlConn, dbs)
+	for i := 0; i < dbs; i++ {
+		conn := sqlite.New(fmt.Sprintf("file:%s_%d?mode=memory&cache=shared", name, i))
+		for j := 0; j < tables; j++ {
+			index := i*tables + j
+			_, err := conn.Exec(fmt.Sprintf(
+				"create table users_%d (id integer primary key, name text)", index))
+			assert.Nil(t, err)
+		}
+		conns[i] = conn
+	}
+
+	return conns
+}
+
+func TestShardedConn(t *testing.T) {
+	conns := buildShards(t, "sharding_test", 2, 2)
+	sc := New(conns, WithTableShards(2), WithRoute(func(key interface{}) uint64 {
+		return uint64(key.(int))
+	}))
+
+	for i := 0; i < 8; i++ {
+		_, err := sc.Exec(i, "users", func(table string) (string, []interface{}) {
+			return fmt.Sprintf("insert into %s (id, name) values (?, ?)", table),
+				[]interface{}{i, fmt.Sprintf("user-%d", i)}
+		})
+		assert.Nil(t, err)
+	}
+
+	// single-shard read routes to the right table
+	var name string
+	err := sc.QueryRow(&name, 5, "users", func(table string) (string, []interface{}) {
+		assert.Equal(t, "users_1", table)
+		return fmt.Sprintf("select name from %s where id = ?", table), []interface{}{5}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "user-5", name)
+
+	// scatter-gather read collects rows from all shards
+	var ids []int
+	err = sc.QueryRowsAll(&ids, "users", func(table string) (string, []interface{}) {
+		return fmt.Sprintf("select id from %s", table), nil
+	})
+	assert.Nil(t, err)
+	sort.Ints(ids)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, ids)
+}
+
+func TestShardedConnDefaults(t *testing.T) {
+	conns := buildShards(t, "sharding_defaults", 1, 1)
+	sc := New(conns)
+	assert.Equal(t, "users_0", sc.Table("users", "any-key"))
+	assert.NotNil(t, sc.Conn("any-key"))
+	assert.Panics(t, func() {
+		New(nil)
+	})
+}
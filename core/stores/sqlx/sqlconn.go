@@ -3,6 +3,7 @@ package sqlx
 import (
 	"context"
 	"database/sql"
+	"sync"
 
 	"github.com/qkbyte/go-zero/core/breaker"
 	"github.com/qkbyte/go-zero/core/logx"
@@ -29,6 +30,9 @@ type (
 		QueryRowsCtx(ctx context.Context, v interface{}, query string, args ...interface{}) error
 		QueryRowsPartial(v interface{}, query string, args ...interface{}) error
 		QueryRowsPartialCtx(ctx context.Context, v interface{}, query string, args ...interface{}) error
+		QueryRowsToMaps(v *[]map[string]interface{}, query string, args ...interface{}) error
+		QueryRowsToMapsCtx(ctx context.Context, v *[]map[string]interface{}, query string,
+			args ...interface{}) error
 	}
 
 	// SqlConn only stands for raw connections, so Transact method can be called.
@@ -63,11 +67,13 @@ type (
 	// Because CORBA doesn't support PREPARE, so we need to combine the
 	// query arguments into one string and do underlying query without arguments
 	commonSqlConn struct {
-		connProv connProvider
-		onError  func(error)
-		beginTx  beginnable
-		brk      breaker.Breaker
-		accept   func(error) bool
+		connProv  connProvider
+		onError   func(error)
+		beginTx   beginnable
+		brk       breaker.Breaker
+		accept    func(error) bool
+		database  string
+		statsOnce sync.Once
 	}
 
 	connProvider func() (*sql.DB, error)
@@ -80,8 +86,9 @@ type (
 	}
 
 	statement struct {
-		query string
-		stmt  *sql.Stmt
+		query    string
+		stmt     *sql.Stmt
+		database string
 	}
 
 	stmtConn interface {
@@ -95,14 +102,24 @@ type (
 // NewSqlConn returns a SqlConn with given driver name and datasource.
 func NewSqlConn(driverName, datasource string, opts ...SqlOption) SqlConn {
 	conn := &commonSqlConn{
-		connProv: func() (*sql.DB, error) {
-			return getSqlConn(driverName, datasource)
-		},
 		onError: func(err error) {
 			logInstanceError(datasource, err)
 		},
-		beginTx: begin,
-		brk:     breaker.NewBreaker(),
+		beginTx:  begin,
+		brk:      breaker.NewBreaker(),
+		database: parseDatabase(datasource),
+	}
+	conn.connProv = func() (*sql.DB, error) {
+		db, err := getSqlConn(driverName, datasource)
+		if err != nil {
+			return nil, err
+		}
+
+		conn.statsOnce.Do(func() {
+			monitorPoolStats(db, conn.database)
+		})
+
+		return db, nil
 	}
 	for _, opt := range opts {
 		opt(conn)
@@ -118,6 +135,7 @@ func NewSqlConnFromDB(db *sql.DB, opts ...SqlOption) SqlConn {
 		connProv: func() (*sql.DB, error) {
 			return db, nil
 		},
+		database: defaultDatabaseLabel,
 		onError: func(err error) {
 			logx.Errorf("Error on getting sql instance: %v", err)
 		},
@@ -150,11 +168,11 @@ func (db *commonSqlConn) ExecCtx(ctx context.Context, q string, args ...interfac
 			return err
 		}
 
-		result, err = exec(ctx, conn, q, args...)
+		result, err = exec(ctx, conn, db.database, q, args...)
 		return err
 	}, db.acceptable)
 	if err == breaker.ErrServiceUnavailable {
-		metricReqErr.Inc("Exec", "breaker")
+		metricReqErr.Inc("Exec", "breaker", db.database)
 	}
 
 	return
@@ -184,13 +202,14 @@ func (db *commonSqlConn) PrepareCtx(ctx context.Context, query string) (stmt Stm
 		}
 
 		stmt = statement{
-			query: query,
-			stmt:  st,
+			query:    query,
+			stmt:     st,
+			database: db.database,
 		}
 		return nil
 	}, db.acceptable)
 	if err == breaker.ErrServiceUnavailable {
-		metricReqErr.Inc("Prepare", "breaker")
+		metricReqErr.Inc("Prepare", "breaker", db.database)
 	}
 
 	return
@@ -260,6 +279,23 @@ func (db *commonSqlConn) QueryRowsPartialCtx(ctx context.Context, v interface{},
 	}, q, args...)
 }
 
+func (db *commonSqlConn) QueryRowsToMaps(v *[]map[string]interface{}, q string,
+	args ...interface{}) error {
+	return db.QueryRowsToMapsCtx(context.Background(), v, q, args...)
+}
+
+func (db *commonSqlConn) QueryRowsToMapsCtx(ctx context.Context, v *[]map[string]interface{},
+	q string, args ...interface{}) (err error) {
+	ctx, span := startSpan(ctx, "QueryRowsToMaps")
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	return db.queryRows(ctx, func(rows *sql.Rows) error {
+		return unmarshalRowsToMaps(v, rows)
+	}, q, args...)
+}
+
 func (db *commonSqlConn) RawDB() (*sql.DB, error) {
 	return db.connProv()
 }
@@ -280,12 +316,28 @@ func (db *commonSqlConn) TransactCtx(ctx context.Context, fn func(context.Contex
 		return transact(ctx, db, db.beginTx, fn)
 	}, db.acceptable)
 	if err == breaker.ErrServiceUnavailable {
-		metricReqErr.Inc("Transact", "breaker")
+		metricReqErr.Inc("Transact", "breaker", db.database)
 	}
 
 	return
 }
 
+// WithAcceptable returns a SqlOption that setting the acceptable function.
+// acceptable is the func to check if the error can be accepted.
+func WithAcceptable(acceptable func(err error) bool) SqlOption {
+	return func(conn *commonSqlConn) {
+		conn.accept = acceptable
+	}
+}
+
+// WithDatabaseLabel returns a SqlOption that sets the database label
+// reported in metrics, overriding the name derived from the datasource.
+func WithDatabaseLabel(database string) SqlOption {
+	return func(conn *commonSqlConn) {
+		conn.database = database
+	}
+}
+
 func (db *commonSqlConn) acceptable(err error) bool {
 	ok := err == nil || err == sql.ErrNoRows || err == sql.ErrTxDone || err == context.Canceled
 	if db.accept == nil {
@@ -305,7 +357,7 @@ func (db *commonSqlConn) queryRows(ctx context.Context, scanner func(*sql.Rows)
 			return err
 		}
 
-		return query(ctx, conn, func(rows *sql.Rows) error {
+		return query(ctx, conn, db.database, func(rows *sql.Rows) error {
 			qerr = scanner(rows)
 			return qerr
 		}, q, args...)
@@ -313,7 +365,7 @@ func (db *commonSqlConn) queryRows(ctx context.Context, scanner func(*sql.Rows)
 		return qerr == err || db.acceptable(err)
 	})
 	if err == breaker.ErrServiceUnavailable {
-		metricReqErr.Inc("queryRows", "breaker")
+		metricReqErr.Inc("queryRows", "breaker", db.database)
 	}
 
 	return
@@ -333,7 +385,7 @@ func (s statement) ExecCtx(ctx context.Context, args ...interface{}) (result sql
 		endSpan(span, err)
 	}()
 
-	return execStmt(ctx, s.stmt, s.query, args...)
+	return execStmt(ctx, s.stmt, s.database, s.query, args...)
 }
 
 func (s statement) QueryRow(v interface{}, args ...interface{}) error {
@@ -346,7 +398,7 @@ func (s statement) QueryRowCtx(ctx context.Context, v interface{}, args ...inter
 		endSpan(span, err)
 	}()
 
-	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
+	return queryStmt(ctx, s.stmt, s.database, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, true)
 	}, s.query, args...)
 }
@@ -361,7 +413,7 @@ func (s statement) QueryRowPartialCtx(ctx context.Context, v interface{}, args .
 		endSpan(span, err)
 	}()
 
-	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
+	return queryStmt(ctx, s.stmt, s.database, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, false)
 	}, s.query, args...)
 }
@@ -376,7 +428,7 @@ func (s statement) QueryRowsCtx(ctx context.Context, v interface{}, args ...inte
 		endSpan(span, err)
 	}()
 
-	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
+	return queryStmt(ctx, s.stmt, s.database, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, true)
 	}, s.query, args...)
 }
@@ -391,7 +443,7 @@ func (s statement) QueryRowsPartialCtx(ctx context.Context, v interface{}, args
 		endSpan(span, err)
 	}()
 
-	return queryStmt(ctx, s.stmt, func(rows *sql.Rows) error {
+	return queryStmt(ctx, s.stmt, s.database, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, false)
 	}, s.query, args...)
 }
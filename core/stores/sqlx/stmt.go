@@ -34,8 +34,9 @@ func SetSlowThreshold(threshold time.Duration) {
 	slowThreshold.Set(threshold)
 }
 
-func exec(ctx context.Context, conn sessionConn, q string, args ...interface{}) (sql.Result, error) {
-	guard := newGuard("exec")
+func exec(ctx context.Context, conn sessionConn, database, q string, args ...interface{}) (
+	sql.Result, error) {
+	guard := newGuard("exec", database)
 	if err := guard.start(q, args...); err != nil {
 		return nil, err
 	}
@@ -46,8 +47,9 @@ func exec(ctx context.Context, conn sessionConn, q string, args ...interface{})
 	return result, err
 }
 
-func execStmt(ctx context.Context, conn stmtConn, q string, args ...interface{}) (sql.Result, error) {
-	guard := newGuard("execStmt")
+func execStmt(ctx context.Context, conn stmtConn, database, q string, args ...interface{}) (
+	sql.Result, error) {
+	guard := newGuard("execStmt", database)
 	if err := guard.start(q, args...); err != nil {
 		return nil, err
 	}
@@ -58,9 +60,9 @@ func execStmt(ctx context.Context, conn stmtConn, q string, args ...interface{})
 	return result, err
 }
 
-func query(ctx context.Context, conn sessionConn, scanner func(*sql.Rows) error,
+func query(ctx context.Context, conn sessionConn, database string, scanner func(*sql.Rows) error,
 	q string, args ...interface{}) error {
-	guard := newGuard("query")
+	guard := newGuard("query", database)
 	if err := guard.start(q, args...); err != nil {
 		return err
 	}
@@ -75,9 +77,9 @@ func query(ctx context.Context, conn sessionConn, scanner func(*sql.Rows) error,
 	return scanner(rows)
 }
 
-func queryStmt(ctx context.Context, conn stmtConn, scanner func(*sql.Rows) error,
+func queryStmt(ctx context.Context, conn stmtConn, database string, scanner func(*sql.Rows) error,
 	q string, args ...interface{}) error {
-	guard := newGuard("queryStmt")
+	guard := newGuard("queryStmt", database)
 	if err := guard.start(q, args...); err != nil {
 		return err
 	}
@@ -102,15 +104,20 @@ type (
 
 	realSqlGuard struct {
 		command   string
+		database  string
 		stmt      string
 		startTime time.Duration
 	}
 )
 
-func newGuard(command string) sqlGuard {
+func newGuard(command, database string) sqlGuard {
+	if len(database) == 0 {
+		database = defaultDatabaseLabel
+	}
 	if logSql.True() || logSlowSql.True() {
 		return &realSqlGuard{
-			command: command,
+			command:  command,
+			database: database,
 		}
 	}
 
@@ -136,7 +143,7 @@ func (e *realSqlGuard) finish(ctx context.Context, err error) {
 		logSqlError(ctx, e.stmt, err)
 	}
 
-	metricReqDur.Observe(int64(duration/time.Millisecond), e.command)
+	metricReqDur.ObserveCtx(ctx, int64(duration/time.Millisecond), e.command, e.database)
 }
 
 func (e *realSqlGuard) start(q string, args ...interface{}) error {
@@ -63,7 +63,7 @@ func TestStmt_exec(t *testing.T) {
 					rowsAffected: test.rowsAffected,
 					err:          test.err,
 					delay:        test.delay,
-				}, test.query, args...)
+				}, defaultDatabaseLabel, test.query, args...)
 			},
 			func(args ...interface{}) (sql.Result, error) {
 				return execStmt(context.Background(), &mockedStmtConn{
@@ -71,7 +71,7 @@ func TestStmt_exec(t *testing.T) {
 					rowsAffected: test.rowsAffected,
 					err:          test.err,
 					delay:        test.delay,
-				}, test.query, args...)
+				}, defaultDatabaseLabel, test.query, args...)
 			},
 		}
 
@@ -141,7 +141,7 @@ func TestStmt_query(t *testing.T) {
 				return query(context.Background(), &mockedSessionConn{
 					err:   test.err,
 					delay: test.delay,
-				}, func(rows *sql.Rows) error {
+				}, defaultDatabaseLabel, func(rows *sql.Rows) error {
 					return nil
 				}, test.query, args...)
 			},
@@ -149,7 +149,7 @@ func TestStmt_query(t *testing.T) {
 				return queryStmt(context.Background(), &mockedStmtConn{
 					err:   test.err,
 					delay: test.delay,
-				}, func(rows *sql.Rows) error {
+				}, defaultDatabaseLabel, func(rows *sql.Rows) error {
 					return nil
 				}, test.query, args...)
 			},
@@ -213,7 +213,7 @@ func TestNilGuard(t *testing.T) {
 	}()
 
 	DisableLog()
-	guard := newGuard("any")
+	guard := newGuard("any", "any")
 	assert.Nil(t, guard.start("foo", "bar"))
 	guard.finish(context.Background(), nil)
 	assert.Equal(t, nilGuard{}, guard)
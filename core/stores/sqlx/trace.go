@@ -18,6 +18,7 @@ func startSpan(ctx context.Context, method string) (context.Context, oteltrace.S
 	start, span := tracer.Start(ctx,
 		spanName,
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(trace.ContextAttributes(ctx)...),
 	)
 	span.SetAttributes(sqlAttributeKey.String(method))
 
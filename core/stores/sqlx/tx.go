@@ -17,6 +17,7 @@ type (
 
 	txSession struct {
 		*sql.Tx
+		database string
 	}
 )
 
@@ -36,7 +37,7 @@ func (t txSession) ExecCtx(ctx context.Context, q string, args ...interface{}) (
 		endSpan(span, err)
 	}()
 
-	result, err = exec(ctx, t.Tx, q, args...)
+	result, err = exec(ctx, t.Tx, t.database, q, args...)
 
 	return
 }
@@ -57,8 +58,9 @@ func (t txSession) PrepareCtx(ctx context.Context, q string) (stmtSession StmtSe
 	}
 
 	return statement{
-		query: q,
-		stmt:  stmt,
+		query:    q,
+		stmt:     stmt,
+		database: t.database,
 	}, nil
 }
 
@@ -72,7 +74,7 @@ func (t txSession) QueryRowCtx(ctx context.Context, v interface{}, q string, arg
 		endSpan(span, err)
 	}()
 
-	return query(ctx, t.Tx, func(rows *sql.Rows) error {
+	return query(ctx, t.Tx, t.database, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, true)
 	}, q, args...)
 }
@@ -88,7 +90,7 @@ func (t txSession) QueryRowPartialCtx(ctx context.Context, v interface{}, q stri
 		endSpan(span, err)
 	}()
 
-	return query(ctx, t.Tx, func(rows *sql.Rows) error {
+	return query(ctx, t.Tx, t.database, func(rows *sql.Rows) error {
 		return unmarshalRow(v, rows, false)
 	}, q, args...)
 }
@@ -103,7 +105,7 @@ func (t txSession) QueryRowsCtx(ctx context.Context, v interface{}, q string, ar
 		endSpan(span, err)
 	}()
 
-	return query(ctx, t.Tx, func(rows *sql.Rows) error {
+	return query(ctx, t.Tx, t.database, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, true)
 	}, q, args...)
 }
@@ -119,11 +121,28 @@ func (t txSession) QueryRowsPartialCtx(ctx context.Context, v interface{}, q str
 		endSpan(span, err)
 	}()
 
-	return query(ctx, t.Tx, func(rows *sql.Rows) error {
+	return query(ctx, t.Tx, t.database, func(rows *sql.Rows) error {
 		return unmarshalRows(v, rows, false)
 	}, q, args...)
 }
 
+func (t txSession) QueryRowsToMaps(v *[]map[string]interface{}, q string,
+	args ...interface{}) error {
+	return t.QueryRowsToMapsCtx(context.Background(), v, q, args...)
+}
+
+func (t txSession) QueryRowsToMapsCtx(ctx context.Context, v *[]map[string]interface{},
+	q string, args ...interface{}) (err error) {
+	ctx, span := startSpan(ctx, "QueryRowsToMaps")
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	return query(ctx, t.Tx, t.database, func(rows *sql.Rows) error {
+		return unmarshalRowsToMaps(v, rows)
+	}, q, args...)
+}
+
 func begin(db *sql.DB) (trans, error) {
 	tx, err := db.Begin()
 	if err != nil {
@@ -143,16 +162,20 @@ func transact(ctx context.Context, db *commonSqlConn, b beginnable,
 		return err
 	}
 
-	return transactOnConn(ctx, conn, b, fn)
+	return transactOnConn(ctx, conn, db.database, b, fn)
 }
 
-func transactOnConn(ctx context.Context, conn *sql.DB, b beginnable,
+func transactOnConn(ctx context.Context, conn *sql.DB, database string, b beginnable,
 	fn func(context.Context, Session) error) (err error) {
 	var tx trans
 	tx, err = b(conn)
 	if err != nil {
 		return
 	}
+	if ts, ok := tx.(txSession); ok {
+		ts.database = database
+		tx = ts
+	}
 
 	defer func() {
 		if p := recover(); p != nil {
@@ -71,6 +71,14 @@ func (mt *mockTx) QueryRowsPartialCtx(ctx context.Context, v interface{}, query
 	return nil
 }
 
+func (mt *mockTx) QueryRowsToMaps(v *[]map[string]interface{}, q string, args ...interface{}) error {
+	return nil
+}
+
+func (mt *mockTx) QueryRowsToMapsCtx(ctx context.Context, v *[]map[string]interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
 func (mt *mockTx) Rollback() error {
 	mt.status |= mockRollback
 	return nil
@@ -84,7 +92,7 @@ func beginMock(mock *mockTx) beginnable {
 
 func TestTransactCommit(t *testing.T) {
 	mock := &mockTx{}
-	err := transactOnConn(context.Background(), nil, beginMock(mock),
+	err := transactOnConn(context.Background(), nil, defaultDatabaseLabel, beginMock(mock),
 		func(context.Context, Session) error {
 			return nil
 		})
@@ -94,7 +102,7 @@ func TestTransactCommit(t *testing.T) {
 
 func TestTransactRollback(t *testing.T) {
 	mock := &mockTx{}
-	err := transactOnConn(context.Background(), nil, beginMock(mock),
+	err := transactOnConn(context.Background(), nil, defaultDatabaseLabel, beginMock(mock),
 		func(context.Context, Session) error {
 			return errors.New("rollback")
 		})
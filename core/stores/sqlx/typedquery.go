@@ -0,0 +1,39 @@
+package sqlx
+
+import "context"
+
+// QueryRow executes the given query and scans the single result row into a
+// value of type T, which saves the interface{} out-params at call sites.
+func QueryRow[T any](ctx context.Context, sess Session, query string,
+	args ...interface{}) (T, error) {
+	var v T
+	err := sess.QueryRowCtx(ctx, &v, query, args...)
+	return v, err
+}
+
+// QueryRowPartial executes the given query and scans the single result row
+// into a value of type T, without requiring all fields to be matched.
+func QueryRowPartial[T any](ctx context.Context, sess Session, query string,
+	args ...interface{}) (T, error) {
+	var v T
+	err := sess.QueryRowPartialCtx(ctx, &v, query, args...)
+	return v, err
+}
+
+// QueryRows executes the given query and scans the result rows into a slice
+// of type T, which saves the interface{} out-params at call sites.
+func QueryRows[T any](ctx context.Context, sess Session, query string,
+	args ...interface{}) ([]T, error) {
+	var v []T
+	err := sess.QueryRowsCtx(ctx, &v, query, args...)
+	return v, err
+}
+
+// QueryRowsPartial executes the given query and scans the result rows into a
+// slice of type T, without requiring all fields to be matched.
+func QueryRowsPartial[T any](ctx context.Context, sess Session, query string,
+	args ...interface{}) ([]T, error) {
+	var v []T
+	err := sess.QueryRowsPartialCtx(ctx, &v, query, args...)
+	return v, err
+}
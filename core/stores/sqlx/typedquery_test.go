@@ -0,0 +1,74 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedQueryRow(t *testing.T) {
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"name", "age"}).FromCSVString("liao,5")
+		mock.ExpectQuery("select (.+) from users where user=?").WithArgs("anyone").WillReturnRows(rs)
+
+		type user struct {
+			Name string `db:"name"`
+			Age  int    `db:"age"`
+		}
+		value, err := QueryRow[user](context.Background(), NewSqlConnFromDB(db),
+			"select name, age from users where user=?", "anyone")
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "liao", Age: 5}, value)
+	})
+}
+
+func TestTypedQueryRowPartial(t *testing.T) {
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"name"}).FromCSVString("liao")
+		mock.ExpectQuery("select (.+) from users where user=?").WithArgs("anyone").WillReturnRows(rs)
+
+		type user struct {
+			Name string `db:"name"`
+			Age  int    `db:"age"`
+		}
+		value, err := QueryRowPartial[user](context.Background(), NewSqlConnFromDB(db),
+			"select name from users where user=?", "anyone")
+		assert.Nil(t, err)
+		assert.Equal(t, user{Name: "liao"}, value)
+	})
+}
+
+func TestTypedQueryRows(t *testing.T) {
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"name", "age"}).FromCSVString("first,2").AddRow("second", 3)
+		mock.ExpectQuery("select (.+) from users").WillReturnRows(rs)
+
+		type user struct {
+			Name string `db:"name"`
+			Age  int    `db:"age"`
+		}
+		values, err := QueryRows[user](context.Background(), NewSqlConnFromDB(db),
+			"select name, age from users")
+		assert.Nil(t, err)
+		assert.Equal(t, []user{{Name: "first", Age: 2}, {Name: "second", Age: 3}}, values)
+	})
+}
+
+func TestTypedQueryRowsPartial(t *testing.T) {
+	runOrmTest(t, func(db *sql.DB, mock sqlmock.Sqlmock) {
+		rs := sqlmock.NewRows([]string{"name"}).FromCSVString("first")
+		mock.ExpectQuery("select (.+) from users").WillReturnRows(rs)
+
+		type user struct {
+			Name string `db:"name"`
+			Age  int    `db:"age"`
+		}
+		values, err := QueryRowsPartial[user](context.Background(), NewSqlConnFromDB(db),
+			"select name from users")
+		assert.Nil(t, err)
+		assert.Equal(t, []user{{Name: "first"}}, values)
+	})
+}
@@ -1,6 +1,11 @@
 package syncx
 
-import "sync"
+import (
+	"sync"
+	"time"
+
+	"github.com/qkbyte/go-zero/core/timex"
+)
 
 type (
 	// SingleFlight lets the concurrent calls with the same key to share the call result.
@@ -11,7 +16,16 @@ type (
 	// B --------------------->calls F with key------>returns val
 	SingleFlight interface {
 		Do(key string, fn func() (interface{}, error)) (interface{}, error)
+		DoChan(key string, fn func() (interface{}, error)) <-chan Result
 		DoEx(key string, fn func() (interface{}, error)) (interface{}, bool, error)
+		Forget(key string)
+	}
+
+	// A Result is the result of a DoChan call.
+	Result struct {
+		Val   interface{}
+		Err   error
+		Fresh bool
 	}
 
 	call struct {
@@ -20,8 +34,15 @@ type (
 		err error
 	}
 
+	cachedResult struct {
+		val      interface{}
+		expireAt time.Duration
+	}
+
 	flightGroup struct {
 		calls map[string]*call
+		cache map[string]cachedResult
+		ttl   time.Duration
 		lock  sync.Mutex
 	}
 )
@@ -33,7 +54,22 @@ func NewSingleFlight() SingleFlight {
 	}
 }
 
+// NewSingleFlightWithTTL returns a SingleFlight that caches the successful
+// results for ttl, the calls within the window share the cached result
+// without executing fn again.
+func NewSingleFlightWithTTL(ttl time.Duration) SingleFlight {
+	return &flightGroup{
+		calls: make(map[string]*call),
+		cache: make(map[string]cachedResult),
+		ttl:   ttl,
+	}
+}
+
 func (g *flightGroup) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	if val, ok := g.fromCache(key); ok {
+		return val, nil
+	}
+
 	c, done := g.createCall(key)
 	if done {
 		return c.val, c.err
@@ -43,7 +79,25 @@ func (g *flightGroup) Do(key string, fn func() (interface{}, error)) (interface{
 	return c.val, c.err
 }
 
+func (g *flightGroup) DoChan(key string, fn func() (interface{}, error)) <-chan Result {
+	ch := make(chan Result, 1)
+	go func() {
+		val, fresh, err := g.DoEx(key, fn)
+		ch <- Result{
+			Val:   val,
+			Err:   err,
+			Fresh: fresh,
+		}
+	}()
+
+	return ch
+}
+
 func (g *flightGroup) DoEx(key string, fn func() (interface{}, error)) (val interface{}, fresh bool, err error) {
+	if val, ok := g.fromCache(key); ok {
+		return val, false, nil
+	}
+
 	c, done := g.createCall(key)
 	if done {
 		return c.val, false, c.err
@@ -53,6 +107,18 @@ func (g *flightGroup) DoEx(key string, fn func() (interface{}, error)) (val inte
 	return c.val, true, c.err
 }
 
+// Forget drops the cached result of key and lets the future calls
+// execute fn again, the in-flight calls are not affected.
+func (g *flightGroup) Forget(key string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	delete(g.calls, key)
+	if g.cache != nil {
+		delete(g.cache, key)
+	}
+}
+
 func (g *flightGroup) createCall(key string) (c *call, done bool) {
 	g.lock.Lock()
 	if c, ok := g.calls[key]; ok {
@@ -69,6 +135,26 @@ func (g *flightGroup) createCall(key string) (c *call, done bool) {
 	return c, false
 }
 
+func (g *flightGroup) fromCache(key string) (interface{}, bool) {
+	if g.cache == nil {
+		return nil, false
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	result, ok := g.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if timex.Now() >= result.expireAt {
+		delete(g.cache, key)
+		return nil, false
+	}
+
+	return result.val, true
+}
+
 func (g *flightGroup) makeCall(c *call, key string, fn func() (interface{}, error)) {
 	defer func() {
 		g.lock.Lock()
@@ -78,4 +164,12 @@ func (g *flightGroup) makeCall(c *call, key string, fn func() (interface{}, erro
 	}()
 
 	c.val, c.err = fn()
+	if g.cache != nil && c.err == nil {
+		g.lock.Lock()
+		g.cache[key] = cachedResult{
+			val:      c.val,
+			expireAt: timex.Now() + g.ttl,
+		}
+		g.lock.Unlock()
+	}
 }
@@ -7,6 +7,8 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestExclusiveCallDo(t *testing.T) {
@@ -139,3 +141,71 @@ func TestExclusiveCallDoExDupSuppress(t *testing.T) {
 		t.Errorf("freshes = %d; want 1", got)
 	}
 }
+
+func TestDoChan(t *testing.T) {
+	g := NewSingleFlight()
+	ch := g.DoChan("key", func() (interface{}, error) {
+		return "bar", nil
+	})
+
+	result := <-ch
+	assert.Nil(t, result.Err)
+	assert.True(t, result.Fresh)
+	assert.Equal(t, "bar", result.Val)
+}
+
+func TestDoChanErr(t *testing.T) {
+	g := NewSingleFlight()
+	someErr := errors.New("some error")
+	ch := g.DoChan("key", func() (interface{}, error) {
+		return nil, someErr
+	})
+
+	result := <-ch
+	assert.Equal(t, someErr, result.Err)
+}
+
+func TestDoWithTTL(t *testing.T) {
+	g := NewSingleFlightWithTTL(time.Minute)
+	var calls int32
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "bar", nil
+	}
+
+	for i := 0; i < 10; i++ {
+		val, err := g.Do("key", fn)
+		assert.Nil(t, err)
+		assert.Equal(t, "bar", val)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// errors are not cached
+	errCalls := int32(0)
+	someErr := errors.New("some error")
+	errFn := func() (interface{}, error) {
+		atomic.AddInt32(&errCalls, 1)
+		return nil, someErr
+	}
+	for i := 0; i < 3; i++ {
+		_, err := g.Do("errKey", errFn)
+		assert.Equal(t, someErr, err)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&errCalls))
+}
+
+func TestForget(t *testing.T) {
+	g := NewSingleFlightWithTTL(time.Minute)
+	var calls int32
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "bar", nil
+	}
+
+	_, err := g.Do("key", fn)
+	assert.Nil(t, err)
+	g.Forget("key")
+	_, err = g.Do("key", fn)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,115 @@
+package threading
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPoolStopped is an error that indicates the worker pool is stopped.
+	ErrPoolStopped = errors.New("worker pool is stopped")
+	// ErrSubmitTimeout is an error that indicates the submit timed out.
+	ErrSubmitTimeout = errors.New("submit to worker pool timed out")
+)
+
+// A WorkerPool runs tasks on a fixed set of workers with a bounded queue,
+// panics in tasks are recovered, and Stop drains the queued tasks.
+type WorkerPool struct {
+	tasks     chan func()
+	workers   int
+	waitGroup sync.WaitGroup
+	lock      sync.RWMutex
+	stopped   bool
+}
+
+// NewWorkerPool returns a WorkerPool with the given number of workers
+// and the given queue size.
+func NewWorkerPool(workers, queueSize int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	pool := &WorkerPool{
+		tasks:   make(chan func(), queueSize),
+		workers: workers,
+	}
+	pool.waitGroup.Add(workers)
+	for i := 0; i < workers; i++ {
+		go pool.work()
+	}
+
+	return pool
+}
+
+// QueueDepth returns the number of queued tasks,
+// report it as a gauge to watch the backlog of the pool.
+func (p *WorkerPool) QueueDepth() int {
+	return len(p.tasks)
+}
+
+// Stop stops accepting tasks, drains the queued tasks
+// and waits for the running ones to finish.
+func (p *WorkerPool) Stop() {
+	p.lock.Lock()
+	if p.stopped {
+		p.lock.Unlock()
+		return
+	}
+	p.stopped = true
+	close(p.tasks)
+	p.lock.Unlock()
+
+	p.waitGroup.Wait()
+}
+
+// Submit submits task to p, blocks until the task is queued,
+// returns ErrPoolStopped if p is stopped.
+func (p *WorkerPool) Submit(task func()) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.stopped {
+		return ErrPoolStopped
+	}
+
+	p.tasks <- task
+	return nil
+}
+
+// SubmitWithTimeout submits task to p, returns ErrSubmitTimeout
+// if the queue is still full after timeout.
+func (p *WorkerPool) SubmitWithTimeout(task func(), timeout time.Duration) error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.stopped {
+		return ErrPoolStopped
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-timer.C:
+		return ErrSubmitTimeout
+	}
+}
+
+// Workers returns the number of workers in p.
+func (p *WorkerPool) Workers() int {
+	return p.workers
+}
+
+func (p *WorkerPool) work() {
+	defer p.waitGroup.Done()
+
+	for task := range p.tasks {
+		RunSafe(task)
+	}
+}
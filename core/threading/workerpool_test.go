@@ -0,0 +1,91 @@
+package threading
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	pool := NewWorkerPool(4, 16)
+	assert.Equal(t, 4, pool.Workers())
+
+	var count int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		assert.Nil(t, pool.Submit(func() {
+			atomic.AddInt32(&count, 1)
+			wg.Done()
+		}))
+	}
+	wg.Wait()
+	assert.Equal(t, int32(100), atomic.LoadInt32(&count))
+	pool.Stop()
+}
+
+func TestWorkerPoolStopDrains(t *testing.T) {
+	pool := NewWorkerPool(1, 16)
+	var count int32
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, pool.Submit(func() {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&count, 1)
+		}))
+	}
+
+	pool.Stop()
+	assert.Equal(t, int32(10), atomic.LoadInt32(&count))
+	assert.Equal(t, ErrPoolStopped, pool.Submit(func() {}))
+	// stopping twice is fine
+	pool.Stop()
+}
+
+func TestWorkerPoolSubmitWithTimeout(t *testing.T) {
+	pool := NewWorkerPool(1, 0)
+	block := make(chan struct{})
+	assert.Nil(t, pool.Submit(func() {
+		<-block
+	}))
+
+	assert.Equal(t, ErrSubmitTimeout, pool.SubmitWithTimeout(func() {}, time.Millisecond*10))
+	close(block)
+	pool.Stop()
+	assert.Equal(t, ErrPoolStopped, pool.SubmitWithTimeout(func() {}, time.Millisecond))
+}
+
+func TestWorkerPoolPanicRecovered(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+	assert.Nil(t, pool.Submit(func() {
+		panic("mock panic")
+	}))
+
+	var done int32
+	assert.Nil(t, pool.Submit(func() {
+		atomic.StoreInt32(&done, 1)
+	}))
+	pool.Stop()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&done))
+}
+
+func TestWorkerPoolQueueDepth(t *testing.T) {
+	pool := NewWorkerPool(1, 8)
+	block := make(chan struct{})
+	started := make(chan struct{})
+	assert.Nil(t, pool.Submit(func() {
+		close(started)
+		<-block
+	}))
+	// make sure the worker picked up the blocking task
+	<-started
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, pool.Submit(func() {}))
+	}
+
+	assert.Equal(t, 4, pool.QueueDepth())
+	close(block)
+	pool.Stop()
+}
@@ -1,6 +1,7 @@
 package trace
 
 import (
+	"path"
 	"testing"
 
 	"github.com/qkbyte/go-zero/core/logx"
@@ -58,3 +59,33 @@ func TestStartAgent(t *testing.T) {
 	_, ok = agents[endpoint2]
 	assert.False(t, ok)
 }
+
+func TestCreateExporter(t *testing.T) {
+	_, err := createExporter(Config{Batcher: kindConsole})
+	assert.NoError(t, err)
+
+	_, err = createExporter(Config{
+		Batcher:  kindFile,
+		Endpoint: path.Join(t.TempDir(), "traces.log"),
+	})
+	assert.NoError(t, err)
+
+	_, err = createExporter(Config{
+		Batcher:  kindFile,
+		Endpoint: path.Join(t.TempDir(), "not-exist", "traces.log"),
+	})
+	assert.Error(t, err)
+
+	_, err = createExporter(Config{
+		Batcher:      kindOtlpHttp,
+		Endpoint:     "localhost:4318",
+		OtlpHttpPath: "/v1/traces",
+		OtlpHeaders: map[string]string{
+			"uptrace-dsn": "http://token@localhost:14317/2",
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = createExporter(Config{Batcher: "bad"})
+	assert.Error(t, err)
+}
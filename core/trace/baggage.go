@@ -0,0 +1,77 @@
+package trace
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// An AttributeExtractor extracts span attributes from ctx,
+// like tenant or user ids carried by the business context.
+type AttributeExtractor func(ctx context.Context) []attribute.KeyValue
+
+var (
+	extractorLock sync.RWMutex
+	extractors    []AttributeExtractor
+)
+
+// RegisterAttributeExtractor registers extractor, the attributes it returns
+// are attached to every span created by the builtin instrumentation.
+func RegisterAttributeExtractor(extractor AttributeExtractor) {
+	extractorLock.Lock()
+	defer extractorLock.Unlock()
+	extractors = append(extractors, extractor)
+}
+
+// ContextAttributes returns the attributes extracted from ctx
+// by the registered extractors.
+func ContextAttributes(ctx context.Context) []attribute.KeyValue {
+	extractorLock.RLock()
+	fns := extractors
+	extractorLock.RUnlock()
+
+	var attrs []attribute.KeyValue
+	for _, fn := range fns {
+		attrs = append(attrs, fn(ctx)...)
+	}
+
+	return attrs
+}
+
+// BaggageAttributeExtractor returns an AttributeExtractor that attaches
+// the baggage values with the given keys as span attributes.
+func BaggageAttributeExtractor(keys ...string) AttributeExtractor {
+	return func(ctx context.Context) []attribute.KeyValue {
+		var attrs []attribute.KeyValue
+		for _, key := range keys {
+			if value := BaggageValue(ctx, key); len(value) > 0 {
+				attrs = append(attrs, attribute.String(key, value))
+			}
+		}
+
+		return attrs
+	}
+}
+
+// BaggageValue returns the baggage value with the given key from ctx.
+func BaggageValue(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
+
+// SetBaggage returns a copy of ctx with the given key value pair
+// set in its baggage, the baggage is propagated to downstream services.
+func SetBaggage(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMember(key, value)
+	if err != nil {
+		return ctx, err
+	}
+
+	bags, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, err
+	}
+
+	return baggage.ContextWithBaggage(ctx, bags), nil
+}
@@ -0,0 +1,43 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSetBaggage(t *testing.T) {
+	ctx, err := SetBaggage(context.Background(), "tenant_id", "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", BaggageValue(ctx, "tenant_id"))
+	assert.Empty(t, BaggageValue(ctx, "user_id"))
+
+	// overwrite the existing member
+	ctx, err = SetBaggage(ctx, "tenant_id", "bar")
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", BaggageValue(ctx, "tenant_id"))
+
+	_, err = SetBaggage(ctx, "bad key", "foo")
+	assert.Error(t, err)
+}
+
+func TestContextAttributes(t *testing.T) {
+	assert.Empty(t, ContextAttributes(context.Background()))
+
+	RegisterAttributeExtractor(func(_ context.Context) []attribute.KeyValue {
+		return []attribute.KeyValue{attribute.String("foo", "bar")}
+	})
+	attrs := ContextAttributes(context.Background())
+	assert.Contains(t, attrs, attribute.String("foo", "bar"))
+}
+
+func TestBaggageAttributeExtractor(t *testing.T) {
+	extractor := BaggageAttributeExtractor("tenant_id", "user_id")
+	assert.Empty(t, extractor(context.Background()))
+
+	ctx, err := SetBaggage(context.Background(), "tenant_id", "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("tenant_id", "foo")}, extractor(ctx))
+}
@@ -8,5 +8,20 @@ type Config struct {
 	Name     string  `json:",optional"`
 	Endpoint string  `json:",optional"`
 	Sampler  float64 `json:",default=1.0"`
-	Batcher  string  `json:",default=jaeger,options=jaeger|zipkin|grpc"`
+	Batcher  string  `json:",default=jaeger,options=jaeger|zipkin|grpc|otlphttp|console|file"`
+	// OtlpHeaders represents the headers for otlp grpc and http exporters.
+	OtlpHeaders map[string]string `json:",optional"`
+	// OtlpHttpPath represents the path for otlp http exporter, e.g. /v1/traces.
+	OtlpHttpPath string `json:",optional"`
+	// OtlpHttpSecure represents whether to use https for otlp http exporter.
+	OtlpHttpSecure bool `json:",optional"`
+	// SamplerType decides how spans without a sampled parent are sampled,
+	// whatever the type, the final sampler is parent based.
+	// With ratio, Sampler is the fraction of traces to sample,
+	// with ratelimited, Sampler is the max number of traces sampled per second.
+	SamplerType string `json:",default=ratio,options=always|never|ratio|ratelimited"`
+	// RouteSamplers overrides the sampling ratio per span name,
+	// for rest servers the span name is the route path,
+	// for zrpc servers the span name is the full rpc method.
+	RouteSamplers map[string]float64 `json:",optional"`
 }
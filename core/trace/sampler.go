@@ -0,0 +1,101 @@
+package trace
+
+import (
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+const (
+	samplerAlways      = "always"
+	samplerNever       = "never"
+	samplerRateLimited = "ratelimited"
+)
+
+// buildSampler builds the sampler described by c,
+// the returned sampler is always parent based.
+func buildSampler(c Config) sdktrace.Sampler {
+	var root sdktrace.Sampler
+	switch c.SamplerType {
+	case samplerAlways:
+		root = sdktrace.AlwaysSample()
+	case samplerNever:
+		root = sdktrace.NeverSample()
+	case samplerRateLimited:
+		root = newRateLimitedSampler(c.Sampler)
+	default:
+		root = sdktrace.TraceIDRatioBased(c.Sampler)
+	}
+
+	if len(c.RouteSamplers) > 0 {
+		root = newRouteSampler(root, c.RouteSamplers)
+	}
+
+	return sdktrace.ParentBased(root)
+}
+
+// A rateLimitedSampler samples at most a given number of traces per second.
+type rateLimitedSampler struct {
+	limiter     *rate.Limiter
+	description string
+}
+
+func newRateLimitedSampler(perSecond float64) sdktrace.Sampler {
+	burst := int(perSecond)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimitedSampler{
+		limiter:     rate.NewLimiter(rate.Limit(perSecond), burst),
+		description: fmt.Sprintf("RateLimited{%v}", perSecond),
+	}
+}
+
+func (s *rateLimitedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	decision := sdktrace.Drop
+	if s.limiter.Allow() {
+		decision = sdktrace.RecordAndSample
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: oteltrace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (s *rateLimitedSampler) Description() string {
+	return s.description
+}
+
+// A routeSampler samples by span name first, and falls back to a default sampler.
+type routeSampler struct {
+	fallback sdktrace.Sampler
+	routes   map[string]sdktrace.Sampler
+}
+
+func newRouteSampler(fallback sdktrace.Sampler, routes map[string]float64) sdktrace.Sampler {
+	samplers := make(map[string]sdktrace.Sampler, len(routes))
+	for name, ratio := range routes {
+		samplers[name] = sdktrace.TraceIDRatioBased(ratio)
+	}
+
+	return &routeSampler{
+		fallback: fallback,
+		routes:   samplers,
+	}
+}
+
+func (s *routeSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if sampler, ok := s.routes[p.Name]; ok {
+		return sampler.ShouldSample(p)
+	}
+
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *routeSampler) Description() string {
+	return fmt.Sprintf("Route{fallback=%s}", s.fallback.Description())
+}
@@ -0,0 +1,65 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestBuildSampler(t *testing.T) {
+	tests := []struct {
+		samplerType string
+		contains    string
+	}{
+		{samplerAlways, "AlwaysOnSampler"},
+		{samplerNever, "AlwaysOffSampler"},
+		{samplerRateLimited, "RateLimited"},
+		{"", "TraceIDRatioBased"},
+		{"ratio", "TraceIDRatioBased"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.samplerType, func(t *testing.T) {
+			sampler := buildSampler(Config{
+				Sampler:     0.5,
+				SamplerType: test.samplerType,
+			})
+			assert.Contains(t, sampler.Description(), "ParentBased")
+			assert.Contains(t, sampler.Description(), test.contains)
+		})
+	}
+}
+
+func TestRateLimitedSampler(t *testing.T) {
+	sampler := newRateLimitedSampler(1)
+	params := sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          "foo",
+	}
+	assert.Equal(t, sdktrace.RecordAndSample, sampler.ShouldSample(params).Decision)
+	// burst of one, the second immediate span is dropped.
+	assert.Equal(t, sdktrace.Drop, sampler.ShouldSample(params).Decision)
+	assert.Contains(t, sampler.Description(), "RateLimited")
+}
+
+func TestRouteSampler(t *testing.T) {
+	sampler := buildSampler(Config{
+		SamplerType: samplerNever,
+		RouteSamplers: map[string]float64{
+			"/ping": 1.0,
+		},
+	})
+
+	sampled := sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          "/ping",
+	}
+	dropped := sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          "/users",
+	}
+	assert.Equal(t, sdktrace.RecordAndSample, sampler.ShouldSample(sampled).Decision)
+	assert.Equal(t, sdktrace.Drop, sampler.ShouldSample(dropped).Decision)
+}
@@ -0,0 +1,13 @@
+package devserver
+
+// Config is config for inner http server.
+type Config struct {
+	Enabled       bool   `json:",optional"`
+	Host          string `json:",optional"`
+	Port          int    `json:",default=6060"`
+	MetricsPath   string `json:",default=/metrics"`
+	HealthPath    string `json:",default=/healthz"`
+	ReadyPath     string `json:",default=/readyz"`
+	EnableMetrics bool   `json:",default=true"`
+	EnablePprof   bool   `json:",default=true"`
+}
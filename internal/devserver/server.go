@@ -0,0 +1,96 @@
+// Package devserver serves an opt-in internal http listener with pprof,
+// expvar, health and prometheus metrics endpoints on a separate port,
+// so business services don't have to wire up the profiling handlers
+// themselves or expose them on the public port.
+package devserver
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/qkbyte/go-zero/core/health"
+	"github.com/qkbyte/go-zero/core/logx"
+	"github.com/qkbyte/go-zero/core/threading"
+)
+
+var once sync.Once
+
+// Server is an inner http server, expose some useful observability information of app.
+// For example, health check, metrics and pprof.
+type Server struct {
+	config Config
+	server *http.ServeMux
+	routes []string
+}
+
+// NewServer returns a new inner http Server.
+func NewServer(config Config) *Server {
+	return &Server{
+		config: config,
+		server: http.NewServeMux(),
+	}
+}
+
+func (s *Server) addRoutes() {
+	// route path, routes list
+	s.handleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(s.routes)
+	})
+
+	// health, see core/health
+	s.handle(s.config.HealthPath, health.LiveHandler())
+	s.handle(s.config.ReadyPath, health.ReadyHandler())
+
+	// expvar
+	s.handle("/debug/vars", expvar.Handler())
+
+	// metrics
+	if s.config.EnableMetrics {
+		s.handle(s.config.MetricsPath, promhttp.Handler())
+	}
+
+	// pprof
+	if s.config.EnablePprof {
+		s.handleFunc("/debug/pprof/", pprof.Index)
+		s.handleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		s.handleFunc("/debug/pprof/profile", pprof.Profile)
+		s.handleFunc("/debug/pprof/symbol", pprof.Symbol)
+		s.handleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+}
+
+func (s *Server) handle(pattern string, handler http.Handler) {
+	s.routes = append(s.routes, pattern)
+	s.server.Handle(pattern, handler)
+}
+
+func (s *Server) handleFunc(pattern string, handler http.HandlerFunc) {
+	s.handle(pattern, handler)
+}
+
+// StartAsync start inner http server background.
+func (s *Server) StartAsync() {
+	s.addRoutes()
+	threading.GoSafe(func() {
+		addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+		logx.Infof("Starting dev http server at %s", addr)
+		if err := http.ListenAndServe(addr, s.server); err != nil {
+			logx.Error(err)
+		}
+	})
+}
+
+// StartAgent start inner http server by config.
+func StartAgent(c Config) {
+	once.Do(func() {
+		if c.Enabled {
+			s := NewServer(c)
+			s.StartAsync()
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package devserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/qkbyte/go-zero/core/conf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerRoutes(t *testing.T) {
+	var c Config
+	assert.Nil(t, conf.LoadFromJsonBytes([]byte(`{}`), &c))
+	assert.Equal(t, 6060, c.Port)
+
+	s := NewServer(c)
+	s.addRoutes()
+
+	paths := []string{"/", c.HealthPath, c.ReadyPath, "/debug/vars", c.MetricsPath, "/debug/pprof/"}
+	for _, path := range paths {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		resp := httptest.NewRecorder()
+		s.server.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code, path)
+	}
+
+	// the root path lists the registered routes
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp := httptest.NewRecorder()
+	s.server.ServeHTTP(resp, req)
+	assert.Contains(t, resp.Body.String(), c.MetricsPath)
+	assert.Contains(t, resp.Body.String(), "/debug/pprof/")
+}
+
+func TestServerPprofDisabled(t *testing.T) {
+	var c Config
+	assert.Nil(t, conf.LoadFromJsonBytes([]byte(`{"EnablePprof": false, "EnableMetrics": false}`), &c))
+
+	s := NewServer(c)
+	s.addRoutes()
+
+	// unregistered paths fall through to the route list
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp := httptest.NewRecorder()
+	s.server.ServeHTTP(resp, req)
+	assert.NotContains(t, resp.Body.String(), "/debug/pprof/")
+	assert.NotContains(t, resp.Body.String(), c.MetricsPath)
+}
@@ -37,8 +37,13 @@ type (
 		MaxConns int    `json:",default=10000"`
 		MaxBytes int64  `json:",default=1048576"`
 		// milliseconds
-		Timeout      int64         `json:",default=3000"`
-		CpuThreshold int64         `json:",default=900,range=[0:1000]"`
-		Signature    SignatureConf `json:",optional"`
+		Timeout      int64 `json:",default=3000"`
+		CpuThreshold int64 `json:",default=900,range=[0:1000]"`
+		// rolling window of the load shedder, defaults apply when zero
+		ShedWindow  time.Duration `json:",optional"`
+		ShedBuckets int           `json:",optional"`
+		// serve /healthz and /readyz from the health check registry
+		Health    bool          `json:",optional"`
+		Signature SignatureConf `json:",optional"`
 	}
 )
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/qkbyte/go-zero/core/codec"
+	"github.com/qkbyte/go-zero/core/health"
 	"github.com/qkbyte/go-zero/core/load"
 	"github.com/qkbyte/go-zero/core/stat"
 	"github.com/qkbyte/go-zero/rest/chain"
@@ -18,8 +19,13 @@ import (
 	"github.com/qkbyte/go-zero/rest/internal/response"
 )
 
-// use 1000m to represent 100%
-const topCpuUsage = 1000
+const (
+	// use 1000m to represent 100%
+	topCpuUsage = 1000
+	// paths of the health check endpoints, see core/health
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+)
 
 // ErrSignatureConfig is an error that indicates bad config for signature.
 var ErrSignatureConfig = errors.New("bad config for Signature")
@@ -41,9 +47,18 @@ func newEngine(c RestConf) *engine {
 		conf: c,
 	}
 	if c.CpuThreshold > 0 {
-		svr.shedder = load.NewAdaptiveShedder(load.WithCpuThreshold(c.CpuThreshold))
-		svr.priorityShedder = load.NewAdaptiveShedder(load.WithCpuThreshold(
-			(c.CpuThreshold + topCpuUsage) >> 1))
+		var opts []load.ShedderOption
+		if c.ShedWindow > 0 {
+			opts = append(opts, load.WithWindow(c.ShedWindow))
+		}
+		if c.ShedBuckets > 0 {
+			opts = append(opts, load.WithBuckets(c.ShedBuckets))
+		}
+		svr.shedder = load.NewAdaptiveShedder(append(opts,
+			load.WithCpuThreshold(c.CpuThreshold))...)
+		svr.priorityShedder = load.NewAdaptiveShedder(append(opts,
+			load.WithName("priority"),
+			load.WithCpuThreshold((c.CpuThreshold+topCpuUsage)>>1))...)
 	}
 
 	return svr
@@ -113,6 +128,14 @@ func (ng *engine) bindRoute(fr featuredRoutes, router httpx.Router, metrics *sta
 	return router.HandleMethod(route.Method, route.Path, handle)
 }
 
+func (ng *engine) bindHealthRoutes(router httpx.Router) error {
+	if err := router.HandleMethod(http.MethodGet, healthzPath, health.LiveHandler()); err != nil {
+		return err
+	}
+
+	return router.HandleMethod(http.MethodGet, readyzPath, health.ReadyHandler())
+}
+
 func (ng *engine) bindRoutes(router httpx.Router) error {
 	metrics := ng.createMetrics()
 
@@ -263,6 +286,12 @@ func (ng *engine) start(router httpx.Router) error {
 		return err
 	}
 
+	if ng.conf.Health {
+		if err := ng.bindHealthRoutes(router); err != nil {
+			return err
+		}
+	}
+
 	if len(ng.conf.CertFile) == 0 && len(ng.conf.KeyFile) == 0 {
 		return internal.StartHttp(ng.conf.Host, ng.conf.Port, router, ng.withTimeout())
 	}
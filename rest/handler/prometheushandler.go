@@ -38,7 +38,7 @@ func PrometheusHandler(path string) func(http.Handler) http.Handler {
 			startTime := timex.Now()
 			cw := &response.WithCodeResponseWriter{Writer: w}
 			defer func() {
-				metricServerReqDur.Observe(int64(timex.Since(startTime)/time.Millisecond), path)
+				metricServerReqDur.ObserveCtx(r.Context(), int64(timex.Since(startTime)/time.Millisecond), path)
 				metricServerReqCodeTotal.Inc(path, strconv.Itoa(cw.Code))
 			}()
 
@@ -28,6 +28,7 @@ func TracingHandler(serviceName, path string) func(http.Handler) http.Handler {
 				oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 				oteltrace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(
 					serviceName, spanName, r)...),
+				oteltrace.WithAttributes(trace.ContextAttributes(ctx)...),
 			)
 			defer span.End()
 
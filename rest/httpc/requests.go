@@ -166,6 +166,7 @@ func request(r *http.Request, cli client) (*http.Response, error) {
 		spanName,
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
 		oteltrace.WithAttributes(semconv.HTTPClientAttributesFromHTTPRequest(r)...),
+		oteltrace.WithAttributes(trace.ContextAttributes(r.Context())...),
 	)
 	defer span.End()
 
@@ -1,10 +1,10 @@
 package httpx
 
 import (
-	"encoding/json"
 	"net/http"
 	"sync"
 
+	"github.com/qkbyte/go-zero/core/jsonx"
 	"github.com/qkbyte/go-zero/core/logx"
 	"github.com/qkbyte/go-zero/rest/internal/errcode"
 	"github.com/qkbyte/go-zero/rest/internal/header"
@@ -68,7 +68,7 @@ func SetErrorHandler(handler func(error) (int, interface{})) {
 
 // WriteJson writes v as json string into w with code.
 func WriteJson(w http.ResponseWriter, code int, v interface{}) {
-	bs, err := json.Marshal(v)
+	bs, err := jsonx.Marshal(v)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1,6 +1,8 @@
 package zrpc
 
 import (
+	"time"
+
 	"github.com/qkbyte/go-zero/core/discov"
 	"github.com/qkbyte/go-zero/core/service"
 	"github.com/qkbyte/go-zero/core/stores/redis"
@@ -19,6 +21,9 @@ type (
 		// setting 0 means no timeout
 		Timeout      int64 `json:",default=2000"`
 		CpuThreshold int64 `json:",default=900,range=[0:1000]"`
+		// rolling window of the load shedder, defaults apply when zero
+		ShedWindow  time.Duration `json:",optional"`
+		ShedBuckets int           `json:",optional"`
 		// grpc health check switch
 		Health bool `json:",default=true"`
 	}
@@ -159,6 +159,7 @@ func startSpan(ctx context.Context, method, target string) (context.Context, tra
 	}
 	tr := otel.Tracer(ztrace.TraceName)
 	name, attr := ztrace.SpanInfo(method, target)
+	attr = append(attr, ztrace.ContextAttributes(ctx)...)
 	ctx, span := tr.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(attr...))
 	ztrace.Inject(ctx, otel.GetTextMapPropagator(), &md)
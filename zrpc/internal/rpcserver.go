@@ -2,14 +2,21 @@ package internal
 
 import (
 	"net"
+	"time"
 
+	"github.com/qkbyte/go-zero/core/health"
+	"github.com/qkbyte/go-zero/core/lang"
 	"github.com/qkbyte/go-zero/core/proc"
 	"github.com/qkbyte/go-zero/core/stat"
+	"github.com/qkbyte/go-zero/core/threading"
 	"github.com/qkbyte/go-zero/zrpc/internal/serverinterceptors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// healthCheckInterval is how often the health service reflects the registry.
+const healthCheckInterval = time.Second * 5
+
 type (
 	// ServerOption defines the method to customize a rpcServerOptions.
 	ServerOption func(options *rpcServerOptions)
@@ -78,6 +85,9 @@ func (s *rpcServer) Start(register RegisterFn) error {
 	if s.health != nil {
 		grpc_health_v1.RegisterHealthServer(server, s.health)
 		s.health.Resume()
+		// reflect the readiness probes of the health check registry,
+		// Shutdown below makes the health server ignore further updates.
+		defer s.monitorHealth()()
 	}
 
 	// we need to make sure all others are wrapped up,
@@ -93,6 +103,33 @@ func (s *rpcServer) Start(register RegisterFn) error {
 	return server.Serve(lis)
 }
 
+// monitorHealth periodically updates the grpc health service with the state
+// of the health check registry, the returned func stops the monitoring.
+func (s *rpcServer) monitorHealth() func() {
+	done := make(chan lang.PlaceholderType)
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := health.CheckReady(); err != nil {
+					s.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+				} else {
+					s.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+				}
+			}
+		}
+	})
+
+	return func() {
+		close(done)
+	}
+}
+
 // WithMetrics returns a func that sets metrics to a Server.
 func WithMetrics(metrics *stat.Metrics) ServerOption {
 	return func(options *rpcServerOptions) {
@@ -37,7 +37,7 @@ func UnaryPrometheusInterceptor(ctx context.Context, req interface{},
 	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	startTime := timex.Now()
 	resp, err := handler(ctx, req)
-	metricServerReqDur.Observe(int64(timex.Since(startTime)/time.Millisecond), info.FullMethod)
+	metricServerReqDur.ObserveCtx(ctx, int64(timex.Since(startTime)/time.Millisecond), info.FullMethod)
 	metricServerReqCodeTotal.Inc(info.FullMethod, strconv.Itoa(int(status.Code(err))))
 	return resp, err
 }
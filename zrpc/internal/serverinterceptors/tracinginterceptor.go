@@ -101,6 +101,7 @@ func startSpan(ctx context.Context, method string) (context.Context, trace.Span)
 	ctx = baggage.ContextWithBaggage(ctx, bags)
 	tr := otel.Tracer(ztrace.TraceName)
 	name, attr := ztrace.SpanInfo(method, ztrace.PeerFromCtx(ctx))
+	attr = append(attr, ztrace.ContextAttributes(ctx)...)
 
 	return tr.Start(trace.ContextWithRemoteSpanContext(ctx, spanCtx), name,
 		trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attr...))
@@ -110,7 +110,14 @@ func SetServerSlowThreshold(threshold time.Duration) {
 
 func setupInterceptors(server internal.Server, c RpcServerConf, metrics *stat.Metrics) error {
 	if c.CpuThreshold > 0 {
-		shedder := load.NewAdaptiveShedder(load.WithCpuThreshold(c.CpuThreshold))
+		opts := []load.ShedderOption{load.WithCpuThreshold(c.CpuThreshold)}
+		if c.ShedWindow > 0 {
+			opts = append(opts, load.WithWindow(c.ShedWindow))
+		}
+		if c.ShedBuckets > 0 {
+			opts = append(opts, load.WithBuckets(c.ShedBuckets))
+		}
+		shedder := load.NewAdaptiveShedder(opts...)
 		server.AddUnaryInterceptors(serverinterceptors.UnarySheddingInterceptor(shedder, metrics))
 	}
 